@@ -0,0 +1,8 @@
+// Package openapi embeds the OpenAPI description of the HTTP gateway, which is
+// generated from the proto together with the gateway itself.
+package openapi
+
+import _ "embed"
+
+//go:embed risk_engine.swagger.json
+var Spec []byte
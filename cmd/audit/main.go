@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/audit"
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/labels"
+)
+
+const usage = `usage:
+  audit verify -dir <audit dir>
+  audit export -dir <audit dir> [-from RFC3339] [-to RFC3339]
+  audit dataset -dir <audit dir> -labels <labels file> [-from RFC3339] [-to RFC3339] [-labeled-only]`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "verify":
+		err = runVerify(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	case "dataset":
+		err = runDataset(os.Args[2:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	dir := fs.String("dir", "audit", "audit segment directory")
+	_ = fs.Parse(args)
+
+	n, err := audit.Verify(audit.ReadDir(*dir))
+	if err != nil {
+		return fmt.Errorf("verification failed after %d entries: %w", n, err)
+	}
+
+	fmt.Printf("audit chain OK: %d entries verified\n", n)
+	return nil
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	dir := fs.String("dir", "audit", "audit segment directory")
+	fromStr := fs.String("from", "", "include entries at or after this RFC3339 time")
+	toStr := fs.String("to", "", "include entries before this RFC3339 time")
+	_ = fs.Parse(args)
+
+	from, err := parseTime(*fromStr)
+	if err != nil {
+		return fmt.Errorf("invalid -from: %w", err)
+	}
+	to, err := parseTime(*toStr)
+	if err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for e, err := range audit.ReadDir(*dir) {
+		if err != nil {
+			return err
+		}
+		if !from.IsZero() && e.Timestamp.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !e.Timestamp.Before(to) {
+			continue
+		}
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// example is an audited decision together with the outcome later reported
+// for its transaction, if any.
+type example struct {
+	audit.Entry
+	Label *domain.Label `json:"label"`
+}
+
+// runDataset writes the audited decisions joined with their outcome labels,
+// one JSON object per line, for backtesting and model training.
+func runDataset(args []string) error {
+	fs := flag.NewFlagSet("dataset", flag.ExitOnError)
+	dir := fs.String("dir", "audit", "audit segment directory")
+	labelsPath := fs.String("labels", "labels.jsonl", "outcome labels file")
+	fromStr := fs.String("from", "", "include decisions at or after this RFC3339 time")
+	toStr := fs.String("to", "", "include decisions before this RFC3339 time")
+	labeledOnly := fs.Bool("labeled-only", false, "skip decisions without a label")
+	_ = fs.Parse(args)
+
+	from, err := parseTime(*fromStr)
+	if err != nil {
+		return fmt.Errorf("invalid -from: %w", err)
+	}
+	to, err := parseTime(*toStr)
+	if err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+
+	all, err := labels.ReadFile(*labelsPath)
+	if err != nil {
+		return err
+	}
+	byTx := make(map[string]domain.Label, len(all))
+	for _, l := range all {
+		byTx[labels.Key(l.TenantID, l.TransactionID)] = l
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for e, err := range audit.ReadDir(*dir) {
+		if err != nil {
+			return err
+		}
+		if !from.IsZero() && e.Timestamp.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !e.Timestamp.Before(to) {
+			continue
+		}
+
+		ex := example{Entry: e}
+		if l, ok := byTx[labels.Key(e.Input.Transaction.TenantID, e.TransactionID)]; ok {
+			l.Decision = nil
+			ex.Label = &l
+		} else if *labeledOnly {
+			continue
+		}
+		if err := enc.Encode(ex); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/joho/godotenv"
 	"github.com/tokyosplif/ai-risk-engine/internal/app"
@@ -11,18 +15,43 @@ import (
 
 func main() {
 	_ = godotenv.Load()
-	cfg := config.Load()
 
-	logger.Setup(cfg.LogLevel)
+	flags, err := config.ParseFlags(os.Args[0], os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(flags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(1)
+	}
 
-	if cfg.Groq.APIKey == "" {
-		slog.Error("CRITICAL: GROQ_API_KEY is missing")
+	if flags.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
 		return
 	}
 
-	slog.Info("Starting AI Risk Engine", "env", "prod", "model", cfg.Groq.Model)
+	logger.Setup(logger.Options{
+		Level:           cfg.Log.Level,
+		Format:          cfg.Log.Format,
+		DebugSampleRate: cfg.Log.DebugSampleRate,
+	})
+
+	if cfg.Groq.APIKey == "" && cfg.Groq.APIKeyFile == "" {
+		slog.Error("GROQ_API_KEY and GROQ_API_KEY_FILE are missing, the engine will report not ready")
+	}
+
+	slog.Info("Starting AI Risk Engine", "env", "prod", "model", cfg.Groq.Model, "config", flags.ConfigPath)
 
-	if err := app.RunServer(cfg); err != nil {
+	if err := app.RunServer(cfg, flags); err != nil {
 		slog.Error("Application failed", "error", err)
+		os.Exit(1)
 	}
 }
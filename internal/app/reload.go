@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/config"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+	"github.com/tokyosplif/ai-risk-engine/pkg/logger"
+	"github.com/tokyosplif/ai-risk-engine/pkg/watch"
+)
+
+// reloader applies the configuration that is safe to change at runtime when
+// the config file or the tenants file is written or replaced: the log level, the
+// engine-wide thresholds and each tenant's thresholds, rules, prescreen
+// checks and degradation policy. Other changes are reported as needing a
+// restart and are not applied.
+type reloader struct {
+	flags   *config.Flags
+	cfg     config.Config
+	tenants *tenantSet
+}
+
+func newReloader(flags *config.Flags, cfg *config.Config, tenants *tenantSet) *reloader {
+	return &reloader{flags: flags, cfg: *cfg, tenants: tenants}
+}
+
+// Watch reloads each time a watched file is written or replaced until ctx
+// is done.
+func (r *reloader) Watch(ctx context.Context) {
+	paths := []string{r.cfg.TenantsPath}
+	if r.flags.ConfigPath != "" {
+		paths = append(paths, r.flags.ConfigPath)
+	}
+	watch.Files(ctx, "config", func(string) { r.reload() }, paths...)
+}
+
+// reload applies the new configuration only when it is valid as a whole, so
+// a half-edited file never leaves the engine half reconfigured.
+func (r *reloader) reload() {
+	cfg, err := config.Load(r.flags)
+	if err != nil {
+		slog.Error("config reload rejected", "err", err)
+		metrics.ConfigReloads.WithLabelValues("failure").Inc()
+		return
+	}
+
+	if changed := restartOnly(r.cfg, *cfg); len(changed) > 0 {
+		slog.Warn("changed settings need a restart and were not applied", "settings", changed)
+	}
+
+	tenants, err := config.LoadTenants(r.cfg.TenantsPath)
+	if err == nil {
+		err = r.tenants.reload(tenants, cfg.Thresholds)
+	}
+	if err != nil {
+		slog.Error("config reload rejected", "err", err)
+		metrics.ConfigReloads.WithLabelValues("failure").Inc()
+		return
+	}
+
+	if cfg.Log.Level != r.cfg.Log.Level {
+		logger.SetLevel(cfg.Log.Level)
+		slog.Info("log level changed", "level", cfg.Log.Level)
+	}
+	r.cfg.Log.Level = cfg.Log.Level
+	r.cfg.Thresholds = cfg.Thresholds
+	metrics.ConfigReloads.WithLabelValues("success").Inc()
+}
+
+// restartOnly returns the settings that differ between old and cur apart
+// from the ones reload applies.
+func restartOnly(old, cur config.Config) []string {
+	for _, c := range []*config.Config{&old, &cur} {
+		c.Log.Level = ""
+		c.Thresholds = config.TenantThresholds{}
+	}
+	return config.Changed(&old, &cur)
+}
@@ -0,0 +1,191 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/tokyosplif/ai-risk-engine/internal/config"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/llm"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+	"github.com/tokyosplif/ai-risk-engine/internal/usecase"
+)
+
+const baseTenants = `{
+  "default": "acme",
+  "tenants": {
+    "acme": {"thresholds": {"low_value": 100}, "rate_limit": {"rps": 10, "burst": 20}},
+    "lite": {"llm": {"model": "small"}}
+  }
+}`
+
+func parseTenants(t *testing.T, data string) *config.TenantsConfig {
+	t.Helper()
+	var tcfg config.TenantsConfig
+	if err := json.Unmarshal([]byte(data), &tcfg); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	return &tcfg
+}
+
+// newTestTenantSet wires tcfg without LLM clients; the analyzers are built
+// but never asked for a verdict.
+func newTestTenantSet(t *testing.T, tcfg *config.TenantsConfig) *tenantSet {
+	t.Helper()
+	set := &tenantSet{
+		clients:   make(map[string]*llm.GroqClient, len(tcfg.Tenants)),
+		base:      "USD",
+		reviewSLA: time.Hour,
+	}
+	for id := range tcfg.Tenants {
+		set.clients[id] = nil
+	}
+	analyzers, err := set.analyzers(tcfg, config.TenantThresholds{})
+	if err != nil {
+		t.Fatalf("analyzers: %v", err)
+	}
+	set.tenants = tcfg
+	set.router = usecase.NewRouter(tcfg.Default, analyzers)
+	return set
+}
+
+func TestRestartRequired(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*config.TenantsConfig)
+		want string
+	}{
+		{"thresholds", func(c *config.TenantsConfig) {
+			acme := c.Tenants["acme"]
+			acme.Thresholds.LowValue = "250"
+			acme.Degradation = "review"
+			c.Tenants["acme"] = acme
+		}, ""},
+		{"default tenant", func(c *config.TenantsConfig) { c.Default = "lite" }, "default tenant"},
+		{"added tenant", func(c *config.TenantsConfig) { c.Tenants["new"] = config.TenantConfig{} }, `adding tenant "new"`},
+		{"removed tenant", func(c *config.TenantsConfig) { delete(c.Tenants, "lite") }, `removing tenant "lite"`},
+		{"llm", func(c *config.TenantsConfig) {
+			lite := c.Tenants["lite"]
+			lite.LLM.Model = "large"
+			c.Tenants["lite"] = lite
+		}, "llm or prompt version"},
+		{"prompt version", func(c *config.TenantsConfig) {
+			acme := c.Tenants["acme"]
+			acme.PromptVersion = "v2"
+			c.Tenants["acme"] = acme
+		}, "llm or prompt version"},
+		{"rate limit", func(c *config.TenantsConfig) {
+			acme := c.Tenants["acme"]
+			acme.RateLimit.RPS = 50
+			c.Tenants["acme"] = acme
+		}, "rate limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur := parseTenants(t, baseTenants)
+			tt.edit(cur)
+
+			err := restartRequired(parseTenants(t, baseTenants), cur)
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("Expected the change to apply, got %v", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("Expected an error about %s, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestTenantSet_Reload(t *testing.T) {
+	set := newTestTenantSet(t, parseTenants(t, baseTenants))
+
+	edited := parseTenants(t, strings.Replace(baseTenants, `"low_value": 100`, `"low_value": 250`, 1))
+	if err := set.reload(edited, config.TenantThresholds{}); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if set.tenants != edited {
+		t.Error("Expected the new tenants to be kept after a reload")
+	}
+
+	for name, data := range map[string]string{
+		"added tenant":    strings.Replace(baseTenants, `"lite":`, `"new": {}, "lite":`, 1),
+		"unknown rule":    strings.Replace(baseTenants, `"llm": {"model": "small"}`, `"llm": {"model": "small"}, "rules": ["nope"]`, 1),
+		"bad degradation": strings.Replace(baseTenants, `"low_value": 100}`, `"low_value": 100}, "degradation": "maybe"`, 1),
+	} {
+		if err := set.reload(parseTenants(t, data), config.TenantThresholds{}); err == nil {
+			t.Errorf("%s: expected the reload to be rejected", name)
+		}
+		if set.tenants != edited {
+			t.Errorf("%s: expected a rejected reload to keep the previous tenants", name)
+		}
+	}
+}
+
+func TestRestartOnly(t *testing.T) {
+	old := config.Defaults()
+	cur := config.Defaults()
+	cur.Log.Level = "debug"
+	cur.Thresholds.LowValue = "250"
+	if changed := restartOnly(*old, *cur); len(changed) != 0 {
+		t.Errorf("Expected the log level and thresholds to apply at runtime, got %v", changed)
+	}
+
+	cur.Groq.Model = "other"
+	if changed := restartOnly(*old, *cur); len(changed) == 0 {
+		t.Error("Expected a model change to need a restart")
+	}
+}
+
+func TestReloader_WatchAppliesReplacedFiles(t *testing.T) {
+	dir := t.TempDir()
+	tenantsPath := filepath.Join(dir, "tenants.json")
+	configPath := filepath.Join(dir, "config.yaml")
+	writeFile := func(path, data string) {
+		t.Helper()
+		// Write and rename, the way editors and config management save.
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(tenantsPath, baseTenants)
+	writeFile(configPath, "tenants_path: "+tenantsPath+"\n")
+
+	flags := &config.Flags{ConfigPath: configPath}
+	cfg, err := config.Load(flags)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	set := newTestTenantSet(t, parseTenants(t, baseTenants))
+	r := newReloader(flags, cfg, set)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Watch(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	reloads := metrics.ConfigReloads.WithLabelValues("success")
+	before := testutil.ToFloat64(reloads)
+	for range 100 {
+		writeFile(tenantsPath, strings.Replace(baseTenants, `"low_value": 100`, `"low_value": 250`, 1))
+		time.Sleep(20 * time.Millisecond)
+		if testutil.ToFloat64(reloads) > before {
+			return
+		}
+	}
+	t.Fatal("Expected a replaced tenants file to be reloaded")
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/auth"
+	"github.com/tokyosplif/ai-risk-engine/internal/config"
+	delivery "github.com/tokyosplif/ai-risk-engine/internal/delivery/grpc"
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+)
+
+// security is the transport and caller authentication shared by the gRPC
+// server and the HTTP gateway. All fields are nil when disabled.
+type security struct {
+	tls  *tls.Config
+	auth *delivery.Auth
+	// jwtSecrets is watched for rotated secrets.
+	jwtSecrets *secrets.KeyRing
+}
+
+func newSecurity(cfg *config.Config) (*security, error) {
+	sec := &security{}
+
+	if cfg.TLS.CertFile != "" || cfg.TLS.KeyFile != "" {
+		tlsCfg, err := serverTLS(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		sec.tls = tlsCfg
+		slog.Info("tls enabled", "client_auth", tlsCfg.ClientAuth.String())
+	} else {
+		slog.Warn("tls is disabled, traffic is unencrypted")
+	}
+
+	if !cfg.Auth.Enabled() {
+		slog.Warn("authentication is disabled, any caller can reach the engine")
+		return sec, nil
+	}
+
+	var clients []auth.Client
+	if cfg.Auth.ClientsPath != "" {
+		var err error
+		if clients, err = auth.LoadClients(cfg.Auth.ClientsPath); err != nil {
+			return nil, err
+		}
+	}
+	var jwt *auth.JWTVerifier
+	if cfg.Auth.JWTEnabled() {
+		var static []secrets.Value
+		if cfg.Auth.JWTSecret != "" {
+			static = append(static, cfg.Auth.JWTSecret)
+		}
+		keys, err := secrets.NewKeyRing("jwt secret", static, cfg.Auth.JWTSecretFile, secrets.RoundRobin, 0)
+		if err != nil {
+			return nil, err
+		}
+		sec.jwtSecrets = keys
+		jwt = auth.NewJWTVerifier(keys, cfg.Auth.JWTIssuer, cfg.Auth.JWTAudience)
+	}
+
+	// Probes must work without credentials; reflection exposes the schema to
+	// any authenticated caller.
+	policy := auth.DefaultPolicy(
+		pb.RiskEngineService_ServiceDesc.ServiceName,
+		pb.RiskAdminService_ServiceDesc.ServiceName,
+		pb.RiskReviewService_ServiceDesc.ServiceName,
+		pb.RiskFeedbackService_ServiceDesc.ServiceName,
+	)
+	policy["/"+healthpb.Health_ServiceDesc.ServiceName+"/"] = []string{auth.RolePublic}
+	policy["/"+reflectionpb.ServerReflection_ServiceDesc.ServiceName+"/"] = []string{auth.RoleAnalyze, auth.RoleReview, auth.RoleFeedback, auth.RoleAdmin}
+	policy["/grpc.reflection.v1alpha.ServerReflection/"] = []string{auth.RoleAnalyze, auth.RoleReview, auth.RoleFeedback, auth.RoleAdmin}
+
+	sec.auth = delivery.NewAuth(auth.NewAuthenticator(clients, jwt), policy)
+	slog.Info("authentication enabled", "clients", len(clients), "jwt", jwt != nil)
+
+	return sec, nil
+}
+
+// unaryInterceptors returns the authentication interceptors, if enabled.
+func (s *security) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	if s.auth == nil {
+		return nil
+	}
+	return []grpc.UnaryServerInterceptor{s.auth.UnaryInterceptor}
+}
+
+// serverOptions returns the gRPC transport credentials and the stream
+// authentication interceptor.
+func (s *security) serverOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if s.tls != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tls)))
+	}
+	if s.auth != nil {
+		opts = append(opts, grpc.ChainStreamInterceptor(s.auth.StreamInterceptor))
+	}
+	return opts
+}
+
+func serverTLS(cfg config.TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tls key pair: %w", err)
+	}
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAFile == "" {
+		return tlsCfg, nil
+	}
+
+	pem, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("client ca file contains no certificates")
+	}
+	tlsCfg.ClientCAs = pool
+
+	switch cfg.ClientAuth {
+	case "require":
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	case "optional", "":
+		tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+	default:
+		return nil, fmt.Errorf("unknown tls client auth mode %q", cfg.ClientAuth)
+	}
+	return tlsCfg, nil
+}
@@ -1,33 +1,383 @@
 package app
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/tokyosplif/ai-risk-engine/internal/audit"
 	"github.com/tokyosplif/ai-risk-engine/internal/config"
+	"github.com/tokyosplif/ai-risk-engine/internal/delivery/gateway"
 	delivery "github.com/tokyosplif/ai-risk-engine/internal/delivery/grpc"
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/health"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/events"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/features"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/fx"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/geo"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/labels"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/lists"
 	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/llm"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/merchants"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/notify"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/review"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+	"github.com/tokyosplif/ai-risk-engine/internal/tracing"
 	"github.com/tokyosplif/ai-risk-engine/internal/usecase"
+	"github.com/tokyosplif/ai-risk-engine/pkg/closer"
 	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
-func RunServer(cfg *config.Config) error {
-	groq := llm.NewGroqClient(cfg.Groq, cfg.PromptsPath)
+// RunServer serves until SIGINT or SIGTERM, then drains in-flight requests for
+// up to cfg.ShutdownTimeout and closes every component in reverse order of
+// construction. flags are reapplied when the configuration is reloaded.
+func RunServer(cfg *config.Config, flags *config.Flags) (err error) {
+	closers := closer.New()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+		err = errors.Join(err, closers.CloseAll(ctx))
+	}()
 
-	analyzer := usecase.NewAnalyzer(groq)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.Exporter)
+	if err != nil {
+		return fmt.Errorf("failed to setup tracing: %w", err)
+	}
+	closers.Add("tracer provider", shutdownTracing)
+
+	healthServer := grpchealth.NewServer()
+	checker := health.NewChecker(healthServer,
+		pb.RiskEngineService_ServiceDesc.ServiceName,
+		pb.RiskAdminService_ServiceDesc.ServiceName,
+		pb.RiskReviewService_ServiceDesc.ServiceName,
+		pb.RiskFeedbackService_ServiceDesc.ServiceName,
+	)
+
+	httpServer := serveHTTP(cfg.MetricsPort, checker)
+	closers.Add("http server", httpServer.Shutdown)
+
+	keys, err := secrets.NewKeyRing("llm api key", secrets.Split(cfg.Groq.APIKey.Reveal()), cfg.Groq.APIKeyFile, cfg.Groq.KeyStrategy, cfg.Groq.KeyCooldown)
+	if err != nil {
+		return err
+	}
+	closers.Go("api key watcher", keys.Watch)
+
+	base := domain.DefaultBaseCurrency
+	fxProvider, err := fx.NewFileProvider(cfg.FXRatesPath)
+	if err != nil {
+		slog.Error("fx rates unavailable, only the base currency is accepted", "path", cfg.FXRatesPath, "err", err)
+	} else {
+		base = fxProvider.Base()
+	}
+
+	groq := llm.NewGroqClient(cfg.Groq, keys, cfg.PromptsPath, base)
+	closers.Go("prompts watcher", func(ctx context.Context) { groq.WatchPrompts(ctx, cfg.PromptsPath) })
+
+	featureStore := features.NewMemoryStore()
+	closers.Go("feature sweeper", func(ctx context.Context) { featureStore.Run(ctx, features.DefaultSweepPeriod) })
+
+	listStore, err := lists.NewStore(cfg.ListsPath)
+	if err != nil {
+		return err
+	}
+	closers.Go("lists watcher", listStore.Watch)
+
+	catalog, err := merchants.NewCatalog(cfg.MerchantsPath)
+	if err != nil {
+		return err
+	}
+	closers.Go("merchants watcher", catalog.Watch)
+
+	reviews, err := review.NewStore(cfg.Review.Path, cfg.Review.ClaimTTL, cfg.Review.Retention, review.NewHTTPNotifier(cfg.Review.CallbackTimeout))
+	if err != nil {
+		return err
+	}
+	closers.AddCloser("review queue", reviews)
+	closers.Go("review sweeper", func(ctx context.Context) { reviews.Run(ctx, review.DefaultSweepPeriod) })
+
+	labelStore, err := labels.NewStore(cfg.Labels.Path, cfg.Labels.DecisionsDir, cfg.Labels.DecisionCache, cfg.Labels.DecisionRetention)
+	if err != nil {
+		return err
+	}
+	closers.AddCloser("labels store", labelStore)
+
+	opts := []usecase.Option{
+		usecase.WithFeatureStore(featureStore),
+		usecase.WithLists(listStore),
+		usecase.WithMerchants(catalog),
+		usecase.WithReviewQueue(reviews),
+		usecase.WithDecisionLog(labelStore),
+	}
+	if fxProvider != nil {
+		opts = append(opts, usecase.WithFX(fxProvider))
+	}
+
+	if geoDB, err := geo.NewFileDB(cfg.GeoDBPath); err != nil {
+		slog.Error("geo database unavailable, geolocation features are disabled", "path", cfg.GeoDBPath, "err", err)
+	} else {
+		opts = append(opts, usecase.WithGeo(geoDB))
+	}
+
+	if cfg.Audit.Dir != "" {
+		auditLog, err := openAuditLog(cfg.Audit)
+		if err != nil {
+			return err
+		}
+		closers.AddCloser("audit log", auditLog)
+		checker.Add("audit_log", func(context.Context) error { return auditLog.Healthy() })
+		opts = append(opts, usecase.WithAuditRecorder(auditLog))
+	}
+
+	if cfg.Events.Enabled() {
+		outbox, err := openOutbox(cfg.Events)
+		if err != nil {
+			return err
+		}
+		closers.AddCloser("event outbox", outbox)
+		closers.Go("event delivery", outbox.Run)
+		opts = append(opts, usecase.WithEventPublisher(outbox))
+	}
+
+	composer, err := notify.NewComposer(cfg.Notify.TemplatesPath)
+	if err != nil {
+		return err
+	}
+	closers.Go("notification templates watcher", composer.Watch)
+	opts = append(opts, usecase.WithMessageComposer(composer))
+	if n := cfg.Notify; n.WebhookURL != "" {
+		queue := notify.NewQueue(notify.NewWebhookNotifier(n.WebhookURL, []byte(n.WebhookSecret.Reveal()), n.Timeout), n.QueueSize)
+		closers.Go("notification sender", queue.Run)
+		opts = append(opts, usecase.WithNotifier(queue, n.Decisions))
+	}
+
+	tenants, err := config.LoadTenants(cfg.TenantsPath)
+	if err != nil {
+		return err
+	}
+	set, err := buildTenants(tenants, cfg, groq, base, opts)
+	if err != nil {
+		return err
+	}
+	for _, k := range set.keys {
+		closers.Go(k.Name()+" watcher", k.Watch)
+	}
+	checker.Add("llm_credentials", set.checkCredentials)
+	closers.Go("config watcher", newReloader(flags, cfg, set).Watch)
+	checker.Add("prompts", set.checkPrompts)
+	checker.AddInfo("llm_provider", set.checkProviders)
+
+	limiter := delivery.NewRateLimiter(tenants.Default, set.limits, delivery.RateLimit{
+		RPS:   cfg.ClientRateLimit.RPS,
+		Burst: cfg.ClientRateLimit.Burst,
+	})
+
+	handler := delivery.NewRiskHandler(set.router)
+
+	outcomes := usecase.NewOutcomes(labelStore, set.router,
+		usecase.WithLabelFeatures(featureStore),
+		usecase.WithLabelDenylist(listStore, cfg.Labels.DenyEntities, cfg.Labels.DenyTTL),
+	)
+	outcomes.Restore(context.Background())
 
-	handler := delivery.NewRiskHandler(analyzer)
+	sec, err := newSecurity(cfg)
+	if err != nil {
+		return err
+	}
+	if sec.jwtSecrets != nil {
+		closers.Go("jwt secret watcher", sec.jwtSecrets.Watch)
+	}
 
 	lis, err := net.Listen("tcp", cfg.Port)
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %v", cfg.Port, err)
 	}
 
-	grpcServer := grpc.NewServer()
+	// Interceptors run in the order they are chained: requests are logged
+	// even when authentication rejects them, and rate limits are charged to
+	// the authenticated tenant.
+	unary := []grpc.UnaryServerInterceptor{delivery.LoggingInterceptor}
+	unary = append(unary, sec.unaryInterceptors()...)
+	unary = append(unary, limiter.UnaryInterceptor)
+
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+	}
+	serverOpts = append(serverOpts, sec.serverOptions()...)
+
+	adminHandler := delivery.NewAdminHandler(listStore, catalog)
+	reviewHandler := delivery.NewReviewHandler(reviews, outcomes)
+	feedbackHandler := delivery.NewFeedbackHandler(outcomes)
+
+	grpcServer := grpc.NewServer(serverOpts...)
 	pb.RegisterRiskEngineServiceServer(grpcServer, handler)
+	pb.RegisterRiskAdminServiceServer(grpcServer, adminHandler)
+	pb.RegisterRiskReviewServiceServer(grpcServer, reviewHandler)
+	pb.RegisterRiskFeedbackServiceServer(grpcServer, feedbackHandler)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	closers.Go("health checker", func(ctx context.Context) { checker.Run(ctx, health.DefaultPeriod) })
+
+	if cfg.HTTPPort != "" {
+		gw, err := gateway.New(context.Background(), handler, adminHandler, reviewHandler, feedbackHandler, unary...)
+		if err != nil {
+			return fmt.Errorf("failed to build http gateway: %w", err)
+		}
+		gwServer, err := serveGateway(cfg.HTTPPort, gw, sec.tls)
+		if err != nil {
+			return err
+		}
+		closers.Add("http gateway", gwServer.Shutdown)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- grpcServer.Serve(lis) }()
 	slog.Info("AI Risk Engine gRPC server is running", "port", cfg.Port)
-	return grpcServer.Serve(lis)
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("grpc server failed: %w", err)
+	case <-ctx.Done():
+		slog.Info("shutdown signal received, draining requests", "timeout", cfg.ShutdownTimeout)
+		checker.Shutdown()
+		drain(grpcServer, cfg.ShutdownTimeout)
+		return nil
+	}
+}
+
+// drain stops accepting calls and waits for in-flight ones. Past the timeout
+// the remaining calls are cancelled, which aborts their outbound LLM requests.
+func drain(s *grpc.Server, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		slog.Info("grpc server drained")
+	case <-time.After(timeout):
+		slog.Warn("drain timeout exceeded, cancelling in-flight requests")
+		s.Stop()
+		<-done
+	}
+}
+
+// Timeouts of the HTTP servers. A client gets readHeaderTimeout to send its
+// request headers, which bounds slow-header connections, and a keep-alive
+// connection is closed after idleTimeout without a request.
+const (
+	readHeaderTimeout = 10 * time.Second
+	idleTimeout       = 2 * time.Minute
+)
+
+// serveGateway starts the HTTP/JSON gateway, over TLS when tlsCfg is set.
+func serveGateway(addr string, handler http.Handler, tlsCfg *tls.Config) (*http.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	if tlsCfg != nil {
+		lis = tls.NewListener(lis, tlsCfg)
+	}
+
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: readHeaderTimeout, IdleTimeout: idleTimeout}
+	go func() {
+		slog.Info("http gateway is running", "port", addr, "tls", tlsCfg != nil)
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("http gateway failed", "error", err)
+		}
+	}()
+	return srv, nil
+}
+
+// serveHTTP serves the Prometheus metrics and the liveness and readiness
+// probes.
+func serveHTTP(addr string, checker *health.Checker) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/livez", checker.LivenessHandler())
+	mux.Handle("/readyz", checker.ReadinessHandler())
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: readHeaderTimeout, IdleTimeout: idleTimeout}
+
+	go func() {
+		slog.Info("metrics and health endpoints are running", "port", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("http server failed", "error", err)
+		}
+	}()
+	return srv
+}
+
+func openAuditLog(cfg config.AuditConfig) (*audit.Log, error) {
+	sink, err := audit.NewFileSink(cfg.Dir, cfg.MaxSegmentBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit sink: %w", err)
+	}
+
+	auditLog, err := audit.NewLog(sink)
+	if err != nil {
+		closer.Close(sink, "audit sink")
+		return nil, err
+	}
+
+	slog.Info("audit trail enabled", "dir", cfg.Dir)
+	return auditLog, nil
+}
+
+func openOutbox(cfg config.EventsConfig) (*events.Outbox, error) {
+	var sinks []events.Sink
+	if cfg.File != "" {
+		file, err := events.NewFileSink(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, file)
+	}
+	if cfg.WebhookURL != "" {
+		sinks = append(sinks, events.NewWebhookSink(cfg.WebhookURL, []byte(cfg.WebhookSecret.Reveal()), cfg.WebhookTimeout))
+	}
+	if cfg.NATSURL != "" {
+		nats, err := events.NewNATSSink(cfg.NATSURL.Reveal(), cfg.NATSSubject, cfg.NATSTimeout)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, nats)
+	}
+
+	outbox, err := events.NewOutbox(cfg.OutboxDir, cfg.OutboxMaxBytes, sinks...)
+	if err != nil {
+		for _, s := range sinks {
+			if c, ok := s.(io.Closer); ok {
+				closer.Close(c, s.Name()+" event sink")
+			}
+		}
+		return nil, err
+	}
+
+	names := make([]string, len(sinks))
+	for i, s := range sinks {
+		names[i] = s.Name()
+	}
+	slog.Info("decision events enabled", "outbox", cfg.OutboxDir, "sinks", names)
+	return outbox, nil
 }
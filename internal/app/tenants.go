@@ -0,0 +1,280 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/config"
+	delivery "github.com/tokyosplif/ai-risk-engine/internal/delivery/grpc"
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/llm"
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+	"github.com/tokyosplif/ai-risk-engine/internal/usecase"
+)
+
+// tenantSet is the per-tenant wiring of the engine.
+type tenantSet struct {
+	router  *usecase.Router
+	limits  map[string]delivery.RateLimit
+	clients map[string]*llm.GroqClient
+	// keys are the tenants' own API key rings, watched for rotation.
+	keys []*secrets.KeyRing
+
+	// tenants, base, reviewSLA and shared rebuild the analyzers on reload.
+	tenants   *config.TenantsConfig
+	base      string
+	reviewSLA time.Duration
+	shared    []usecase.Option
+}
+
+// buildTenants creates one analyzer per configured tenant on top of the
+// shared options. Tenants inherit the engine-wide thresholds, LLM key
+// settings and review SLA of cfg.
+func buildTenants(tcfg *config.TenantsConfig, cfg *config.Config, groq *llm.GroqClient, base string, shared []usecase.Option) (*tenantSet, error) {
+	set := &tenantSet{
+		limits:    make(map[string]delivery.RateLimit, len(tcfg.Tenants)),
+		clients:   make(map[string]*llm.GroqClient, len(tcfg.Tenants)),
+		base:      base,
+		reviewSLA: cfg.Review.SLA,
+		shared:    shared,
+	}
+
+	for id, t := range tcfg.Tenants {
+		keys, err := tenantKeys(id, t.LLM, cfg.Groq)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %q: %w", id, err)
+		}
+		if keys != nil {
+			set.keys = append(set.keys, keys)
+		}
+
+		client := groq.ForTenant(id, config.GroqConfig{
+			BaseURL: t.LLM.BaseURL,
+			Model:   t.LLM.Model,
+		}, keys, t.PromptVersion)
+		if !client.HasPrompt() {
+			slog.Warn("tenant prompt version is not loaded", "tenant", id, "prompt_version", t.PromptVersion)
+		}
+
+		set.clients[id] = client
+		set.limits[id] = delivery.RateLimit{RPS: t.RateLimit.RPS, Burst: t.RateLimit.Burst}
+	}
+
+	analyzers, err := set.analyzers(tcfg, cfg.Thresholds)
+	if err != nil {
+		return nil, err
+	}
+	set.tenants = tcfg
+	set.router = usecase.NewRouter(tcfg.Default, analyzers)
+
+	slog.Info("tenants configured", "count", len(analyzers), "default", tcfg.Default)
+	return set, nil
+}
+
+// analyzers builds the analyzer of every tenant in tcfg on its client.
+func (s *tenantSet) analyzers(tcfg *config.TenantsConfig, defaults config.TenantThresholds) (map[string]*usecase.Analyzer, error) {
+	analyzers := make(map[string]*usecase.Analyzer, len(tcfg.Tenants))
+	for id, t := range tcfg.Tenants {
+		if err := validateTenant(t); err != nil {
+			return nil, fmt.Errorf("tenant %q: %w", id, err)
+		}
+
+		thresholds, err := tenantThresholds(s.base, defaults, t.Thresholds)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %q: %w", id, err)
+		}
+		review, err := tenantReview(t.Review, s.reviewSLA)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %q: %w", id, err)
+		}
+
+		opts := append([]usecase.Option{}, s.shared...)
+		opts = append(opts,
+			usecase.WithTenant(id),
+			usecase.WithThresholds(thresholds),
+			usecase.WithReviewPolicy(review),
+		)
+		if t.Rules != nil {
+			opts = append(opts, usecase.WithRules(t.Rules))
+		}
+		if t.Prescreen != nil {
+			opts = append(opts, usecase.WithScreens(t.Prescreen))
+		}
+		if t.Degradation != "" {
+			opts = append(opts, usecase.WithDegradation(t.Degradation))
+		}
+
+		analyzers[id] = usecase.NewAnalyzer(s.clients[id], opts...)
+	}
+	return analyzers, nil
+}
+
+// reload applies the tenant settings that are safe to change at runtime:
+// thresholds, rules, prescreen checks, the degradation policy and the review
+// SLA and callback, which apply to cases opened from then on. A change to
+// anything else, which would rewire LLM clients or rate limits, needs a
+// restart and fails the whole reload.
+func (s *tenantSet) reload(tcfg *config.TenantsConfig, defaults config.TenantThresholds) error {
+	if err := restartRequired(s.tenants, tcfg); err != nil {
+		return err
+	}
+
+	analyzers, err := s.analyzers(tcfg, defaults)
+	if err != nil {
+		return err
+	}
+	s.router.Replace(analyzers)
+	s.tenants = tcfg
+
+	slog.Info("tenants reloaded", "count", len(analyzers))
+	return nil
+}
+
+func restartRequired(old, cur *config.TenantsConfig) error {
+	if old.Default != cur.Default {
+		return errors.New("changing the default tenant requires a restart")
+	}
+	for id, t := range cur.Tenants {
+		o, ok := old.Tenants[id]
+		switch {
+		case !ok:
+			return fmt.Errorf("adding tenant %q requires a restart", id)
+		case o.LLM != t.LLM || o.PromptVersion != t.PromptVersion:
+			return fmt.Errorf("tenant %q: changing the llm or prompt version requires a restart", id)
+		case o.RateLimit != t.RateLimit:
+			return fmt.Errorf("tenant %q: changing the rate limit requires a restart", id)
+		}
+	}
+	for id := range old.Tenants {
+		if _, ok := cur.Tenants[id]; !ok {
+			return fmt.Errorf("removing tenant %q requires a restart", id)
+		}
+	}
+	return nil
+}
+
+// checkPrompts fails while a tenant's prompt version is not loaded.
+func (s *tenantSet) checkPrompts(context.Context) error {
+	var missing []string
+	for id, c := range s.clients {
+		if !c.HasPrompt() {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return fmt.Errorf("prompt not loaded for tenants %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkCredentials fails while a tenant has no usable LLM API key.
+func (s *tenantSet) checkCredentials(context.Context) error {
+	var missing []string
+	for id, c := range s.clients {
+		if c.Credentials() != nil {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return fmt.Errorf("no usable llm api key for tenants %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkProviders reports the tenants whose LLM provider circuit is open. It is
+// informational: those tenants get their degradation verdict, and the other
+// tenants and services keep working.
+func (s *tenantSet) checkProviders(context.Context) error {
+	var open []string
+	for id, c := range s.clients {
+		if c.CircuitState() == llm.CircuitOpen {
+			open = append(open, id)
+		}
+	}
+	if len(open) > 0 {
+		slices.Sort(open)
+		return fmt.Errorf("llm provider circuit open for tenants %s", strings.Join(open, ", "))
+	}
+	return nil
+}
+
+func validateTenant(t config.TenantConfig) error {
+	if err := usecase.ValidateRules(t.Rules); err != nil {
+		return err
+	}
+	if err := usecase.ValidateScreens(t.Prescreen); err != nil {
+		return err
+	}
+	return usecase.ValidateDegradation(t.Degradation)
+}
+
+// tenantThresholds overrides the built-in thresholds with each layer in
+// turn, read as amounts in the base currency.
+func tenantThresholds(base string, layers ...config.TenantThresholds) (domain.Thresholds, error) {
+	out := usecase.DefaultThresholds(base)
+	for _, t := range layers {
+		for _, f := range []struct {
+			value json.Number
+			dst   *domain.Money
+			name  string
+		}{
+			{t.LowValue, &out.LowValue, "low_value"},
+			{t.HeuristicBlockMin, &out.HeuristicBlockMin, "heuristic_block_min"},
+			{t.HighValue, &out.HighValue, "high_value"},
+			{t.PrescreenAllowMax, &out.PrescreenAllowMax, "prescreen_allow_max"},
+		} {
+			if f.value == "" {
+				continue
+			}
+			m, err := domain.ParseMoney(f.value.String(), base)
+			if err != nil {
+				return domain.Thresholds{}, fmt.Errorf("threshold %s: %w", f.name, err)
+			}
+			*f.dst = m
+		}
+	}
+	return out, nil
+}
+
+// tenantReview returns the tenant's review policy. An empty SLA inherits
+// defaultSLA.
+func tenantReview(cfg config.TenantReviewConfig, defaultSLA time.Duration) (domain.ReviewPolicy, error) {
+	policy := domain.ReviewPolicy{SLA: defaultSLA, CallbackURL: cfg.CallbackURL}
+	if cfg.SLA != "" {
+		sla, err := time.ParseDuration(cfg.SLA)
+		if err != nil || sla <= 0 {
+			return domain.ReviewPolicy{}, fmt.Errorf("review sla %q is not a positive duration", cfg.SLA)
+		}
+		policy.SLA = sla
+	}
+	if cfg.CallbackURL != "" {
+		u, err := url.Parse(cfg.CallbackURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return domain.ReviewPolicy{}, fmt.Errorf("review callback_url %q is not an http(s) URL", cfg.CallbackURL)
+		}
+	}
+	return policy, nil
+}
+
+// tenantKeys returns the tenant's own API keys, or nil when it uses the
+// engine's. The strategy and cooldown follow the engine's.
+func tenantKeys(id string, cfg config.TenantLLMConfig, groqCfg config.GroqConfig) (*secrets.KeyRing, error) {
+	if cfg.APIKeyEnv == "" && cfg.APIKeyFile == "" {
+		return nil, nil
+	}
+	var static []secrets.Value
+	if cfg.APIKeyEnv != "" {
+		static = secrets.Split(os.Getenv(cfg.APIKeyEnv))
+	}
+	return secrets.NewKeyRing(id+" llm api key", static, cfg.APIKeyFile, groqCfg.KeyStrategy, groqCfg.KeyCooldown)
+}
@@ -0,0 +1,223 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+var ErrChainBroken = errors.New("audit chain broken")
+
+// Entry is a single hash-chained audit record. Hash covers every other field,
+// including PrevHash, so altering or removing any entry breaks the chain.
+// Entries read back from a sink are verified against the bytes that were
+// stored, so the Go types embedded here may evolve without invalidating
+// existing chains. New fields should still be omitempty.
+type Entry struct {
+	Seq           uint64               `json:"seq"`
+	Timestamp     time.Time            `json:"timestamp"`
+	TransactionID string               `json:"transaction_id"`
+	Input         Input                `json:"input"`
+	Features      *domain.UserFeatures `json:"features,omitempty"`
+	Geo           *domain.GeoFeatures  `json:"geo,omitempty"`
+	LLM           *domain.LLMTrace     `json:"llm,omitempty"`
+	LLMError      string               `json:"llm_error,omitempty"`
+	LLMVerdict    Verdict              `json:"llm_verdict"`
+	Overrides     []domain.Override    `json:"overrides"`
+	Final         Verdict              `json:"final"`
+	Route         string               `json:"route,omitempty"`
+	RouteReason   string               `json:"route_reason,omitempty"`
+	Explanation   *domain.Explanation  `json:"explanation,omitempty"`
+	PrevHash      string               `json:"prev_hash"`
+	Hash          string               `json:"hash"`
+
+	// raw is the stored encoding of an entry read back from a sink.
+	raw []byte
+}
+
+type Input struct {
+	Transaction domain.Transaction `json:"transaction"`
+	TxData      string             `json:"tx_data"`
+}
+
+type Verdict struct {
+	IsBlocked       bool   `json:"is_blocked"`
+	ConfidenceScore int    `json:"confidence_score"`
+	Reason          string `json:"reason"`
+	AIPushMessage   string `json:"ai_push_message"`
+	Decision        string `json:"decision"`
+}
+
+// Sink persists entries in order and can report the last one written so the
+// chain can be resumed after a restart. Appended entries are durable once
+// Sync returns.
+type Sink interface {
+	Append(e Entry) error
+	Sync() error
+	Last() (Entry, bool, error)
+	Close() error
+}
+
+type Log struct {
+	mu       sync.Mutex
+	sink     Sink
+	seq      uint64
+	lastHash string
+	lastErr  error
+	now      func() time.Time
+}
+
+func NewLog(sink Sink) (*Log, error) {
+	l := &Log{sink: sink, now: time.Now}
+
+	last, ok, err := sink.Last()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume audit chain: %w", err)
+	}
+	if ok {
+		l.seq = last.Seq
+		l.lastHash = last.Hash
+	}
+
+	return l, nil
+}
+
+// Record appends rec to the chain and returns once it is durable. Entries are
+// chained under the log's lock; the fsync happens outside it so that
+// concurrent records share one.
+func (l *Log) Record(_ context.Context, rec domain.AuditRecord) error {
+	if err := l.append(rec); err != nil {
+		return err
+	}
+	if err := l.sink.Sync(); err != nil {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.lastErr = fmt.Errorf("failed to sync audit log: %w", err)
+		return l.lastErr
+	}
+	return nil
+}
+
+func (l *Log) append(rec domain.AuditRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e := Entry{
+		Seq:           l.seq + 1,
+		Timestamp:     l.now().UTC(),
+		TransactionID: rec.Transaction.ID,
+		Input:         Input{Transaction: rec.Transaction, TxData: rec.TxData},
+		Features:      rec.Features,
+		Geo:           rec.Geo,
+		LLM:           rec.LLM,
+		LLMError:      rec.LLMError,
+		LLMVerdict:    toVerdict(rec.LLMVerdict),
+		Overrides:     rec.Final.Overrides,
+		Final:         toVerdict(rec.Final),
+		Route:         rec.Final.Route,
+		RouteReason:   rec.Final.RouteReason,
+		Explanation:   &rec.Final.Explanation,
+		PrevHash:      l.lastHash,
+	}
+
+	hash, err := e.computeHash()
+	if err != nil {
+		return err
+	}
+	e.Hash = hash
+
+	if err := l.sink.Append(e); err != nil {
+		l.lastErr = fmt.Errorf("failed to append audit entry: %w", err)
+		return l.lastErr
+	}
+
+	l.lastErr = nil
+	l.seq = e.Seq
+	l.lastHash = e.Hash
+	return nil
+}
+
+// Healthy returns the error of the last append or sync, if it failed.
+func (l *Log) Healthy() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastErr
+}
+
+func (l *Log) Close() error {
+	return l.sink.Close()
+}
+
+// computeHash hashes the entry's encoding with an empty hash field. For
+// entries read back from a sink the stored bytes are used as-is, since hash is
+// always the last field written.
+func (e Entry) computeHash() (string, error) {
+	stored := []byte(`"hash":"` + e.Hash + `"}`)
+	if e.raw != nil && bytes.HasSuffix(e.raw, stored) {
+		data := append(bytes.Clone(e.raw[:len(e.raw)-len(stored)]), `"hash":""}`...)
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:]), nil
+	}
+
+	e.Hash = ""
+	e.raw = nil
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Verify checks that each entry's hash is intact and links to its predecessor.
+// It returns the number of entries verified.
+func Verify(entries iter.Seq2[Entry, error]) (int, error) {
+	var prev string
+	var seq uint64
+	n := 0
+
+	for e, err := range entries {
+		if err != nil {
+			return n, err
+		}
+
+		hash, err := e.computeHash()
+		if err != nil {
+			return n, err
+		}
+		if hash != e.Hash {
+			return n, fmt.Errorf("%w: entry %d hash mismatch", ErrChainBroken, e.Seq)
+		}
+		if e.PrevHash != prev {
+			return n, fmt.Errorf("%w: entry %d does not link to entry %d", ErrChainBroken, e.Seq, seq)
+		}
+		if e.Seq != seq+1 {
+			return n, fmt.Errorf("%w: expected entry %d, got %d", ErrChainBroken, seq+1, e.Seq)
+		}
+
+		prev = e.Hash
+		seq = e.Seq
+		n++
+	}
+
+	return n, nil
+}
+
+func toVerdict(r domain.RiskAssessment) Verdict {
+	return Verdict{
+		IsBlocked:       r.IsBlocked,
+		ConfidenceScore: r.ConfidenceScore,
+		Reason:          r.Reason,
+		AIPushMessage:   r.AIPushMessage,
+		Decision:        r.Decision(),
+	}
+}
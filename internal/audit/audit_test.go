@@ -0,0 +1,192 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+func writeEntries(t *testing.T, dir string, n int) {
+	t.Helper()
+
+	sink, err := NewFileSink(dir, 512)
+	if err != nil {
+		t.Fatalf("NewFileSink: %v", err)
+	}
+	log, err := NewLog(sink)
+	if err != nil {
+		t.Fatalf("NewLog: %v", err)
+	}
+	defer log.Close()
+
+	for i := 0; i < n; i++ {
+		err := log.Record(context.Background(), domain.AuditRecord{
+			Transaction: domain.Transaction{ID: "tx", Amount: domain.Money{MinorUnits: int64(i), Currency: "USD"}},
+			Final:       domain.RiskAssessment{Reason: "ok"},
+		})
+		if err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+}
+
+func TestVerify_ChainAcrossSegmentsAndRestarts(t *testing.T) {
+	dir := t.TempDir()
+
+	writeEntries(t, dir, 5)
+	writeEntries(t, dir, 5)
+
+	segments, _ := listSegments(dir)
+	if len(segments) < 2 {
+		t.Fatalf("Expected entries to span several segments, got %d", len(segments))
+	}
+
+	n, err := Verify(ReadDir(dir))
+	if err != nil {
+		t.Fatalf("Expected chain to verify, got %v", err)
+	}
+	if n != 10 {
+		t.Errorf("Expected 10 entries verified, got %d", n)
+	}
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	dir := t.TempDir()
+	writeEntries(t, dir, 3)
+
+	path := filepath.Join(dir, "audit-000001.jsonl")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), `"is_blocked":false`, `"is_blocked":true`, 1)
+	if err := os.WriteFile(path, []byte(tampered), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Verify(ReadDir(dir)); !errors.Is(err, ErrChainBroken) {
+		t.Errorf("Expected ErrChainBroken, got %v", err)
+	}
+}
+
+func TestVerify_LegacyEncoding(t *testing.T) {
+	dir := t.TempDir()
+
+	// Written before amounts were Money: amount is a bare float.
+	unsigned := `{"seq":1,"timestamp":"2026-01-01T00:00:00Z","transaction_id":"tx",` +
+		`"input":{"transaction":{"id":"tx","user_id":"u1","amount":499.99,"merchant":"","location":"","user_profile":"","currency":"USD"},"tx_data":""},` +
+		`"llm_verdict":{"is_blocked":false,"confidence_score":0,"reason":"","ai_push_message":"","decision":"allow"},` +
+		`"overrides":null,"final":{"is_blocked":false,"confidence_score":0,"reason":"ok","ai_push_message":"","decision":"allow"},` +
+		`"prev_hash":"","hash":""}`
+	sum := sha256.Sum256([]byte(unsigned))
+	line := strings.Replace(unsigned, `"hash":""`, `"hash":"`+hex.EncodeToString(sum[:])+`"`, 1)
+	if err := os.WriteFile(filepath.Join(dir, "audit-000001.jsonl"), []byte(line+"\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	for e, err := range ReadDir(dir) {
+		if err != nil {
+			t.Fatalf("ReadDir: %v", err)
+		}
+		if got := e.Input.Transaction.Amount.Decimal(); got != "499.99" {
+			t.Errorf("Expected legacy amount 499.99, got %s", got)
+		}
+	}
+
+	if n, err := Verify(ReadDir(dir)); err != nil || n != 1 {
+		t.Errorf("Expected legacy entry to verify, got n=%d err=%v", n, err)
+	}
+}
+
+func TestFileSink_ResumesFromHighestSegment(t *testing.T) {
+	dir := t.TempDir()
+	writeEntries(t, dir, 5)
+
+	segments, _ := listSegments(dir)
+	last := segments[len(segments)-1]
+	// Archiving an early segment leaves a gap in the numbering.
+	if err := os.Remove(segments[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	sink, err := NewFileSink(dir, 512)
+	if err != nil {
+		t.Fatalf("NewFileSink: %v", err)
+	}
+	defer sink.Close()
+	if got := filepath.Join(dir, fmt.Sprintf(segmentFormat, sink.segment)); got != last {
+		t.Errorf("Expected to resume %s, got %s", last, got)
+	}
+}
+
+func TestFileSink_DropsIncompleteLastEntry(t *testing.T) {
+	dir := t.TempDir()
+	writeEntries(t, dir, 3)
+
+	segments, _ := listSegments(dir)
+	f, err := os.OpenFile(segments[len(segments)-1], os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"seq":4,"timest`)
+	f.Close()
+
+	writeEntries(t, dir, 2)
+	if n, err := Verify(ReadDir(dir)); err != nil || n != 5 {
+		t.Errorf("Expected 5 entries to verify after a torn write, got %d %v", n, err)
+	}
+}
+
+func TestLog_RecordWhileSegmentsRotate(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewFileSink(dir, 512)
+	if err != nil {
+		t.Fatalf("NewFileSink: %v", err)
+	}
+	log, err := NewLog(sink)
+	if err != nil {
+		t.Fatalf("NewLog: %v", err)
+	}
+
+	const writers, perWriter = 32, 50
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*perWriter)
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWriter {
+				errs <- log.Record(context.Background(), domain.AuditRecord{
+					Transaction: domain.Transaction{ID: fmt.Sprintf("tx-%d-%d", w, i)},
+					Final:       domain.RiskAssessment{Reason: "ok"},
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Expected every record to be written while segments rotate, got %v", err)
+		}
+	}
+	if segments, _ := listSegments(dir); len(segments) < 2 {
+		t.Fatalf("Expected the records to rotate segments, got %d", len(segments))
+	}
+	if n, err := Verify(ReadDir(dir)); err != nil || n != writers*perWriter {
+		t.Errorf("Expected %d entries to verify, got %d %v", writers*perWriter, n, err)
+	}
+}
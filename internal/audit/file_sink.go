@@ -0,0 +1,270 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/tokyosplif/ai-risk-engine/pkg/jsonl"
+)
+
+const (
+	segmentPattern         = "audit-*.jsonl"
+	segmentFormat          = "audit-%06d.jsonl"
+	DefaultMaxSegmentBytes = 64 << 20
+	maxEntryBytes          = 4 << 20
+)
+
+// FileSink appends entries as JSON lines to numbered segment files in a
+// directory, starting a new segment once the current one exceeds maxBytes.
+// Files are opened append-only. Sync commits every entry appended so far with
+// one fsync, so concurrent writers share it.
+type FileSink struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+	segment  int
+	file     *os.File
+	size     int64
+	written  uint64
+
+	syncMu sync.Mutex
+	synced uint64
+}
+
+func NewFileSink(dir string, maxBytes int64) (*FileSink, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxSegmentBytes
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit dir: %w", err)
+	}
+
+	s := &FileSink{dir: dir, maxBytes: maxBytes}
+
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+	s.segment = 1
+	for _, path := range segments {
+		var n int
+		if _, err := fmt.Sscanf(filepath.Base(path), segmentFormat, &n); err == nil && n > s.segment {
+			s.segment = n
+		}
+	}
+
+	if err := s.trimTail(); err != nil {
+		return nil, err
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// trimTail drops an entry left incomplete at the end of the current segment
+// by a crash. It was never synced, so no decision relied on it.
+func (s *FileSink) trimTail() error {
+	f, err := os.OpenFile(s.path(), os.O_RDWR, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open audit segment: %w", err)
+	}
+	defer f.Close()
+
+	_, dropped, err := jsonl.TrimPartial(f)
+	if err != nil {
+		return err
+	}
+	if dropped > 0 {
+		slog.Warn("dropped incomplete entry at the end of the audit log", "segment", filepath.Base(s.path()), "bytes", dropped)
+	}
+	return nil
+}
+
+func (s *FileSink) Append(e Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size > 0 && s.size+int64(len(data)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.file.Write(data); err != nil {
+		// Drop a partial write so that the next entry starts on its own line.
+		_ = s.file.Truncate(s.size)
+		return err
+	}
+	s.size += int64(len(data))
+	s.written++
+	return nil
+}
+
+// Sync makes every entry appended before the call durable. Callers that
+// arrive while an fsync is running share the next one.
+func (s *FileSink) Sync() error {
+	s.mu.Lock()
+	want := s.written
+	s.mu.Unlock()
+
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	if s.synced >= want {
+		return nil
+	}
+
+	s.mu.Lock()
+	target, f, segment := s.written, s.file, s.segment
+	s.mu.Unlock()
+	if err := f.Sync(); err != nil {
+		// An Append may have rotated f away meanwhile; rotate syncs a
+		// segment before closing it, so its entries are durable.
+		s.mu.Lock()
+		rotated := s.segment != segment
+		s.mu.Unlock()
+		if !rotated {
+			return err
+		}
+	}
+	s.synced = target
+	return nil
+}
+
+func (s *FileSink) Last() (Entry, bool, error) {
+	segments, err := listSegments(s.dir)
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	// Walk back past empty segments left by a rotation that crashed before writing.
+	for i := len(segments) - 1; i >= 0; i-- {
+		var last Entry
+		var readErr error
+		found := false
+		readSegment(segments[i], func(e Entry, err error) bool {
+			if err != nil {
+				readErr = err
+				return false
+			}
+			last = e
+			found = true
+			return true
+		})
+		if readErr != nil {
+			return Entry{}, false, readErr
+		}
+		if found {
+			return last, true, nil
+		}
+	}
+	return Entry{}, false, nil
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+func (s *FileSink) path() string {
+	return filepath.Join(s.dir, fmt.Sprintf(segmentFormat, s.segment))
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to open audit segment: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
+
+// rotate syncs and closes the current segment and opens the next one.
+// Callers must hold s.mu.
+func (s *FileSink) rotate() error {
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.segment++
+	return s.open()
+}
+
+// ReadDir yields every entry stored in the segment files of dir, in order.
+func ReadDir(dir string) iter.Seq2[Entry, error] {
+	return func(yield func(Entry, error) bool) {
+		segments, err := listSegments(dir)
+		if err != nil {
+			yield(Entry{}, err)
+			return
+		}
+
+		for _, path := range segments {
+			if !readSegment(path, yield) {
+				return
+			}
+		}
+	}
+}
+
+func readSegment(path string, yield func(Entry, error) bool) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return yield(Entry{}, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64<<10), maxEntryBytes)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return yield(Entry{}, fmt.Errorf("%w: malformed entry in %s: %v", ErrChainBroken, filepath.Base(path), err))
+		}
+		e.raw = bytes.Clone(scanner.Bytes())
+		if !yield(e, nil) {
+			return false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return yield(Entry{}, err)
+	}
+	return true
+}
+
+func listSegments(dir string) ([]string, error) {
+	segments, err := filepath.Glob(filepath.Join(dir, segmentPattern))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
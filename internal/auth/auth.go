@@ -0,0 +1,194 @@
+// Package auth authenticates engine callers by API key, JWT or client
+// certificate and authorizes them per RPC.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	RoleAnalyze = "analyze"
+	RoleAdmin   = "admin"
+	// RoleReview works the manual review queue.
+	RoleReview = "review"
+	// RoleFeedback reports transaction outcomes such as chargebacks.
+	RoleFeedback = "feedback"
+	// RolePublic in a policy entry lets anyone call the method without
+	// credentials.
+	RolePublic = "*"
+)
+
+const (
+	MethodAPIKey      = "api_key"
+	MethodJWT         = "jwt"
+	MethodCertificate = "certificate"
+)
+
+var (
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Identity is an authenticated caller. An empty Tenant means the caller may
+// act for any tenant.
+type Identity struct {
+	Subject string
+	Tenant  string
+	Roles   []string
+	Method  string
+}
+
+func (id Identity) HasRole(role string) bool {
+	return slices.Contains(id.Roles, role)
+}
+
+type ctxKey struct{}
+
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(ctxKey{}).(Identity)
+	return id, ok
+}
+
+// Client is a registered caller. KeySHA256 is the hex SHA-256 of its API key,
+// so the clients file never holds a usable secret; CertSubject is the common
+// name of its client certificate.
+type Client struct {
+	Name        string   `json:"name"`
+	Tenant      string   `json:"tenant,omitempty"`
+	Roles       []string `json:"roles"`
+	KeySHA256   string   `json:"key_sha256,omitempty"`
+	CertSubject string   `json:"cert_subject,omitempty"`
+}
+
+// LoadClients reads the registered clients from a JSON array.
+func LoadClients(path string) ([]Client, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clients file: %w", err)
+	}
+
+	var clients []Client
+	if err := json.Unmarshal(data, &clients); err != nil {
+		return nil, fmt.Errorf("failed to parse clients json: %w", err)
+	}
+	for _, c := range clients {
+		if c.Name == "" {
+			return nil, errors.New("client name is required")
+		}
+		if c.KeySHA256 == "" && c.CertSubject == "" {
+			return nil, fmt.Errorf("client %q: key_sha256 or cert_subject is required", c.Name)
+		}
+	}
+	return clients, nil
+}
+
+// HashKey returns the form of an API key stored in the clients file.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Credentials are what a caller presented. Token is an API key or a JWT;
+// CertSubject is the common name of a verified client certificate.
+type Credentials struct {
+	Token       string
+	CertSubject string
+}
+
+// Authenticator resolves credentials to an identity.
+type Authenticator struct {
+	clients []Client
+	jwt     *JWTVerifier
+}
+
+// NewAuthenticator accepts API keys and certificates of clients and, when
+// jwt is non-nil, tokens it verifies.
+func NewAuthenticator(clients []Client, jwt *JWTVerifier) *Authenticator {
+	return &Authenticator{clients: clients, jwt: jwt}
+}
+
+// Authenticate prefers a presented token over the client certificate.
+func (a *Authenticator) Authenticate(creds Credentials) (Identity, error) {
+	if creds.Token != "" {
+		if a.jwt != nil && strings.Count(creds.Token, ".") == 2 {
+			return a.jwt.Verify(creds.Token)
+		}
+		return a.byKey(creds.Token)
+	}
+	if creds.CertSubject != "" {
+		return a.byCert(creds.CertSubject)
+	}
+	return Identity{}, fmt.Errorf("%w: no credentials", ErrUnauthenticated)
+}
+
+func (a *Authenticator) byKey(key string) (Identity, error) {
+	hash := HashKey(key)
+	for _, c := range a.clients {
+		if c.KeySHA256 != "" && subtle.ConstantTimeCompare([]byte(strings.ToLower(c.KeySHA256)), []byte(hash)) == 1 {
+			return c.identity(MethodAPIKey), nil
+		}
+	}
+	return Identity{}, fmt.Errorf("%w: invalid api key", ErrUnauthenticated)
+}
+
+func (a *Authenticator) byCert(subject string) (Identity, error) {
+	for _, c := range a.clients {
+		if c.CertSubject != "" && c.CertSubject == subject {
+			return c.identity(MethodCertificate), nil
+		}
+	}
+	return Identity{}, fmt.Errorf("%w: unknown client certificate %q", ErrUnauthenticated, subject)
+}
+
+func (c Client) identity(method string) Identity {
+	return Identity{Subject: c.Name, Tenant: c.Tenant, Roles: c.Roles, Method: method}
+}
+
+// Policy maps a full gRPC method name, or a "/package.Service/" prefix, to the
+// roles allowed to call it. Methods without an entry are denied.
+type Policy map[string][]string
+
+// DefaultPolicy lets analysts score transactions, reviewers work the review
+// queue, feedback clients report outcomes and restricts the admin service to
+// admins.
+func DefaultPolicy(analyzeService, adminService, reviewService, feedbackService string) Policy {
+	return Policy{
+		"/" + analyzeService + "/":  {RoleAnalyze, RoleAdmin},
+		"/" + adminService + "/":    {RoleAdmin},
+		"/" + reviewService + "/":   {RoleReview, RoleAdmin},
+		"/" + feedbackService + "/": {RoleFeedback, RoleAdmin},
+	}
+}
+
+// Public reports whether method may be called without credentials.
+func (p Policy) Public(method string) bool {
+	return slices.Contains(p.roles(method), RolePublic)
+}
+
+func (p Policy) roles(method string) []string {
+	if roles, ok := p[method]; ok {
+		return roles
+	}
+	return p[method[:strings.LastIndex(method, "/")+1]]
+}
+
+// Authorize reports whether id may call method.
+func (p Policy) Authorize(id Identity, method string) error {
+	if roles := p.roles(method); slices.Contains(roles, RolePublic) || slices.ContainsFunc(roles, id.HasRole) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s may not call %s", ErrPermissionDenied, id.Subject, method)
+}
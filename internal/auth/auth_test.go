@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+)
+
+func signHS256(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	body, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshal claims: %v", err)
+	}
+	payload := header + "." + base64.RawURLEncoding.EncodeToString(body)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthenticate_APIKeyAndCertificate(t *testing.T) {
+	a := NewAuthenticator([]Client{
+		{Name: "acme-gateway", Tenant: "acme-bank", Roles: []string{RoleAnalyze}, KeySHA256: HashKey("secret-key")},
+		{Name: "ops", Roles: []string{RoleAdmin}, CertSubject: "ops.internal"},
+	}, nil)
+
+	id, err := a.Authenticate(Credentials{Token: "secret-key"})
+	if err != nil {
+		t.Fatalf("Expected api key to authenticate, got %v", err)
+	}
+	if id.Subject != "acme-gateway" || id.Tenant != "acme-bank" || id.Method != MethodAPIKey {
+		t.Errorf("Unexpected identity %+v", id)
+	}
+
+	if id, err := a.Authenticate(Credentials{CertSubject: "ops.internal"}); err != nil || id.Subject != "ops" {
+		t.Errorf("Expected certificate to authenticate ops, got %+v, %v", id, err)
+	}
+
+	for _, creds := range []Credentials{{Token: "wrong"}, {CertSubject: "stranger"}, {}} {
+		if _, err := a.Authenticate(creds); !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("Expected %+v to be rejected, got %v", creds, err)
+		}
+	}
+}
+
+func TestJWTVerifier(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	v := NewJWTVerifier(secrets.Static("old-secret", "jwt-secret"), "issuer", "risk-engine")
+	v.now = func() time.Time { return now }
+
+	valid := map[string]any{
+		"sub":    "svc-checkout",
+		"tenant": "acme-bank",
+		"roles":  []string{RoleAnalyze},
+		"iss":    "issuer",
+		"aud":    []string{"risk-engine"},
+		"exp":    now.Add(time.Minute).Unix(),
+	}
+
+	id, err := v.Verify(signHS256(t, "jwt-secret", valid))
+	if err != nil {
+		t.Fatalf("Expected token to verify, got %v", err)
+	}
+	if id.Subject != "svc-checkout" || id.Tenant != "acme-bank" || !id.HasRole(RoleAnalyze) {
+		t.Errorf("Unexpected identity %+v", id)
+	}
+	if _, err := v.Verify(signHS256(t, "old-secret", valid)); err != nil {
+		t.Errorf("Expected a token signed with the previous secret to verify during rotation, got %v", err)
+	}
+
+	cases := map[string]string{
+		"wrong secret": signHS256(t, "other", valid),
+		"expired":      signHS256(t, "jwt-secret", with(valid, "exp", now.Add(-time.Second).Unix())),
+		"no expiry":    signHS256(t, "jwt-secret", with(valid, "exp", 0)),
+		"issuer":       signHS256(t, "jwt-secret", with(valid, "iss", "someone-else")),
+		"audience":     signHS256(t, "jwt-secret", with(valid, "aud", "billing")),
+		"alg none":     "eyJhbGciOiJub25lIn0.e30.",
+	}
+	for name, token := range cases {
+		if _, err := v.Verify(token); !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("%s: expected rejection, got %v", name, err)
+		}
+	}
+}
+
+func with(claims map[string]any, key string, value any) map[string]any {
+	out := make(map[string]any, len(claims))
+	for k, v := range claims {
+		out[k] = v
+	}
+	out[key] = value
+	return out
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	p := DefaultPolicy("riskengine.RiskEngineService", "riskengine.RiskAdminService", "riskengine.RiskReviewService", "riskengine.RiskFeedbackService")
+
+	analyst := Identity{Subject: "svc", Roles: []string{RoleAnalyze}}
+	admin := Identity{Subject: "ops", Roles: []string{RoleAdmin}}
+
+	if err := p.Authorize(analyst, "/riskengine.RiskEngineService/AnalyzeTransaction"); err != nil {
+		t.Errorf("Expected analyst to analyze, got %v", err)
+	}
+	if err := p.Authorize(analyst, "/riskengine.RiskAdminService/AddListEntry"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected analyst to be denied admin RPCs, got %v", err)
+	}
+	if err := p.Authorize(admin, "/riskengine.RiskAdminService/AddListEntry"); err != nil {
+		t.Errorf("Expected admin to call admin RPCs, got %v", err)
+	}
+	reviewer := Identity{Subject: "jane", Roles: []string{RoleReview}}
+	if err := p.Authorize(reviewer, "/riskengine.RiskReviewService/ClaimReviewCase"); err != nil {
+		t.Errorf("Expected reviewer to claim cases, got %v", err)
+	}
+	if err := p.Authorize(analyst, "/riskengine.RiskReviewService/ClaimReviewCase"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected analyst to be denied review RPCs, got %v", err)
+	}
+	feedback := Identity{Subject: "chargebacks", Roles: []string{RoleFeedback}}
+	if err := p.Authorize(feedback, "/riskengine.RiskFeedbackService/ReportOutcome"); err != nil {
+		t.Errorf("Expected feedback clients to report outcomes, got %v", err)
+	}
+	if err := p.Authorize(analyst, "/riskengine.RiskFeedbackService/ReportOutcome"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected analyst to be denied feedback RPCs, got %v", err)
+	}
+	if err := p.Authorize(admin, "/other.Service/Call"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected unlisted methods to be denied, got %v", err)
+	}
+}
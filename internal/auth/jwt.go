@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+)
+
+// JWTVerifier accepts HS256 tokens signed with any of the secrets of a key
+// ring, so that a secret can be rotated without rejecting tokens signed with
+// the old one. Tokens must carry exp; iss and aud are checked when configured.
+type JWTVerifier struct {
+	secrets  *secrets.KeyRing
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+func NewJWTVerifier(keys *secrets.KeyRing, issuer, audience string) *JWTVerifier {
+	return &JWTVerifier{secrets: keys, issuer: issuer, audience: audience, now: time.Now}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	Tenant    string   `json:"tenant"`
+	Roles     []string `json:"roles"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+}
+
+// audience decodes both the string and the array form of aud.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*a = audience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (v *JWTVerifier) Verify(token string) (Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Identity{}, fmt.Errorf("%w: malformed token", ErrUnauthenticated)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return Identity{}, fmt.Errorf("%w: unsupported token algorithm", ErrUnauthenticated)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: malformed signature", ErrUnauthenticated)
+	}
+	if !v.signedByAny(parts[0]+"."+parts[1], sig) {
+		return Identity{}, fmt.Errorf("%w: invalid token signature", ErrUnauthenticated)
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Identity{}, fmt.Errorf("%w: malformed claims", ErrUnauthenticated)
+	}
+
+	now := v.now().Unix()
+	switch {
+	case claims.Subject == "":
+		return Identity{}, fmt.Errorf("%w: token has no subject", ErrUnauthenticated)
+	case claims.ExpiresAt == 0 || now >= claims.ExpiresAt:
+		return Identity{}, fmt.Errorf("%w: token expired", ErrUnauthenticated)
+	case claims.NotBefore != 0 && now < claims.NotBefore:
+		return Identity{}, fmt.Errorf("%w: token not yet valid", ErrUnauthenticated)
+	case v.issuer != "" && claims.Issuer != v.issuer:
+		return Identity{}, fmt.Errorf("%w: unexpected token issuer", ErrUnauthenticated)
+	case v.audience != "" && !slices.Contains(claims.Audience, v.audience):
+		return Identity{}, fmt.Errorf("%w: unexpected token audience", ErrUnauthenticated)
+	}
+
+	return Identity{Subject: claims.Subject, Tenant: claims.Tenant, Roles: claims.Roles, Method: MethodJWT}, nil
+}
+
+func (v *JWTVerifier) signedByAny(payload string, sig []byte) bool {
+	for _, secret := range v.secrets.Values() {
+		mac := hmac.New(sha256.New, []byte(secret.Reveal()))
+		mac.Write([]byte(payload))
+		if hmac.Equal(sig, mac.Sum(nil)) {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -1,38 +1,473 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Port        string
-	LogLevel    string
-	Groq        GroqConfig
-	PromptsPath string
+	Port        string `yaml:"port"`
+	MetricsPort string `yaml:"metrics_port"`
+	// HTTPPort serves the HTTP/JSON gateway; empty disables it.
+	HTTPPort string        `yaml:"http_port"`
+	Log      LogConfig     `yaml:"log"`
+	Tracing  TracingConfig `yaml:"tracing"`
+	Audit    AuditConfig   `yaml:"audit"`
+	TLS      TLSConfig     `yaml:"tls"`
+	Auth     AuthConfig    `yaml:"auth"`
+	Review   ReviewConfig  `yaml:"review"`
+	Labels   LabelsConfig  `yaml:"labels"`
+	Events   EventsConfig  `yaml:"events"`
+	Notify   NotifyConfig  `yaml:"notifications"`
+	// ShutdownTimeout bounds how long in-flight requests are drained on
+	// shutdown, and then how long components get to close.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// ClientRateLimit applies to each caller on top of its tenant's limit.
+	ClientRateLimit RateLimitConfig `yaml:"client_rate_limit"`
+	Groq            GroqConfig      `yaml:"llm"`
+	// Thresholds are the engine-wide limits, in the base currency, that
+	// tenants inherit unless they set their own.
+	Thresholds  TenantThresholds `yaml:"thresholds"`
+	PromptsPath string           `yaml:"prompts_path"`
+	ListsPath   string           `yaml:"lists_path"`
+	// MerchantsPath points at the merchant catalog.
+	MerchantsPath string `yaml:"merchants_path"`
+	// FXRatesPath points at the FX rates and per-currency threshold file.
+	FXRatesPath string `yaml:"fx_rates_path"`
+	// TenantsPath points at the tenant-scoped configuration.
+	TenantsPath string `yaml:"tenants_path"`
+	// GeoDBPath points at the offline geo database used for location and IP
+	// enrichment.
+	GeoDBPath string `yaml:"geo_db_path"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+	// DebugSampleRate keeps one of every N debug log records.
+	DebugSampleRate int `yaml:"debug_sample_rate"`
 }
 
 type GroqConfig struct {
-	APIKey  string
-	BaseURL string
-	Model   string
+	// APIKey holds one or more comma-separated keys. APIKeyFile lists more,
+	// one per line, and is re-read when it changes.
+	APIKey     secrets.Value `yaml:"api_key"`
+	APIKeyFile string        `yaml:"api_key_file"`
+	// KeyStrategy picks among several keys: round_robin or failover. A key
+	// answered with 429 sits out KeyCooldown.
+	KeyStrategy string        `yaml:"key_strategy"`
+	KeyCooldown time.Duration `yaml:"key_cooldown"`
+	BaseURL     string        `yaml:"base_url"`
+	Model       string        `yaml:"model"`
+	// PromptVersion is the prompt used by tenants that do not pick one.
+	PromptVersion string        `yaml:"prompt_version"`
+	Temperature   float64       `yaml:"temperature"`
+	Timeout       time.Duration `yaml:"timeout"`
+	// MaxIdleConns and IdleConnTimeout size the provider connection pool.
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	IdleConnTimeout time.Duration `yaml:"idle_conn_timeout"`
+	// MaxConcurrent bounds in-flight provider calls across all tenants; zero
+	// disables the bound. Up to MaxQueued further calls wait QueueTimeout for
+	// a slot before the degradation policy applies.
+	MaxConcurrent int           `yaml:"max_concurrent"`
+	MaxQueued     int           `yaml:"max_queued"`
+	QueueTimeout  time.Duration `yaml:"queue_timeout"`
+	// BreakerThreshold consecutive provider failures open the circuit for
+	// BreakerCooldown; zero disables the breaker.
+	BreakerThreshold int           `yaml:"breaker_threshold"`
+	BreakerCooldown  time.Duration `yaml:"breaker_cooldown"`
+}
+
+type AuditConfig struct {
+	// Dir is where audit segments are written. Auditing is disabled when empty.
+	Dir             string `yaml:"dir"`
+	MaxSegmentBytes int64  `yaml:"max_segment_bytes"`
+}
+
+// TLSConfig enables TLS on the gRPC port when CertFile and KeyFile are set.
+// With ClientCAFile, client certificates signed by that CA are verified;
+// ClientAuth "require" rejects connections without one.
+type TLSConfig struct {
+	CertFile     string `yaml:"cert_file"`
+	KeyFile      string `yaml:"key_file"`
+	ClientCAFile string `yaml:"client_ca_file"`
+	ClientAuth   string `yaml:"client_auth"`
+}
+
+// AuthConfig enables caller authentication when ClientsPath or a JWT secret
+// is set.
+type AuthConfig struct {
+	// ClientsPath lists the callers accepted by API key or certificate.
+	ClientsPath string `yaml:"clients_path"`
+	// JWTSecret verifies HS256 bearer tokens. JWTSecretFile adds secrets, one
+	// per line, so that tokens signed with the old and the new secret are
+	// both accepted while it rotates.
+	JWTSecret     secrets.Value `yaml:"jwt_secret"`
+	JWTSecretFile string        `yaml:"jwt_secret_file"`
+	JWTIssuer     string        `yaml:"jwt_issuer"`
+	JWTAudience   string        `yaml:"jwt_audience"`
+}
+
+func (c AuthConfig) Enabled() bool {
+	return c.ClientsPath != "" || c.JWTEnabled()
+}
+
+func (c AuthConfig) JWTEnabled() bool {
+	return c.JWTSecret != "" || c.JWTSecretFile != ""
+}
+
+// ReviewConfig is the manual review queue. Tenants may set their own SLA and
+// outcome callback in the tenants file.
+type ReviewConfig struct {
+	// Path is the JSON file that holds the queue.
+	Path string `yaml:"path"`
+	// SLA is how long analysts have to resolve a case before it is
+	// escalated.
+	SLA time.Duration `yaml:"sla"`
+	// ClaimTTL returns a claimed case to the queue if it is not resolved in
+	// time; zero keeps claims until resolved.
+	ClaimTTL time.Duration `yaml:"claim_ttl"`
+	// Retention is how long resolved cases are kept; zero keeps them.
+	Retention       time.Duration `yaml:"retention"`
+	CallbackTimeout time.Duration `yaml:"callback_timeout"`
+}
+
+// LabelsConfig is the ingestion of transaction outcomes such as chargebacks.
+type LabelsConfig struct {
+	// Path is the JSON Lines file that holds the labels.
+	Path string `yaml:"path"`
+	// DecisionCache is how many recent decisions are kept in memory to be
+	// joined with the labels reported on them.
+	DecisionCache int `yaml:"decision_cache"`
+	// DecisionsDir holds the log of every decision, kept for
+	// DecisionRetention, which joins the labels that miss the cache.
+	DecisionsDir      string        `yaml:"decisions_dir"`
+	DecisionRetention time.Duration `yaml:"decision_retention"`
+	// DenyEntities are denylisted when a transaction is confirmed as fraud:
+	// user, device or ip. The entries apply to the label's tenant only.
+	DenyEntities []string `yaml:"deny_entities"`
+	// DenyTTL is how long those entries last; zero keeps them until removed.
+	DenyTTL time.Duration `yaml:"deny_ttl"`
+}
+
+// EventsConfig publishes every decision to downstream systems through a
+// durable outbox. Events are off unless a sink is set.
+type EventsConfig struct {
+	// OutboxDir holds the undelivered events and each sink's progress.
+	OutboxDir string `yaml:"outbox_dir"`
+	// OutboxMaxBytes bounds the undelivered backlog; past it new events are
+	// dropped. Zero leaves it unbounded.
+	OutboxMaxBytes int64 `yaml:"outbox_max_bytes"`
+	// File appends events to a local JSON Lines file.
+	File string `yaml:"file"`
+	// WebhookURL receives events signed with WebhookSecret.
+	WebhookURL     string        `yaml:"webhook_url"`
+	WebhookSecret  secrets.Value `yaml:"webhook_secret"`
+	WebhookTimeout time.Duration `yaml:"webhook_timeout"`
+	// NATSURL is a nats:// server URL, which may carry credentials; events
+	// go to NATSSubject.
+	NATSURL     secrets.Value `yaml:"nats_url"`
+	NATSSubject string        `yaml:"nats_subject"`
+	NATSTimeout time.Duration `yaml:"nats_timeout"`
+}
+
+// NotifyConfig is the push message shown to the user for a decision.
+type NotifyConfig struct {
+	// TemplatesPath holds the per-decision, per-locale templates and the
+	// policies the model's message must pass.
+	TemplatesPath string `yaml:"templates_path"`
+	// Decisions are sent to the user through WebhookURL.
+	Decisions []string `yaml:"decisions"`
+	// WebhookURL is the push gateway; notifications are off when unset.
+	WebhookURL    string        `yaml:"webhook_url"`
+	WebhookSecret secrets.Value `yaml:"webhook_secret"`
+	Timeout       time.Duration `yaml:"timeout"`
+	// QueueSize bounds the notifications waiting to be sent.
+	QueueSize int `yaml:"queue_size"`
 }
 
-func Load() *Config {
+func (c EventsConfig) Enabled() bool {
+	return c.File != "" || c.WebhookURL != "" || c.NATSURL != ""
+}
+
+type TracingConfig struct {
+	Exporter string `yaml:"exporter"`
+}
+
+// Defaults returns the built-in configuration.
+func Defaults() *Config {
 	return &Config{
-		Port:        getEnv("PORT", ":50051"),
-		LogLevel:    getEnv("LOG_LEVEL", "info"),
-		PromptsPath: getEnv("PROMPTS_PATH", "prompts.json"),
+		Port:          ":50051",
+		MetricsPort:   ":9090",
+		HTTPPort:      ":8080",
+		PromptsPath:   "prompts.json",
+		ListsPath:     "lists.json",
+		MerchantsPath: "merchants.json",
+		FXRatesPath:   "fx_rates.json",
+		GeoDBPath:     "geo.json",
+		TenantsPath:   "tenants.json",
+		Log: LogConfig{
+			Level:           "info",
+			Format:          "json",
+			DebugSampleRate: 1,
+		},
+		Tracing: TracingConfig{
+			Exporter: "none",
+		},
+		Audit: AuditConfig{
+			MaxSegmentBytes: 64 << 20,
+		},
+		TLS: TLSConfig{
+			ClientAuth: "optional",
+		},
+		Review: ReviewConfig{
+			Path:            "reviews.json",
+			SLA:             4 * time.Hour,
+			ClaimTTL:        30 * time.Minute,
+			Retention:       30 * 24 * time.Hour,
+			CallbackTimeout: 5 * time.Second,
+		},
+		Labels: LabelsConfig{
+			Path:              "labels.jsonl",
+			DecisionCache:     100_000,
+			DecisionsDir:      "decisions",
+			DecisionRetention: 180 * 24 * time.Hour,
+			DenyEntities:      []string{domain.EntityDevice},
+			DenyTTL:           90 * 24 * time.Hour,
+		},
+		Events: EventsConfig{
+			OutboxDir:      "outbox",
+			OutboxMaxBytes: 256 << 20,
+			WebhookTimeout: 5 * time.Second,
+			NATSSubject:    "risk.decisions",
+			NATSTimeout:    5 * time.Second,
+		},
+		Notify: NotifyConfig{
+			TemplatesPath: "notifications.json",
+			Decisions:     []string{domain.DecisionBlock, domain.DecisionReview},
+			Timeout:       5 * time.Second,
+			QueueSize:     1000,
+		},
+		ShutdownTimeout: 20 * time.Second,
 		Groq: GroqConfig{
-			APIKey:  os.Getenv("GROQ_API_KEY"),
-			BaseURL: getEnv("GROQ_BASE_URL", "https://api.groq.com/openai/v1"),
-			Model:   getEnv("GROQ_MODEL", "llama-3.3-70b-versatile"),
+			BaseURL:          "https://api.groq.com/openai/v1",
+			Model:            "llama-3.3-70b-versatile",
+			KeyStrategy:      secrets.RoundRobin,
+			KeyCooldown:      time.Minute,
+			PromptVersion:    "antifraud_v1",
+			Temperature:      0.1,
+			Timeout:          15 * time.Second,
+			MaxIdleConns:     50,
+			IdleConnTimeout:  30 * time.Second,
+			MaxConcurrent:    16,
+			MaxQueued:        64,
+			QueueTimeout:     2 * time.Second,
+			BreakerThreshold: 5,
+			BreakerCooldown:  30 * time.Second,
 		},
 	}
 }
 
-func getEnv(key, defaultValue string) string {
+// Load builds the configuration from, in increasing precedence, the built-in
+// defaults, the config file, environment variables and command-line flags,
+// and validates the result.
+func Load(f *Flags) (*Config, error) {
+	if f == nil {
+		f = &Flags{}
+	}
+	cfg := Defaults()
+
+	if f.ConfigPath != "" {
+		if err := loadFile(f.ConfigPath, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	env := &envReader{}
+	env.apply(cfg)
+	if err := errors.Join(env.errs...); err != nil {
+		return nil, err
+	}
+
+	for _, override := range f.overrides {
+		override(cfg)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile decodes a YAML or JSON config file over cfg. Unknown keys are
+// rejected so that typos do not silently fall back to defaults.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// envReader overrides configuration values with the environment variables
+// that are set, collecting the ones that do not parse.
+type envReader struct {
+	errs []error
+}
+
+func (e *envReader) apply(c *Config) {
+	e.str(&c.Port, "PORT")
+	e.str(&c.MetricsPort, "METRICS_PORT")
+	e.str(&c.HTTPPort, "HTTP_PORT")
+	e.str(&c.PromptsPath, "PROMPTS_PATH")
+	e.str(&c.ListsPath, "LISTS_PATH")
+	e.str(&c.MerchantsPath, "MERCHANTS_PATH")
+	e.str(&c.FXRatesPath, "FX_RATES_PATH")
+	e.str(&c.GeoDBPath, "GEO_DB_PATH")
+	e.str(&c.TenantsPath, "TENANTS_PATH")
+
+	e.str(&c.Log.Level, "LOG_LEVEL")
+	e.str(&c.Log.Format, "LOG_FORMAT")
+	e.int(&c.Log.DebugSampleRate, "LOG_DEBUG_SAMPLE_RATE")
+
+	e.str(&c.Tracing.Exporter, "TRACING_EXPORTER")
+
+	e.str(&c.Audit.Dir, "AUDIT_DIR")
+	e.int64(&c.Audit.MaxSegmentBytes, "AUDIT_MAX_SEGMENT_BYTES")
+
+	e.str(&c.TLS.CertFile, "TLS_CERT_FILE")
+	e.str(&c.TLS.KeyFile, "TLS_KEY_FILE")
+	e.str(&c.TLS.ClientCAFile, "TLS_CLIENT_CA_FILE")
+	e.str(&c.TLS.ClientAuth, "TLS_CLIENT_AUTH")
+
+	e.str(&c.Auth.ClientsPath, "AUTH_CLIENTS_PATH")
+	e.secret(&c.Auth.JWTSecret, "AUTH_JWT_SECRET")
+	e.str(&c.Auth.JWTSecretFile, "AUTH_JWT_SECRET_FILE")
+	e.str(&c.Auth.JWTIssuer, "AUTH_JWT_ISSUER")
+	e.str(&c.Auth.JWTAudience, "AUTH_JWT_AUDIENCE")
+
+	e.str(&c.Review.Path, "REVIEW_PATH")
+	e.millis(&c.Review.SLA, "REVIEW_SLA_MS")
+	e.millis(&c.Review.ClaimTTL, "REVIEW_CLAIM_TTL_MS")
+	e.millis(&c.Review.Retention, "REVIEW_RETENTION_MS")
+	e.millis(&c.Review.CallbackTimeout, "REVIEW_CALLBACK_TIMEOUT_MS")
+
+	e.str(&c.Labels.Path, "LABELS_PATH")
+	e.int(&c.Labels.DecisionCache, "LABELS_DECISION_CACHE")
+	e.str(&c.Labels.DecisionsDir, "LABELS_DECISIONS_DIR")
+	e.millis(&c.Labels.DecisionRetention, "LABELS_DECISION_RETENTION_MS")
+	e.list(&c.Labels.DenyEntities, "LABELS_DENY_ENTITIES")
+	e.millis(&c.Labels.DenyTTL, "LABELS_DENY_TTL_MS")
+
+	e.str(&c.Events.OutboxDir, "EVENTS_OUTBOX_DIR")
+	e.int64(&c.Events.OutboxMaxBytes, "EVENTS_OUTBOX_MAX_BYTES")
+	e.str(&c.Events.File, "EVENTS_FILE")
+	e.str(&c.Events.WebhookURL, "EVENTS_WEBHOOK_URL")
+	e.secret(&c.Events.WebhookSecret, "EVENTS_WEBHOOK_SECRET")
+	e.millis(&c.Events.WebhookTimeout, "EVENTS_WEBHOOK_TIMEOUT_MS")
+	e.secret(&c.Events.NATSURL, "EVENTS_NATS_URL")
+	e.str(&c.Events.NATSSubject, "EVENTS_NATS_SUBJECT")
+	e.millis(&c.Events.NATSTimeout, "EVENTS_NATS_TIMEOUT_MS")
+
+	e.str(&c.Notify.TemplatesPath, "NOTIFY_TEMPLATES_PATH")
+	e.list(&c.Notify.Decisions, "NOTIFY_DECISIONS")
+	e.str(&c.Notify.WebhookURL, "NOTIFY_WEBHOOK_URL")
+	e.secret(&c.Notify.WebhookSecret, "NOTIFY_WEBHOOK_SECRET")
+	e.millis(&c.Notify.Timeout, "NOTIFY_TIMEOUT_MS")
+	e.int(&c.Notify.QueueSize, "NOTIFY_QUEUE_SIZE")
+
+	e.millis(&c.ShutdownTimeout, "SHUTDOWN_TIMEOUT_MS")
+	e.float(&c.ClientRateLimit.RPS, "RATE_LIMIT_CLIENT_RPS")
+	e.int(&c.ClientRateLimit.Burst, "RATE_LIMIT_CLIENT_BURST")
+
+	e.secret(&c.Groq.APIKey, "GROQ_API_KEY")
+	e.str(&c.Groq.APIKeyFile, "GROQ_API_KEY_FILE")
+	e.str(&c.Groq.KeyStrategy, "LLM_KEY_STRATEGY")
+	e.millis(&c.Groq.KeyCooldown, "LLM_KEY_COOLDOWN_MS")
+	e.str(&c.Groq.BaseURL, "GROQ_BASE_URL")
+	e.str(&c.Groq.Model, "GROQ_MODEL")
+	e.str(&c.Groq.PromptVersion, "LLM_PROMPT_VERSION")
+	e.float(&c.Groq.Temperature, "LLM_TEMPERATURE")
+	e.millis(&c.Groq.Timeout, "LLM_TIMEOUT_MS")
+	e.int(&c.Groq.MaxIdleConns, "LLM_MAX_IDLE_CONNS")
+	e.millis(&c.Groq.IdleConnTimeout, "LLM_IDLE_CONN_TIMEOUT_MS")
+	e.int(&c.Groq.MaxConcurrent, "LLM_MAX_CONCURRENT")
+	e.int(&c.Groq.MaxQueued, "LLM_MAX_QUEUED")
+	e.millis(&c.Groq.QueueTimeout, "LLM_QUEUE_TIMEOUT_MS")
+	e.int(&c.Groq.BreakerThreshold, "LLM_BREAKER_THRESHOLD")
+	e.millis(&c.Groq.BreakerCooldown, "LLM_BREAKER_COOLDOWN_MS")
+}
+
+func (e *envReader) str(dst *string, key string) {
+	if value, exists := os.LookupEnv(key); exists {
+		*dst = value
+	}
+}
+
+// list reads a comma-separated list; an empty value clears it.
+func (e *envReader) list(dst *[]string, key string) {
 	if value, exists := os.LookupEnv(key); exists {
-		return value
+		*dst = nil
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				*dst = append(*dst, v)
+			}
+		}
+	}
+}
+
+func (e *envReader) secret(dst *secrets.Value, key string) {
+	if value, exists := os.LookupEnv(key); exists {
+		*dst = secrets.Value(value)
+	}
+}
+
+func (e *envReader) int(dst *int, key string) {
+	parseEnv(e, dst, key, strconv.Atoi)
+}
+
+func (e *envReader) int64(dst *int64, key string) {
+	parseEnv(e, dst, key, func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) })
+}
+
+func (e *envReader) float(dst *float64, key string) {
+	parseEnv(e, dst, key, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+}
+
+func (e *envReader) millis(dst *time.Duration, key string) {
+	parseEnv(e, dst, key, func(s string) (time.Duration, error) {
+		n, err := strconv.Atoi(s)
+		return time.Duration(n) * time.Millisecond, err
+	})
+}
+
+// parseEnv sets dst from key when it is set and parses, and records an error
+// when it does not.
+func parseEnv[T any](e *envReader, dst *T, key string, parse func(string) (T, error)) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return
+	}
+	v, err := parse(value)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: invalid value %q", key, value))
+		return
 	}
-	return defaultValue
+	*dst = v
 }
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func parseFlags(t *testing.T, args ...string) *Flags {
+	t.Helper()
+
+	f, err := ParseFlags("risk-engine", args, io.Discard)
+	if err != nil {
+		t.Fatalf("ParseFlags: %v", err)
+	}
+	return f
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfig(t, "config.yaml", `
+port: ":6000"
+log:
+  level: warn
+llm:
+  model: file-model
+  timeout: 5s
+  temperature: 0.3
+thresholds:
+  high_value: 2500
+`)
+	t.Setenv("GROQ_MODEL", "env-model")
+	t.Setenv("LLM_TIMEOUT_MS", "7000")
+
+	cfg, err := Load(parseFlags(t, "--config", path, "--model", "flag-model"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.Port != ":6000" || cfg.Log.Level != "warn" {
+		t.Errorf("Expected file values, got port %q level %q", cfg.Port, cfg.Log.Level)
+	}
+	if cfg.Groq.Timeout != 7*time.Second {
+		t.Errorf("Expected env to override the file timeout, got %v", cfg.Groq.Timeout)
+	}
+	if cfg.Groq.Model != "flag-model" {
+		t.Errorf("Expected the flag to override env and file, got %q", cfg.Groq.Model)
+	}
+	if cfg.Groq.Temperature != 0.3 || cfg.Thresholds.HighValue.String() != "2500" {
+		t.Errorf("Unexpected file values %v %q", cfg.Groq.Temperature, cfg.Thresholds.HighValue)
+	}
+	if cfg.MetricsPort != ":9090" {
+		t.Errorf("Expected defaults for unset values, got %q", cfg.MetricsPort)
+	}
+}
+
+func TestLoad_JSONFile(t *testing.T) {
+	path := writeConfig(t, "config.json", `{"http_port": "", "llm": {"queue_timeout": "500ms"}}`)
+
+	cfg, err := Load(parseFlags(t, "--config", path))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.HTTPPort != "" || cfg.Groq.QueueTimeout != 500*time.Millisecond {
+		t.Errorf("Unexpected values %q %v", cfg.HTTPPort, cfg.Groq.QueueTimeout)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  map[string]string
+		want []string
+	}{
+		{"unknown key", "llm:\n  modle: x\n", nil, []string{"modle"}},
+		{"bad env value", "", map[string]string{"LLM_MAX_QUEUED": "lots"}, []string{"LLM_MAX_QUEUED"}},
+		{
+			"invalid values",
+			"port: 50051\nlog:\n  level: loud\nllm:\n  temperature: 3\ntls:\n  cert_file: server.crt\nthresholds:\n  low_value: abc\n",
+			nil,
+			[]string{"port:", "log.level", "llm.temperature", "tls: cert_file and key_file", "thresholds.low_value"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			_, err := Load(parseFlags(t, "--config", writeConfig(t, "config.yaml", tc.file)))
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(err.Error(), w) {
+					t.Errorf("Expected the error to mention %q, got %v", w, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPrint_RedactsSecrets(t *testing.T) {
+	t.Setenv("GROQ_API_KEY", "gsk_live_secret")
+	t.Setenv("AUTH_JWT_SECRET", "jwt-secret")
+
+	cfg, err := Load(parseFlags(t))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := cfg.Print(&buf); err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "gsk_live_secret") || strings.Contains(out, "jwt-secret") {
+		t.Errorf("Expected secrets to be redacted:\n%s", out)
+	}
+	if strings.Count(out, secrets.Redacted) != 2 {
+		t.Errorf("Expected two redacted values:\n%s", out)
+	}
+	if cfg.Groq.APIKey.Reveal() != "gsk_live_secret" {
+		t.Error("Expected Print not to modify the configuration")
+	}
+}
+
+func TestChanged(t *testing.T) {
+	old, cur := Defaults(), Defaults()
+	cur.Port = ":6000"
+	cur.Groq.Timeout = time.Second
+
+	got := Changed(old, cur)
+	if strings.Join(got, ",") != "port,llm.timeout" {
+		t.Errorf("Unexpected changed keys %v", got)
+	}
+}
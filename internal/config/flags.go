@@ -0,0 +1,68 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+)
+
+// Flags are the command-line options. They are parsed once and reapplied on
+// every Load, so a reload keeps the overrides given at startup.
+type Flags struct {
+	// ConfigPath is the YAML or JSON config file; it defaults to CONFIG_PATH.
+	// Empty means defaults and environment only.
+	ConfigPath string
+	// PrintConfig asks for the effective configuration to be printed, with
+	// secrets redacted, instead of starting the server.
+	PrintConfig bool
+
+	overrides []func(*Config)
+}
+
+// stringFlags override the settings most often changed per run. Every other
+// setting comes from the config file or the environment.
+var stringFlags = []struct {
+	name  string
+	usage string
+	field func(*Config) *string
+}{
+	{"port", "gRPC listen address", func(c *Config) *string { return &c.Port }},
+	{"http-port", "HTTP/JSON gateway listen address, empty disables it", func(c *Config) *string { return &c.HTTPPort }},
+	{"metrics-port", "metrics and health listen address", func(c *Config) *string { return &c.MetricsPort }},
+	{"log-level", "log level: debug, info, warn or error", func(c *Config) *string { return &c.Log.Level }},
+	{"log-format", "log format: json or text", func(c *Config) *string { return &c.Log.Format }},
+	{"tenants", "tenants file", func(c *Config) *string { return &c.TenantsPath }},
+	{"prompts", "prompts file", func(c *Config) *string { return &c.PromptsPath }},
+	{"model", "default LLM model", func(c *Config) *string { return &c.Groq.Model }},
+}
+
+// ParseFlags parses the command-line arguments, without the program name.
+func ParseFlags(name string, args []string, output io.Writer) (*Flags, error) {
+	f := &Flags{}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+
+	fs.StringVar(&f.ConfigPath, "config", "", "config file (YAML or JSON); defaults to $CONFIG_PATH")
+	fs.BoolVar(&f.PrintConfig, "print-config", false, "print the effective configuration with secrets redacted and exit")
+	values := make(map[string]*string, len(stringFlags))
+	for _, sf := range stringFlags {
+		values[sf.name] = fs.String(sf.name, "", sf.usage)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if f.ConfigPath == "" {
+		f.ConfigPath = os.Getenv("CONFIG_PATH")
+	}
+	fs.Visit(func(fl *flag.Flag) {
+		for _, sf := range stringFlags {
+			if sf.name == fl.Name {
+				value, field := *values[sf.name], sf.field
+				f.overrides = append(f.overrides, func(c *Config) { *field(c) = value })
+			}
+		}
+	})
+	return f, nil
+}
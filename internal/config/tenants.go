@@ -0,0 +1,99 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+// TenantsConfig is the tenant-scoped configuration loaded from TENANTS_PATH.
+type TenantsConfig struct {
+	// Default is the tenant used when a request does not name one.
+	Default string                  `json:"default"`
+	Tenants map[string]TenantConfig `json:"tenants"`
+}
+
+// TenantConfig overrides the engine defaults for one tenant. Zero values
+// inherit the default.
+type TenantConfig struct {
+	PromptVersion string `json:"prompt_version"`
+	// Rules and Prescreen name the heuristic rules and prescreen checks to
+	// run. Absent means all of them; an empty list disables the stage.
+	Rules      []string         `json:"rules"`
+	Prescreen  []string         `json:"prescreen"`
+	Thresholds TenantThresholds `json:"thresholds"`
+	LLM        TenantLLMConfig  `json:"llm"`
+	// Degradation is what to return when the LLM is unavailable: allow,
+	// block or review.
+	Degradation string             `json:"degradation"`
+	RateLimit   RateLimitConfig    `json:"rate_limit"`
+	Review      TenantReviewConfig `json:"review"`
+}
+
+// TenantThresholds are decimal amounts in the base currency.
+type TenantThresholds struct {
+	LowValue          json.Number `json:"low_value" yaml:"low_value,omitempty"`
+	HeuristicBlockMin json.Number `json:"heuristic_block_min" yaml:"heuristic_block_min,omitempty"`
+	HighValue         json.Number `json:"high_value" yaml:"high_value,omitempty"`
+	PrescreenAllowMax json.Number `json:"prescreen_allow_max" yaml:"prescreen_allow_max,omitempty"`
+}
+
+type TenantLLMConfig struct {
+	BaseURL string `json:"base_url"`
+	Model   string `json:"model"`
+	// APIKeyEnv names the environment variable holding the provider keys and
+	// APIKeyFile a file listing them, so that keys never live in the tenants
+	// file.
+	APIKeyEnv  string `json:"api_key_env"`
+	APIKeyFile string `json:"api_key_file"`
+}
+
+// TenantReviewConfig overrides the review SLA, as a Go duration such as
+// "2h", and names the URL that receives the outcome of the tenant's cases.
+type TenantReviewConfig struct {
+	SLA         string `json:"sla"`
+	CallbackURL string `json:"callback_url"`
+}
+
+// RateLimitConfig is a token bucket; a zero RPS means unlimited.
+type RateLimitConfig struct {
+	RPS   float64 `json:"rps" yaml:"rps"`
+	Burst int     `json:"burst" yaml:"burst"`
+}
+
+// LoadTenants reads the tenants file. A missing file yields a single default
+// tenant with the engine defaults.
+func LoadTenants(path string) (*TenantsConfig, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &TenantsConfig{
+			Default: domain.DefaultTenant,
+			Tenants: map[string]TenantConfig{domain.DefaultTenant: {}},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenants file: %w", err)
+	}
+
+	var cfg TenantsConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse tenants json: %w", err)
+	}
+
+	if cfg.Default == "" {
+		cfg.Default = domain.DefaultTenant
+	}
+	if _, ok := cfg.Tenants[cfg.Default]; !ok {
+		return nil, fmt.Errorf("default tenant %q is not configured", cfg.Default)
+	}
+	for id, t := range cfg.Tenants {
+		if t.RateLimit.RPS < 0 || t.RateLimit.Burst < 0 {
+			return nil, fmt.Errorf("tenant %q: rate limit must not be negative", id)
+		}
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// Validate reports every invalid setting, named by its config file key.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(validAddr(c.Port), "port", "%q is not a host:port address", c.Port)
+	check(validAddr(c.MetricsPort), "metrics_port", "%q is not a host:port address", c.MetricsPort)
+	check(c.HTTPPort == "" || validAddr(c.HTTPPort), "http_port", "%q is not a host:port address", c.HTTPPort)
+
+	check(slices.Contains([]string{"debug", "info", "warn", "error"}, strings.ToLower(c.Log.Level)), "log.level", "must be debug, info, warn or error, got %q", c.Log.Level)
+	check(slices.Contains([]string{"json", "text"}, strings.ToLower(c.Log.Format)), "log.format", "must be json or text, got %q", c.Log.Format)
+	check(c.Log.DebugSampleRate >= 0, "log.debug_sample_rate", "must not be negative")
+	check(slices.Contains([]string{"", "otlp", "stdout", "none"}, c.Tracing.Exporter), "tracing.exporter", "must be otlp, stdout or none, got %q", c.Tracing.Exporter)
+	check(c.Audit.MaxSegmentBytes > 0, "audit.max_segment_bytes", "must be positive")
+
+	check((c.TLS.CertFile == "") == (c.TLS.KeyFile == ""), "tls", "cert_file and key_file must be set together")
+	check(c.TLS.ClientCAFile == "" || c.TLS.CertFile != "", "tls.client_ca_file", "requires cert_file and key_file")
+	check(slices.Contains([]string{"", "optional", "require"}, c.TLS.ClientAuth), "tls.client_auth", "must be optional or require, got %q", c.TLS.ClientAuth)
+
+	check(c.Review.SLA > 0, "review.sla", "must be positive")
+	check(c.Review.ClaimTTL >= 0, "review.claim_ttl", "must not be negative")
+	check(c.Review.Retention >= 0, "review.retention", "must not be negative")
+	check(c.Review.CallbackTimeout > 0, "review.callback_timeout", "must be positive")
+
+	check(c.Labels.DecisionCache > 0, "labels.decision_cache", "must be positive")
+	check(c.Labels.DecisionRetention > 0, "labels.decision_retention", "must be positive")
+	check(c.Labels.DenyTTL >= 0, "labels.deny_ttl", "must not be negative")
+	for _, e := range c.Labels.DenyEntities {
+		check(slices.Contains([]string{domain.EntityUser, domain.EntityDevice, domain.EntityIP}, e), "labels.deny_entities", "must be user, device or ip, got %q", e)
+	}
+
+	if ev := c.Events; ev.Enabled() {
+		check(ev.OutboxDir != "", "events.outbox_dir", "is required when a sink is set")
+		check(ev.OutboxMaxBytes >= 0, "events.outbox_max_bytes", "must not be negative")
+		if ev.WebhookURL != "" {
+			u, err := url.Parse(ev.WebhookURL)
+			check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", "events.webhook_url", "%q is not an http(s) URL", ev.WebhookURL)
+			check(ev.WebhookSecret != "", "events.webhook_secret", "is required with webhook_url")
+			check(ev.WebhookTimeout > 0, "events.webhook_timeout", "must be positive")
+		}
+		if ev.NATSURL != "" {
+			u, err := url.Parse(ev.NATSURL.Reveal())
+			check(err == nil && u.Scheme == "nats" && u.Host != "", "events.nats_url", "is not a nats:// URL")
+			check(ev.NATSSubject != "" && !strings.ContainsAny(ev.NATSSubject, " \t\r\n"), "events.nats_subject", "must be a non-empty subject without whitespace")
+			check(ev.NATSTimeout > 0, "events.nats_timeout", "must be positive")
+		}
+	}
+
+	for _, d := range c.Notify.Decisions {
+		check(slices.Contains([]string{domain.DecisionAllow, domain.DecisionBlock, domain.DecisionReview}, d), "notifications.decisions", "must be allow, block or review, got %q", d)
+	}
+	if n := c.Notify; n.WebhookURL != "" {
+		u, err := url.Parse(n.WebhookURL)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", "notifications.webhook_url", "%q is not an http(s) URL", n.WebhookURL)
+		check(n.WebhookSecret != "", "notifications.webhook_secret", "is required with webhook_url")
+		check(n.Timeout > 0, "notifications.timeout", "must be positive")
+		check(n.QueueSize > 0, "notifications.queue_size", "must be positive")
+	}
+
+	check(c.ShutdownTimeout > 0, "shutdown_timeout", "must be positive")
+	check(c.ClientRateLimit.RPS >= 0 && c.ClientRateLimit.Burst >= 0, "client_rate_limit", "must not be negative")
+
+	g := c.Groq
+	check(g.BaseURL != "", "llm.base_url", "is required")
+	check(g.Model != "", "llm.model", "is required")
+	check(slices.Contains([]string{secrets.RoundRobin, secrets.Failover}, g.KeyStrategy), "llm.key_strategy", "must be %s or %s, got %q", secrets.RoundRobin, secrets.Failover, g.KeyStrategy)
+	check(g.KeyCooldown >= 0, "llm.key_cooldown", "must not be negative")
+	check(g.PromptVersion != "", "llm.prompt_version", "is required")
+	check(g.Temperature >= 0 && g.Temperature <= 2, "llm.temperature", "must be between 0 and 2, got %v", g.Temperature)
+	check(g.Timeout > 0, "llm.timeout", "must be positive")
+	check(g.MaxIdleConns >= 0, "llm.max_idle_conns", "must not be negative")
+	check(g.IdleConnTimeout >= 0, "llm.idle_conn_timeout", "must not be negative")
+	check(g.MaxConcurrent >= 0 && g.MaxQueued >= 0, "llm.max_concurrent", "concurrency and queue size must not be negative")
+	check(g.QueueTimeout >= 0, "llm.queue_timeout", "must not be negative")
+	check(g.BreakerThreshold >= 0, "llm.breaker_threshold", "must not be negative")
+	check(g.BreakerCooldown >= 0, "llm.breaker_cooldown", "must not be negative")
+
+	for _, t := range []struct{ key, value string }{
+		{"thresholds.low_value", c.Thresholds.LowValue.String()},
+		{"thresholds.heuristic_block_min", c.Thresholds.HeuristicBlockMin.String()},
+		{"thresholds.high_value", c.Thresholds.HighValue.String()},
+		{"thresholds.prescreen_allow_max", c.Thresholds.PrescreenAllowMax.String()},
+	} {
+		if t.value == "" {
+			continue
+		}
+		m, err := domain.ParseMoney(t.value, domain.DefaultBaseCurrency)
+		check(err == nil && m.MinorUnits >= 0, t.key, "%q is not a non-negative decimal amount", t.value)
+	}
+
+	for _, p := range []struct{ key, path string }{
+		{"prompts_path", c.PromptsPath},
+		{"lists_path", c.ListsPath},
+		{"merchants_path", c.MerchantsPath},
+		{"fx_rates_path", c.FXRatesPath},
+		{"tenants_path", c.TenantsPath},
+		{"geo_db_path", c.GeoDBPath},
+		{"review.path", c.Review.Path},
+		{"labels.path", c.Labels.Path},
+		{"labels.decisions_dir", c.Labels.DecisionsDir},
+		{"notifications.templates_path", c.Notify.TemplatesPath},
+	} {
+		check(p.path != "", p.key, "is required")
+	}
+
+	return errors.Join(errs...)
+}
+
+func validAddr(addr string) bool {
+	_, _, err := net.SplitHostPort(addr)
+	return err == nil
+}
+
+// Print writes the configuration as YAML. Secrets are secrets.Value and
+// print redacted.
+func (c *Config) Print(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Changed returns the config file keys of the settings that differ between
+// old and cur.
+func Changed(old, cur *Config) []string {
+	return changed(reflect.ValueOf(*old), reflect.ValueOf(*cur), "")
+}
+
+func changed(a, b reflect.Value, prefix string) []string {
+	var keys []string
+	for i := range a.NumField() {
+		key := prefix + strings.Split(a.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		fa, fb := a.Field(i), b.Field(i)
+		if fa.Kind() == reflect.Struct {
+			keys = append(keys, changed(fa, fb, key+".")...)
+			continue
+		}
+		if !reflect.DeepEqual(fa.Interface(), fb.Interface()) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,101 @@
+// Package gateway serves the gRPC services as HTTP/JSON using the bindings in
+// api/proto/risk_engine.http.yaml.
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/tokyosplif/ai-risk-engine/api/openapi"
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// MaxBodyBytes bounds request bodies; larger ones fail with 400.
+const MaxBodyBytes = 1 << 20
+
+// forwardedHeaders are passed to the handlers as gRPC metadata, so that the
+// same credentials, tenant and correlation headers work over both transports.
+var forwardedHeaders = []string{"x-api-key", "x-tenant-id", "x-request-id"}
+
+// New returns the HTTP handler for the engine, admin, review and feedback
+// services.
+// Requests run through interceptors, in order, before reaching the service
+// implementation.
+func New(ctx context.Context, engine pb.RiskEngineServiceServer, admin pb.RiskAdminServiceServer, review pb.RiskReviewServiceServer, feedback pb.RiskFeedbackServiceServer, interceptors ...grpc.UnaryServerInterceptor) (http.Handler, error) {
+	conn := newInprocConn(interceptors...)
+	conn.register(&pb.RiskEngineService_ServiceDesc, engine)
+	conn.register(&pb.RiskAdminService_ServiceDesc, admin)
+	conn.register(&pb.RiskReviewService_ServiceDesc, review)
+	conn.register(&pb.RiskFeedbackService_ServiceDesc, feedback)
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(matchHeader),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			// Field names match the proto and the OpenAPI spec; unknown
+			// request fields are rejected rather than silently dropped.
+			MarshalOptions: protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+		}),
+	)
+	if err := pb.RegisterRiskEngineServiceHandlerClient(ctx, mux, pb.NewRiskEngineServiceClient(conn)); err != nil {
+		return nil, err
+	}
+	if err := pb.RegisterRiskAdminServiceHandlerClient(ctx, mux, pb.NewRiskAdminServiceClient(conn)); err != nil {
+		return nil, err
+	}
+	if err := pb.RegisterRiskReviewServiceHandlerClient(ctx, mux, pb.NewRiskReviewServiceClient(conn)); err != nil {
+		return nil, err
+	}
+	if err := pb.RegisterRiskFeedbackServiceHandlerClient(ctx, mux, pb.NewRiskFeedbackServiceClient(conn)); err != nil {
+		return nil, err
+	}
+
+	root := http.NewServeMux()
+	root.Handle("/v1/", withRequestContext(mux))
+	root.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openapi.Spec)
+	})
+	return root, nil
+}
+
+func matchHeader(key string) (string, bool) {
+	lower := strings.ToLower(key)
+	for _, h := range forwardedHeaders {
+		if lower == h {
+			return h, true
+		}
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// withRequestContext bounds the body and carries the caller's address, client
+// certificate and trace context into the request context, where the
+// interceptors expect to find them on a gRPC call.
+func withRequestContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, MaxBodyBytes)
+
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		p := &peer.Peer{Addr: remoteAddr(r.RemoteAddr)}
+		if r.TLS != nil {
+			p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+		}
+		ctx = peer.NewContext(ctx, p)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+type remoteAddr string
+
+func (a remoteAddr) Network() string { return "tcp" }
+func (a remoteAddr) String() string  { return string(a) }
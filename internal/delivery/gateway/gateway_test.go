@@ -0,0 +1,110 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type stubEngine struct {
+	pb.UnimplementedRiskEngineServiceServer
+	tenant string
+}
+
+func (s *stubEngine) AnalyzeTransaction(ctx context.Context, req *pb.AnalyzeRequest) (*pb.AnalyzeResponse, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-tenant-id")) > 0 {
+		s.tenant = md.Get("x-tenant-id")[0]
+	}
+	if req.TransactionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "transaction_id is required")
+	}
+	return &pb.AnalyzeResponse{IsBlocked: true, Reason: "blocked " + req.TransactionId}, nil
+}
+
+func newTestGateway(t *testing.T, engine pb.RiskEngineServiceServer, interceptors ...grpc.UnaryServerInterceptor) http.Handler {
+	t.Helper()
+
+	h, err := New(context.Background(), engine, pb.UnimplementedRiskAdminServiceServer{}, pb.UnimplementedRiskReviewServiceServer{}, pb.UnimplementedRiskFeedbackServiceServer{}, interceptors...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return h
+}
+
+func post(h http.Handler, path, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGateway_AnalyzeThroughInterceptors(t *testing.T) {
+	engine := &stubEngine{}
+
+	var methods []string
+	record := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		methods = append(methods, info.FullMethod)
+		return handler(ctx, req)
+	}
+	h := newTestGateway(t, engine, record)
+
+	rec := post(h, "/v1/analyze", `{"transaction_id":"tx-1"}`, http.Header{"X-Tenant-Id": {"acme-bank"}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+
+	var resp map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp["is_blocked"] != true || resp["reason"] != "blocked tx-1" {
+		t.Errorf("Unexpected response %v", resp)
+	}
+
+	if len(methods) != 1 || methods[0] != pb.RiskEngineService_AnalyzeTransaction_FullMethodName {
+		t.Errorf("Expected the interceptor to see AnalyzeTransaction, got %v", methods)
+	}
+	if engine.tenant != "acme-bank" {
+		t.Errorf("Expected x-tenant-id to be forwarded, got %q", engine.tenant)
+	}
+}
+
+func TestGateway_ErrorMapping(t *testing.T) {
+	deny := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, _ := metadata.FromIncomingContext(ctx); len(md.Get("x-api-key")) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing credentials")
+		}
+		return handler(ctx, req)
+	}
+	h := newTestGateway(t, &stubEngine{}, deny)
+	key := http.Header{"X-Api-Key": {"k"}}
+
+	cases := []struct {
+		name   string
+		body   string
+		header http.Header
+		want   int
+	}{
+		{"unauthenticated", `{"transaction_id":"tx-1"}`, nil, http.StatusUnauthorized},
+		{"invalid argument", `{}`, key, http.StatusBadRequest},
+		{"unknown field", `{"transaction_id":"tx-1","amout":5}`, key, http.StatusBadRequest},
+		{"oversized body", `{"transaction_id":"` + strings.Repeat("x", MaxBodyBytes) + `"}`, key, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		if rec := post(h, "/v1/analyze", tc.body, tc.header); rec.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d: %s", tc.name, tc.want, rec.Code, rec.Body)
+		}
+	}
+}
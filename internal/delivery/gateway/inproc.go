@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// inprocConn is a grpc.ClientConnInterface that calls registered service
+// implementations directly, through the same unary interceptor chain as the
+// gRPC server, so that gateway requests are logged, authenticated and rate
+// limited exactly like gRPC ones.
+type inprocConn struct {
+	interceptor grpc.UnaryServerInterceptor
+	methods     map[string]inprocMethod
+}
+
+type inprocMethod struct {
+	impl    any
+	handler grpc.MethodHandler
+}
+
+func newInprocConn(interceptors ...grpc.UnaryServerInterceptor) *inprocConn {
+	return &inprocConn{
+		interceptor: chainUnary(interceptors),
+		methods:     make(map[string]inprocMethod),
+	}
+}
+
+func (c *inprocConn) register(desc *grpc.ServiceDesc, impl any) {
+	for _, m := range desc.Methods {
+		c.methods["/"+desc.ServiceName+"/"+m.MethodName] = inprocMethod{impl: impl, handler: m.Handler}
+	}
+}
+
+func (c *inprocConn) Invoke(ctx context.Context, method string, args, reply any, _ ...grpc.CallOption) error {
+	m, ok := c.methods[method]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "method %s is not served by the gateway", method)
+	}
+
+	// The gateway forwards HTTP headers as outgoing metadata; the handlers
+	// read them as incoming metadata.
+	md, _ := metadata.FromOutgoingContext(ctx)
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	dec := func(v any) error {
+		proto.Merge(v.(proto.Message), args.(proto.Message))
+		return nil
+	}
+	resp, err := m.handler(m.impl, ctx, dec, c.interceptor)
+	if err != nil {
+		return err
+	}
+
+	proto.Merge(reply.(proto.Message), resp.(proto.Message))
+	return nil
+}
+
+func (c *inprocConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "streaming is not served by the gateway")
+}
+
+// chainUnary composes interceptors so that the first one is outermost, like
+// grpc.ChainUnaryInterceptor.
+func chainUnary(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
@@ -0,0 +1,224 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/auth"
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ListManager interface {
+	Add(e domain.ListEntry) (domain.ListEntry, error)
+	Remove(tenant, list, entity, value string) error
+	List(list, entity string) []domain.ListEntry
+}
+
+type MerchantManager interface {
+	Upsert(m domain.Merchant) (domain.Merchant, error)
+	Remove(id string) error
+	List(category string) []domain.Merchant
+}
+
+type AdminHandler struct {
+	pb.UnimplementedRiskAdminServiceServer
+	lists     ListManager
+	merchants MerchantManager
+}
+
+func NewAdminHandler(lists ListManager, merchants MerchantManager) *AdminHandler {
+	return &AdminHandler{lists: lists, merchants: merchants}
+}
+
+func (h *AdminHandler) AddListEntry(ctx context.Context, req *pb.AddListEntryRequest) (*pb.ListEntry, error) {
+	if req.Entry == nil {
+		return nil, status.Error(codes.InvalidArgument, "entry is required")
+	}
+	if err := checkListTenant(ctx, req.Entry.TenantId); err != nil {
+		return nil, err
+	}
+
+	entry, err := h.lists.Add(fromPBListEntry(req.Entry))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return toPBListEntry(entry), nil
+}
+
+func (h *AdminHandler) RemoveListEntry(ctx context.Context, req *pb.RemoveListEntryRequest) (*pb.RemoveListEntryResponse, error) {
+	if err := checkListTenant(ctx, req.TenantId); err != nil {
+		return nil, err
+	}
+	if err := h.lists.Remove(req.TenantId, fromPBListKind(req.List), fromPBEntityType(req.Entity), req.Value); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &pb.RemoveListEntryResponse{}, nil
+}
+
+func (h *AdminHandler) ListListEntries(ctx context.Context, req *pb.ListListEntriesRequest) (*pb.ListListEntriesResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	resp := &pb.ListListEntriesResponse{}
+	for _, e := range h.lists.List(fromPBListKind(req.List), fromPBEntityType(req.Entity)) {
+		if id.Tenant != "" && e.TenantID != "" && e.TenantID != id.Tenant {
+			continue
+		}
+		resp.Entries = append(resp.Entries, toPBListEntry(e))
+	}
+	return resp, nil
+}
+
+// checkListTenant refuses a tenant-bound caller an entry of another tenant,
+// and a global entry, which would apply to every tenant.
+func checkListTenant(ctx context.Context, tenant string) error {
+	id, _ := auth.FromContext(ctx)
+	if id.Tenant != "" && strings.TrimSpace(tenant) != id.Tenant {
+		return status.Errorf(codes.PermissionDenied, "%s may only manage list entries of tenant %q", id.Subject, id.Tenant)
+	}
+	return nil
+}
+
+func (h *AdminHandler) UpsertMerchant(ctx context.Context, req *pb.UpsertMerchantRequest) (*pb.Merchant, error) {
+	if req.Merchant == nil {
+		return nil, status.Error(codes.InvalidArgument, "merchant is required")
+	}
+
+	m, err := h.merchants.Upsert(fromPBMerchant(req.Merchant))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return toPBMerchant(m), nil
+}
+
+func (h *AdminHandler) RemoveMerchant(ctx context.Context, req *pb.RemoveMerchantRequest) (*pb.RemoveMerchantResponse, error) {
+	if err := h.merchants.Remove(req.Id); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &pb.RemoveMerchantResponse{}, nil
+}
+
+func (h *AdminHandler) ListMerchants(ctx context.Context, req *pb.ListMerchantsRequest) (*pb.ListMerchantsResponse, error) {
+	resp := &pb.ListMerchantsResponse{}
+	for _, m := range h.merchants.List(req.Category) {
+		resp.Merchants = append(resp.Merchants, toPBMerchant(m))
+	}
+	return resp, nil
+}
+
+func fromPBListEntry(e *pb.ListEntry) domain.ListEntry {
+	entry := domain.ListEntry{
+		TenantID: e.TenantId,
+		List:     fromPBListKind(e.List),
+		Entity:   fromPBEntityType(e.Entity),
+		Value:    e.Value,
+		Reason:   e.Reason,
+		Author:   e.Author,
+	}
+	if e.ExpiresAt != nil {
+		entry.ExpiresAt = e.ExpiresAt.AsTime()
+	}
+	return entry
+}
+
+func toPBListEntry(e domain.ListEntry) *pb.ListEntry {
+	return &pb.ListEntry{
+		List:      toPBListKind(e.List),
+		Entity:    toPBEntityType(e.Entity),
+		Value:     e.Value,
+		Reason:    e.Reason,
+		Author:    e.Author,
+		CreatedAt: toPBTime(e.CreatedAt),
+		ExpiresAt: toPBTime(e.ExpiresAt),
+		TenantId:  e.TenantID,
+	}
+}
+
+func fromPBMerchant(m *pb.Merchant) domain.Merchant {
+	return domain.Merchant{
+		ID:       m.Id,
+		Name:     m.Name,
+		MCC:      m.Mcc,
+		Category: m.Category,
+		RiskTier: riskTiers[m.RiskTier],
+		Aliases:  m.Aliases,
+	}
+}
+
+func toPBMerchant(m domain.Merchant) *pb.Merchant {
+	return &pb.Merchant{
+		Id:       m.ID,
+		Name:     m.Name,
+		Mcc:      m.MCC,
+		Category: m.Category,
+		RiskTier: toPBRiskTier(m.RiskTier),
+		Aliases:  m.Aliases,
+	}
+}
+
+func toPBTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+var listKinds = map[pb.ListKind]string{
+	pb.ListKind_LIST_KIND_ALLOW: domain.ListAllow,
+	pb.ListKind_LIST_KIND_DENY:  domain.ListDeny,
+}
+
+var entityTypes = map[pb.EntityType]string{
+	pb.EntityType_ENTITY_TYPE_MERCHANT: domain.EntityMerchant,
+	pb.EntityType_ENTITY_TYPE_USER:     domain.EntityUser,
+	pb.EntityType_ENTITY_TYPE_DEVICE:   domain.EntityDevice,
+	pb.EntityType_ENTITY_TYPE_IP:       domain.EntityIP,
+	pb.EntityType_ENTITY_TYPE_BIN:      domain.EntityBIN,
+	pb.EntityType_ENTITY_TYPE_COUNTRY:  domain.EntityCountry,
+}
+
+var riskTiers = map[pb.MerchantRiskTier]string{
+	pb.MerchantRiskTier_MERCHANT_RISK_TIER_LOW:    domain.RiskTierLow,
+	pb.MerchantRiskTier_MERCHANT_RISK_TIER_MEDIUM: domain.RiskTierMedium,
+	pb.MerchantRiskTier_MERCHANT_RISK_TIER_HIGH:   domain.RiskTierHigh,
+}
+
+func fromPBListKind(k pb.ListKind) string {
+	return listKinds[k]
+}
+
+func fromPBEntityType(t pb.EntityType) string {
+	return entityTypes[t]
+}
+
+func toPBListKind(list string) pb.ListKind {
+	for k, v := range listKinds {
+		if v == list {
+			return k
+		}
+	}
+	return pb.ListKind_LIST_KIND_UNSPECIFIED
+}
+
+func toPBEntityType(entity string) pb.EntityType {
+	for k, v := range entityTypes {
+		if v == entity {
+			return k
+		}
+	}
+	return pb.EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+func toPBRiskTier(tier string) pb.MerchantRiskTier {
+	for k, v := range riskTiers {
+		if v == tier {
+			return k
+		}
+	}
+	return pb.MerchantRiskTier_MERCHANT_RISK_TIER_UNSPECIFIED
+}
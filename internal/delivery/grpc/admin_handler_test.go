@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/auth"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/lists"
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestAdminHandler(t *testing.T) *AdminHandler {
+	t.Helper()
+
+	store, err := lists.NewStore(filepath.Join(t.TempDir(), "lists.json"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	h := NewAdminHandler(store, nil)
+	for _, e := range []*pb.ListEntry{
+		{TenantId: "acme", Value: "dev-acme"},
+		{TenantId: "other", Value: "dev-other"},
+		{Value: "dev-global"},
+	} {
+		e.List, e.Entity, e.Reason = pb.ListKind_LIST_KIND_DENY, pb.EntityType_ENTITY_TYPE_DEVICE, "fraud"
+		if _, err := h.AddListEntry(context.Background(), &pb.AddListEntryRequest{Entry: e}); err != nil {
+			t.Fatalf("AddListEntry: %v", err)
+		}
+	}
+	return h
+}
+
+func TestAdminHandler_TenantBoundCallerManagesOwnEntries(t *testing.T) {
+	h := newTestAdminHandler(t)
+	acme := auth.WithIdentity(context.Background(), auth.Identity{Subject: "jane", Tenant: "acme"})
+	deny := func(tenant, value string) *pb.ListEntry {
+		return &pb.ListEntry{TenantId: tenant, List: pb.ListKind_LIST_KIND_DENY, Entity: pb.EntityType_ENTITY_TYPE_DEVICE, Value: value, Reason: "fraud"}
+	}
+
+	if _, err := h.AddListEntry(acme, &pb.AddListEntryRequest{Entry: deny("acme", "dev-2")}); err != nil {
+		t.Errorf("Expected an entry of the caller's tenant to be added, got %v", err)
+	}
+	for _, tt := range []struct{ name, tenant, value string }{
+		{"another tenant's", "other", "dev-other"},
+		{"global", "", "dev-global"},
+	} {
+		if _, err := h.AddListEntry(acme, &pb.AddListEntryRequest{Entry: deny(tt.tenant, "dev-3")}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Expected adding a %s entry to be denied, got %v", tt.name, err)
+		}
+		if _, err := h.RemoveListEntry(acme, &pb.RemoveListEntryRequest{TenantId: tt.tenant, List: pb.ListKind_LIST_KIND_DENY, Entity: pb.EntityType_ENTITY_TYPE_DEVICE, Value: tt.value}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Expected removing a %s entry to be denied, got %v", tt.name, err)
+		}
+	}
+	if _, err := h.RemoveListEntry(acme, &pb.RemoveListEntryRequest{TenantId: "acme", List: pb.ListKind_LIST_KIND_DENY, Entity: pb.EntityType_ENTITY_TYPE_DEVICE, Value: "dev-acme"}); err != nil {
+		t.Errorf("Expected an entry of the caller's tenant to be removed, got %v", err)
+	}
+
+	resp, err := h.ListListEntries(acme, &pb.ListListEntriesRequest{})
+	if err != nil {
+		t.Fatalf("ListListEntries: %v", err)
+	}
+	got := map[string]bool{}
+	for _, e := range resp.Entries {
+		got[e.Value] = true
+	}
+	if len(got) != 2 || !got["dev-2"] || !got["dev-global"] {
+		t.Errorf("Expected the caller's and the global entries only, got %v", resp.Entries)
+	}
+}
+
+func TestAdminHandler_EngineWideCallerManagesEveryEntry(t *testing.T) {
+	h := newTestAdminHandler(t)
+	admin := auth.WithIdentity(context.Background(), auth.Identity{Subject: "ops"})
+
+	resp, err := h.ListListEntries(admin, &pb.ListListEntriesRequest{})
+	if err != nil || len(resp.Entries) != 3 {
+		t.Fatalf("Expected every entry, got %v, %v", resp, err)
+	}
+	if _, err := h.RemoveListEntry(admin, &pb.RemoveListEntryRequest{TenantId: "other", List: pb.ListKind_LIST_KIND_DENY, Entity: pb.EntityType_ENTITY_TYPE_DEVICE, Value: "dev-other"}); err != nil {
+		t.Errorf("Expected an engine-wide caller to remove any entry, got %v", err)
+	}
+}
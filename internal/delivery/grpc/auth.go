@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/auth"
+	"github.com/tokyosplif/ai-risk-engine/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	apiKeyHeader        = "x-api-key"
+)
+
+// Auth authenticates every call and checks it against the method policy.
+// The caller's identity is stored in the context for handlers; a caller
+// bound to a tenant may not act for another one.
+type Auth struct {
+	authenticator *auth.Authenticator
+	policy        auth.Policy
+}
+
+func NewAuth(a *auth.Authenticator, p auth.Policy) *Auth {
+	return &Auth{authenticator: a, policy: p}
+}
+
+func (a *Auth) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if a.policy.Public(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	id, _ := auth.FromContext(ctx)
+	if requested := requestedTenant(ctx, req); id.Tenant != "" && requested != "" && requested != id.Tenant {
+		slog.WarnContext(ctx, "caller requested a foreign tenant", "tenant", requested)
+		return nil, status.Errorf(codes.PermissionDenied, "%s may not act for tenant %q", id.Subject, requested)
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *Auth) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if a.policy.Public(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &identifiedStream{ServerStream: ss, ctx: ctx})
+}
+
+func (a *Auth) authorize(ctx context.Context, method string) (context.Context, error) {
+	id, err := a.authenticator.Authenticate(callerCredentials(ctx))
+	if err != nil {
+		slog.WarnContext(ctx, "authentication failed", "method", method, "err", err)
+		return nil, status.Error(codes.Unauthenticated, "invalid or missing credentials")
+	}
+
+	ctx = logger.WithAttrs(auth.WithIdentity(ctx, id), slog.String("principal", id.Subject))
+	if err := a.policy.Authorize(id, method); err != nil {
+		slog.WarnContext(ctx, "authorization failed", "err", err)
+		if errors.Is(err, auth.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return ctx, nil
+}
+
+// callerCredentials reads a bearer token or API key from the metadata and the
+// common name of a verified client certificate from the TLS peer.
+func callerCredentials(ctx context.Context) auth.Credentials {
+	var creds auth.Credentials
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(authorizationHeader); len(v) > 0 {
+			if token, ok := strings.CutPrefix(v[0], "Bearer "); ok {
+				creds.Token = strings.TrimSpace(token)
+			}
+		}
+		if v := md.Get(apiKeyHeader); creds.Token == "" && len(v) > 0 {
+			creds.Token = v[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+			creds.CertSubject = info.State.VerifiedChains[0][0].Subject.CommonName
+		}
+	}
+	return creds
+}
+
+type identifiedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identifiedStream) Context() context.Context {
+	return s.ctx
+}
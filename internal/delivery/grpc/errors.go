@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes maps the error classes of the layers below to the gRPC code
+// callers see. The first match wins.
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{domain.ErrUnknownTenant, codes.InvalidArgument},
+	{domain.ErrUnsupportedCurrency, codes.InvalidArgument},
+	{domain.ErrInvalidAmount, codes.InvalidArgument},
+	{domain.ErrCurrencyMismatch, codes.InvalidArgument},
+	{domain.ErrInvalidListEntry, codes.InvalidArgument},
+	{domain.ErrInvalidMerchant, codes.InvalidArgument},
+	{domain.ErrListEntryNotFound, codes.NotFound},
+	{domain.ErrMerchantNotFound, codes.NotFound},
+	{domain.ErrInvalidReviewOutcome, codes.InvalidArgument},
+	{domain.ErrReviewCaseNotFound, codes.NotFound},
+	{domain.ErrNoReviewCase, codes.NotFound},
+	{domain.ErrReviewCaseClaimed, codes.FailedPrecondition},
+	{domain.ErrReviewCaseResolved, codes.FailedPrecondition},
+	{domain.ErrReviewCaseNotClaimed, codes.FailedPrecondition},
+	{domain.ErrInvalidLabel, codes.InvalidArgument},
+	{domain.ErrAuditUnavailable, codes.Unavailable},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+	{context.Canceled, codes.Canceled},
+}
+
+// statusError converts err to a gRPC status. Errors that already carry a
+// status pass through. Unclassified errors are logged and returned as
+// Internal with a generic message, so implementation details do not reach
+// callers.
+func statusError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return status.Error(c.code, err.Error())
+		}
+	}
+
+	slog.ErrorContext(ctx, "unclassified error", "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
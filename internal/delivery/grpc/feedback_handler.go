@@ -0,0 +1,277 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxImportRows   = 10_000
+	maxImportErrors = 100
+)
+
+// OutcomeReporter records outcome labels.
+type OutcomeReporter interface {
+	Report(ctx context.Context, l domain.Label) (domain.OutcomeResult, error)
+}
+
+type FeedbackHandler struct {
+	pb.UnimplementedRiskFeedbackServiceServer
+	outcomes OutcomeReporter
+}
+
+func NewFeedbackHandler(outcomes OutcomeReporter) *FeedbackHandler {
+	return &FeedbackHandler{outcomes: outcomes}
+}
+
+var (
+	reportOutcomeRules = []fieldRule[*pb.ReportOutcomeRequest]{
+		stringField("transaction_id", (*pb.ReportOutcomeRequest).GetTransactionId, required, maxLen(maxIDLen), printable),
+		stringField("tenant_id", (*pb.ReportOutcomeRequest).GetTenantId, maxLen(maxIDLen), printable),
+		{"label", func(r *pb.ReportOutcomeRequest) string {
+			if _, ok := outcomeLabels[r.Label]; !ok {
+				return "must be fraud or legitimate"
+			}
+			return ""
+		}},
+		{"source", func(r *pb.ReportOutcomeRequest) string {
+			if _, ok := outcomeSources[r.Source]; !ok {
+				return "must be chargeback, dispute, review or other"
+			}
+			return ""
+		}},
+		{"timestamp", func(r *pb.ReportOutcomeRequest) string {
+			if r.Timestamp != nil && r.Timestamp.CheckValid() != nil {
+				return "must be a valid timestamp"
+			}
+			return ""
+		}},
+	}
+	importOutcomesRules = []fieldRule[*pb.ImportOutcomesRequest]{
+		stringField("tenant_id", (*pb.ImportOutcomesRequest).GetTenantId, maxLen(maxIDLen), printable),
+		{"format", func(r *pb.ImportOutcomesRequest) string {
+			if r.Format == pb.OutcomeFileFormat_OUTCOME_FILE_FORMAT_UNSPECIFIED {
+				return "must be csv or jsonl"
+			}
+			return ""
+		}},
+		{"data", func(r *pb.ImportOutcomesRequest) string {
+			if len(r.Data) == 0 {
+				return "is required"
+			}
+			return ""
+		}},
+	}
+)
+
+func (h *FeedbackHandler) ReportOutcome(ctx context.Context, req *pb.ReportOutcomeRequest) (*pb.ReportOutcomeResponse, error) {
+	if err := validate(req, reportOutcomeRules); err != nil {
+		return nil, err
+	}
+
+	l := domain.Label{
+		TransactionID: req.TransactionId,
+		TenantID:      tenantID(ctx, req),
+		Label:         outcomeLabels[req.Label],
+		Source:        outcomeSources[req.Source],
+	}
+	if req.Timestamp != nil {
+		l.OccurredAt = req.Timestamp.AsTime()
+	}
+
+	res, err := h.outcomes.Report(ctx, l)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	resp := &pb.ReportOutcomeResponse{Duplicate: res.Duplicate}
+	if d := res.Label.Decision; d != nil {
+		resp.MatchedDecision = true
+		resp.Decision = d.Decision
+	}
+	for _, e := range res.Denylisted {
+		resp.Denylisted = append(resp.Denylisted, toPBListEntry(e))
+	}
+	return resp, nil
+}
+
+// ImportOutcomes reports every row of the file. A bad row is listed in the
+// response and does not stop the import.
+func (h *FeedbackHandler) ImportOutcomes(ctx context.Context, req *pb.ImportOutcomesRequest) (*pb.ImportOutcomesResponse, error) {
+	if err := validate(req, importOutcomesRules); err != nil {
+		return nil, err
+	}
+
+	var rows []outcomeRow
+	var err error
+	switch req.Format {
+	case pb.OutcomeFileFormat_OUTCOME_FILE_FORMAT_CSV:
+		rows, err = parseOutcomeCSV(req.Data)
+	case pb.OutcomeFileFormat_OUTCOME_FILE_FORMAT_JSONL:
+		rows, err = parseOutcomeJSONL(req.Data)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown format %v", req.Format)
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(rows) > maxImportRows {
+		return nil, status.Errorf(codes.InvalidArgument, "file has %d rows, at most %d are allowed", len(rows), maxImportRows)
+	}
+
+	tenant := tenantID(ctx, req)
+	resp := &pb.ImportOutcomesResponse{}
+	fail := func(line int, msg string) {
+		if len(resp.Errors) < maxImportErrors {
+			resp.Errors = append(resp.Errors, &pb.ImportError{Line: int32(line), Message: msg})
+		}
+	}
+
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return nil, statusError(ctx, err)
+		}
+		if row.err != "" {
+			fail(row.line, row.err)
+			continue
+		}
+
+		row.label.TenantID = tenant
+		res, err := h.outcomes.Report(ctx, row.label)
+		switch {
+		case errors.Is(err, domain.ErrUnknownTenant):
+			return nil, statusError(ctx, err)
+		case errors.Is(err, domain.ErrInvalidLabel):
+			fail(row.line, err.Error())
+		case err != nil:
+			return nil, statusError(ctx, err)
+		case res.Duplicate:
+			resp.Duplicates++
+		default:
+			resp.Imported++
+			if res.Label.Decision != nil {
+				resp.Matched++
+			}
+		}
+	}
+	return resp, nil
+}
+
+// outcomeRow is a parsed row of an import file, or why it could not be parsed.
+type outcomeRow struct {
+	line  int
+	label domain.Label
+	err   string
+}
+
+func newOutcomeRow(line int, txID, label, source, timestamp string) outcomeRow {
+	row := outcomeRow{line: line, label: domain.Label{
+		TransactionID: strings.TrimSpace(txID),
+		Label:         strings.ToLower(strings.TrimSpace(label)),
+		Source:        strings.ToLower(strings.TrimSpace(source)),
+	}}
+	if ts := strings.TrimSpace(timestamp); ts != "" {
+		t, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			row.err = fmt.Sprintf("timestamp %q is not RFC 3339", ts)
+			return row
+		}
+		row.label.OccurredAt = t
+	}
+	if len(row.label.TransactionID) > maxIDLen {
+		row.err = fmt.Sprintf("transaction_id is longer than %d bytes", maxIDLen)
+	}
+	return row
+}
+
+func parseOutcomeCSV(data []byte) ([]outcomeRow, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("csv header: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"transaction_id", "label", "source"} {
+		if _, ok := cols[name]; !ok {
+			return nil, fmt.Errorf("csv header has no %s column", name)
+		}
+	}
+	field := func(rec []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(rec) {
+			return rec[i]
+		}
+		return ""
+	}
+
+	var rows []outcomeRow
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		line, _ := r.FieldPos(0)
+		if err != nil {
+			var perr *csv.ParseError
+			if !errors.As(err, &perr) {
+				return nil, err
+			}
+			rows = append(rows, outcomeRow{line: perr.Line, err: perr.Err.Error()})
+			continue
+		}
+		rows = append(rows, newOutcomeRow(line, field(rec, "transaction_id"), field(rec, "label"), field(rec, "source"), field(rec, "timestamp")))
+	}
+}
+
+func parseOutcomeJSONL(data []byte) ([]outcomeRow, error) {
+	var rows []outcomeRow
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var v struct {
+			TransactionID string `json:"transaction_id"`
+			Label         string `json:"label"`
+			Source        string `json:"source"`
+			Timestamp     string `json:"timestamp"`
+		}
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&v); err != nil {
+			rows = append(rows, outcomeRow{line: i + 1, err: err.Error()})
+			continue
+		}
+		rows = append(rows, newOutcomeRow(i+1, v.TransactionID, v.Label, v.Source, v.Timestamp))
+	}
+	return rows, nil
+}
+
+var outcomeLabels = map[pb.OutcomeLabel]string{
+	pb.OutcomeLabel_OUTCOME_LABEL_FRAUD:      domain.LabelFraud,
+	pb.OutcomeLabel_OUTCOME_LABEL_LEGITIMATE: domain.LabelLegitimate,
+}
+
+var outcomeSources = map[pb.OutcomeSource]string{
+	pb.OutcomeSource_OUTCOME_SOURCE_CHARGEBACK: domain.SourceChargeback,
+	pb.OutcomeSource_OUTCOME_SOURCE_DISPUTE:    domain.SourceDispute,
+	pb.OutcomeSource_OUTCOME_SOURCE_REVIEW:     domain.SourceReview,
+	pb.OutcomeSource_OUTCOME_SOURCE_OTHER:      domain.SourceOther,
+}
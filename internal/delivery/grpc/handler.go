@@ -2,32 +2,130 @@ package grpc
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/tokyosplif/ai-risk-engine/internal/usecase"
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/tracing"
 	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
 )
 
+var tracer = otel.Tracer("github.com/tokyosplif/ai-risk-engine/internal/delivery/grpc")
+
+// TransactionProcessor is implemented by usecase.Analyzer and usecase.Router.
+type TransactionProcessor interface {
+	ProcessTransaction(ctx context.Context, tx domain.Transaction) (domain.RiskAssessment, error)
+}
+
 type RiskHandler struct {
 	pb.UnimplementedRiskEngineServiceServer
-	usecase *usecase.Analyzer
+	usecase TransactionProcessor
 }
 
-func NewRiskHandler(u *usecase.Analyzer) *RiskHandler {
+func NewRiskHandler(u TransactionProcessor) *RiskHandler {
 	return &RiskHandler{usecase: u}
 }
 
 func (h *RiskHandler) AnalyzeTransaction(ctx context.Context, req *pb.AnalyzeRequest) (*pb.AnalyzeResponse, error) {
-	txData := fmt.Sprintf("Amount: %f, Merchant: %s, Location: %s", req.Amount, req.Merchant, req.Location)
+	ctx, span := tracer.Start(ctx, "RiskHandler.AnalyzeTransaction")
+	defer span.End()
 
-	result, err := h.usecase.ProcessAnalysis(ctx, txData, req.UserProfileContext)
-	if err != nil {
+	tenant := tenantID(ctx, req)
+	span.SetAttributes(
+		tracing.AttrTransactionID.String(req.TransactionId),
+		tracing.AttrTenant.String(tenant),
+	)
+
+	if err := validate(req, analyzeRules); err != nil {
 		return nil, err
 	}
 
+	amount, err := requestAmount(req)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	result, err := h.usecase.ProcessTransaction(ctx, domain.Transaction{
+		ID:          req.TransactionId,
+		TenantID:    tenant,
+		UserID:      req.UserId,
+		Amount:      amount,
+		Merchant:    req.Merchant,
+		Location:    req.Location,
+		UserProfile: req.UserProfileContext,
+		DeviceID:    req.DeviceId,
+		IPAddress:   req.IpAddress,
+		CardBIN:     req.CardBin,
+		CountryCode: req.CountryCode,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+		return nil, statusError(ctx, err)
+	}
+
+	span.SetAttributes(tracing.AttrDecision.String(result.Decision()))
+
 	return &pb.AnalyzeResponse{
-		IsBlocked: result.IsBlocked,
-		Reason:    result.Reason,
-		AiPushMsg: result.AIPushMessage,
+		IsBlocked:    result.IsBlocked,
+		Reason:       result.Reason,
+		AiPushMsg:    result.AIPushMessage,
+		AiPushLocale: result.AIPushLocale,
+		Explanation:  toPBExplanation(result),
+		ReviewCaseId: result.ReviewCaseID,
+		Degraded:     result.Route == domain.RouteDegraded,
 	}, nil
 }
+
+// requestAmount prefers the exact money field and falls back to the
+// deprecated floating point amount for older clients.
+func requestAmount(req *pb.AnalyzeRequest) (domain.Money, error) {
+	if m := req.GetMoney(); m != nil {
+		return domain.Money{MinorUnits: m.MinorUnits, Currency: domain.NormalizeCurrency(m.Currency)}, nil
+	}
+	return domain.MoneyFromFloat(req.Amount, req.Currency)
+}
+
+func toPBExplanation(r domain.RiskAssessment) *pb.Explanation {
+	exp := &pb.Explanation{
+		Decision:        r.Decision(),
+		ConfidenceScore: int32(r.ConfidenceScore),
+		LlmRationale:    r.Explanation.LLMRationale,
+		Route:           r.Route,
+		RouteReason:     r.RouteReason,
+	}
+
+	for _, f := range r.Explanation.Factors {
+		exp.Factors = append(exp.Factors, &pb.RiskFactor{
+			Name:      f.Name,
+			Direction: toPBDirection(f.Direction),
+			Weight:    f.Weight,
+			Detail:    f.Detail,
+		})
+	}
+
+	for _, o := range r.Overrides {
+		exp.Overrides = append(exp.Overrides, &pb.AppliedOverride{
+			Stage:          o.Stage,
+			Rule:           o.Rule,
+			BlockedBefore:  o.BlockedBefore,
+			BlockedAfter:   o.BlockedAfter,
+			ChangedOutcome: o.ChangedOutcome(),
+		})
+	}
+
+	return exp
+}
+
+func toPBDirection(direction string) pb.FactorDirection {
+	switch direction {
+	case domain.DirectionIncreasesRisk:
+		return pb.FactorDirection_FACTOR_DIRECTION_INCREASES_RISK
+	case domain.DirectionDecreasesRisk:
+		return pb.FactorDirection_FACTOR_DIRECTION_DECREASES_RISK
+	case domain.DirectionNeutral:
+		return pb.FactorDirection_FACTOR_DIRECTION_NEUTRAL
+	default:
+		return pb.FactorDirection_FACTOR_DIRECTION_UNSPECIFIED
+	}
+}
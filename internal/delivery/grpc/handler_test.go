@@ -0,0 +1,252 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/usecase"
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubProcessor struct {
+	err   error
+	calls int
+}
+
+func (s *stubProcessor) ProcessTransaction(ctx context.Context, tx domain.Transaction) (domain.RiskAssessment, error) {
+	s.calls++
+	return domain.RiskAssessment{Reason: "ok"}, s.err
+}
+
+func validRequest() *pb.AnalyzeRequest {
+	return &pb.AnalyzeRequest{
+		TransactionId: "tx-1",
+		UserId:        "user-1",
+		Merchant:      "Amazon",
+		Money:         &pb.Money{MinorUnits: 4999, Currency: "USD"},
+		IpAddress:     "203.0.113.7",
+		CardBin:       "411111",
+		CountryCode:   "US",
+	}
+}
+
+func fieldViolations(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v: %v", st.Code(), err)
+	}
+	out := map[string]string{}
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				out[v.Field] = v.Description
+			}
+		}
+	}
+	return out
+}
+
+func TestAnalyzeTransaction_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*pb.AnalyzeRequest)
+		fields []string
+	}{
+		{"missing ids", func(r *pb.AnalyzeRequest) { r.TransactionId, r.UserId = "", "" }, []string{"transaction_id", "user_id"}},
+		{"empty merchant", func(r *pb.AnalyzeRequest) { r.Merchant = "" }, []string{"merchant"}},
+		{"negative money", func(r *pb.AnalyzeRequest) { r.Money.MinorUnits = -1 }, []string{"money.minor_units"}},
+		{"bad money currency", func(r *pb.AnalyzeRequest) { r.Money.Currency = "DOLLARS" }, []string{"money.currency"}},
+		{"nan amount", func(r *pb.AnalyzeRequest) { r.Money, r.Amount = nil, math.NaN() }, []string{"amount"}},
+		{"negative amount", func(r *pb.AnalyzeRequest) { r.Money, r.Amount = nil, -5 }, []string{"amount"}},
+		{"oversized profile", func(r *pb.AnalyzeRequest) { r.UserProfileContext = strings.Repeat("x", maxProfileLen+1) }, []string{"user_profile_context"}},
+		{"control characters", func(r *pb.AnalyzeRequest) { r.TransactionId = "tx\n1" }, []string{"transaction_id"}},
+		{"bad optional fields", func(r *pb.AnalyzeRequest) { r.IpAddress, r.CardBin, r.CountryCode = "localhost", "41x", "USA" }, []string{"ip_address", "card_bin", "country_code"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			proc := &stubProcessor{}
+			req := validRequest()
+			tc.mutate(req)
+
+			_, err := NewRiskHandler(proc).AnalyzeTransaction(context.Background(), req)
+
+			got := fieldViolations(t, err)
+			if len(got) != len(tc.fields) {
+				t.Errorf("Expected violations for %v, got %v", tc.fields, got)
+			}
+			for _, f := range tc.fields {
+				if got[f] == "" {
+					t.Errorf("Expected a violation for %s, got %v", f, got)
+				}
+			}
+			if proc.calls != 0 {
+				t.Error("Expected an invalid request not to reach the use case")
+			}
+		})
+	}
+}
+
+func TestAnalyzeTransaction_ValidRequest(t *testing.T) {
+	legacy := validRequest()
+	legacy.Money, legacy.Amount, legacy.Currency = nil, 49.99, "eur"
+
+	for _, req := range []*pb.AnalyzeRequest{validRequest(), legacy} {
+		resp, err := NewRiskHandler(&stubProcessor{}).AnalyzeTransaction(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Expected a valid request to pass, got %v", err)
+		}
+		if resp.Reason != "ok" {
+			t.Errorf("Unexpected response %v", resp)
+		}
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{fmt.Errorf("%w: %q", domain.ErrUnknownTenant, "nope"), codes.InvalidArgument},
+		{fmt.Errorf("%w: XYZ", domain.ErrUnsupportedCurrency), codes.InvalidArgument},
+		{domain.ErrInvalidAmount, codes.InvalidArgument},
+		{domain.ErrMerchantNotFound, codes.NotFound},
+		{fmt.Errorf("%w: USD vs EUR", domain.ErrCurrencyMismatch), codes.InvalidArgument},
+		{domain.ErrAuditUnavailable, codes.Unavailable},
+		{fmt.Errorf("llm: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{context.Canceled, codes.Canceled},
+		{status.Error(codes.PermissionDenied, "denied"), codes.PermissionDenied},
+		{errors.New("dial tcp 10.0.0.3:5432: connection refused"), codes.Internal},
+	}
+
+	for _, tc := range tests {
+		proc := &stubProcessor{err: tc.err}
+		_, err := NewRiskHandler(proc).AnalyzeTransaction(context.Background(), validRequest())
+		if got := status.Code(err); got != tc.code {
+			t.Errorf("%v: expected %v, got %v", tc.err, tc.code, got)
+		}
+	}
+
+	_, err := NewRiskHandler(&stubProcessor{err: errors.New("dial tcp 10.0.0.3:5432")}).AnalyzeTransaction(context.Background(), validRequest())
+	if strings.Contains(status.Convert(err).Message(), "10.0.0.3") {
+		t.Errorf("Expected internal details to be hidden, got %q", status.Convert(err).Message())
+	}
+}
+
+// failingLLM fails every call, or waits for the caller to give up when wait
+// is set.
+type failingLLM struct {
+	err  error
+	wait bool
+}
+
+func (l failingLLM) Analyze(ctx context.Context, _, _ string) (domain.RiskAssessment, error) {
+	if l.wait {
+		<-ctx.Done()
+		return domain.RiskAssessment{}, fmt.Errorf("llm request: %w", ctx.Err())
+	}
+	return domain.RiskAssessment{}, l.err
+}
+
+func TestAnalyzeTransaction_LLMFailureIsMarkedDegraded(t *testing.T) {
+	for _, tc := range []struct {
+		err    error
+		policy string
+		block  bool
+	}{
+		{fmt.Errorf("%w: queue full", domain.ErrLLMOverloaded), usecase.DegradeBlock, true},
+		{errors.New("provider returned 502"), usecase.DegradeAllow, false},
+	} {
+		analyzer := usecase.NewAnalyzer(failingLLM{err: tc.err}, usecase.WithDegradation(tc.policy))
+		resp, err := NewRiskHandler(analyzer).AnalyzeTransaction(context.Background(), validRequest())
+		if err != nil {
+			t.Fatalf("%v: expected the degradation verdict, got %v", tc.err, err)
+		}
+		if !resp.Degraded || resp.IsBlocked != tc.block || resp.Explanation.GetRoute() != domain.RouteDegraded {
+			t.Errorf("%v: expected a degraded %s verdict, got %+v", tc.err, tc.policy, resp)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	analyzer := usecase.NewAnalyzer(failingLLM{wait: true})
+	if _, err := NewRiskHandler(analyzer).AnalyzeTransaction(ctx, validRequest()); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded once the caller's deadline passed, got %v", err)
+	}
+}
+
+type stubReporter struct {
+	reported []domain.Label
+}
+
+func (s *stubReporter) Report(_ context.Context, l domain.Label) (domain.OutcomeResult, error) {
+	if err := l.Validate(); err != nil {
+		return domain.OutcomeResult{}, err
+	}
+	for _, r := range s.reported {
+		if r.TransactionID == l.TransactionID && r.Label == l.Label {
+			return domain.OutcomeResult{Label: r, Duplicate: true}, nil
+		}
+	}
+	s.reported = append(s.reported, l)
+	return domain.OutcomeResult{Label: l}, nil
+}
+
+func TestImportOutcomes(t *testing.T) {
+	files := []struct {
+		format pb.OutcomeFileFormat
+		data   string
+	}{
+		{pb.OutcomeFileFormat_OUTCOME_FILE_FORMAT_CSV, "source,transaction_id,label,timestamp\n" +
+			"chargeback,tx-1,fraud,2026-10-01T12:00:00Z\n" +
+			"dispute,tx-2,Legitimate,\n" +
+			"chargeback,tx-1,fraud,\n" +
+			"chargeback,tx-3,stolen,\n" +
+			"other,tx-4,fraud,yesterday\n"},
+		{pb.OutcomeFileFormat_OUTCOME_FILE_FORMAT_JSONL, `{"transaction_id":"tx-1","label":"fraud","source":"chargeback","timestamp":"2026-10-01T12:00:00Z"}` + "\n" +
+			`{"transaction_id":"tx-2","label":"legitimate","source":"dispute"}` + "\n\n" +
+			`{"transaction_id":"tx-1","label":"fraud","source":"chargeback"}` + "\n" +
+			`{"transaction_id":"tx-3","label":"stolen","source":"chargeback"}` + "\n" +
+			`{"transaction_id":"tx-4","label":"fraud","source":"other","amount":5}` + "\n"},
+	}
+
+	for _, f := range files {
+		reporter := &stubReporter{}
+		resp, err := NewFeedbackHandler(reporter).ImportOutcomes(context.Background(), &pb.ImportOutcomesRequest{
+			TenantId: "acme",
+			Format:   f.format,
+			Data:     []byte(f.data),
+		})
+		if err != nil {
+			t.Fatalf("%v: ImportOutcomes: %v", f.format, err)
+		}
+		if resp.Imported != 2 || resp.Duplicates != 1 || len(resp.Errors) != 2 {
+			t.Errorf("%v: expected 2 imported, 1 duplicate and 2 errors, got %+v", f.format, resp)
+		}
+		if len(resp.Errors) == 2 && (resp.Errors[0].Line != 5 || resp.Errors[1].Line != 6) {
+			t.Errorf("%v: expected errors on lines 5 and 6, got %v", f.format, resp.Errors)
+		}
+		if l := reporter.reported[0]; l.TenantID != "acme" || !l.OccurredAt.Equal(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)) {
+			t.Errorf("%v: unexpected label %+v", f.format, l)
+		}
+	}
+
+	_, err := NewFeedbackHandler(&stubReporter{}).ImportOutcomes(context.Background(), &pb.ImportOutcomesRequest{
+		Format: pb.OutcomeFileFormat_OUTCOME_FILE_FORMAT_CSV,
+		Data:   []byte("transaction_id,label\ntx-1,fraud\n"),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected a CSV without a source column to be refused, got %v", err)
+	}
+}
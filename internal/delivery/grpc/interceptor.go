@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/pkg/logger"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDHeader = "x-request-id"
+
+// identifiedRequest is implemented by requests that carry transaction identity.
+type identifiedRequest interface {
+	GetTransactionId() string
+	GetUserId() string
+}
+
+// LoggingInterceptor enriches the request context with correlation fields so
+// every slog *Context call made while serving the request carries them.
+func LoggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	attrs := []slog.Attr{
+		slog.String("request_id", requestID(ctx)),
+		slog.String("method", info.FullMethod),
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+	}
+	if tenant := tenantID(ctx, req); tenant != "" {
+		attrs = append(attrs, slog.String("tenant_id", tenant))
+	}
+	if r, ok := req.(identifiedRequest); ok {
+		attrs = append(attrs,
+			slog.String("transaction_id", r.GetTransactionId()),
+			slog.String("user_id", r.GetUserId()),
+		)
+	}
+	ctx = logger.WithAttrs(ctx, attrs...)
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	slog.InfoContext(ctx, "grpc request handled",
+		"code", status.Code(err).String(),
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	return resp, err
+}
+
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDHeader); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
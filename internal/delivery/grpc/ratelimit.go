@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"container/list"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/auth"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	scopeTenant = "tenant"
+	scopeClient = "client"
+
+	// maxClientBuckets caps the client buckets kept, so that anonymous
+	// callers cannot grow them forever. Past it, the least recently seen
+	// client's bucket is dropped.
+	maxClientBuckets = 10000
+)
+
+// RateLimit is a token bucket of RPS requests per second with the given
+// burst. A zero RPS means unlimited.
+type RateLimit struct {
+	RPS   float64
+	Burst int
+}
+
+func (l RateLimit) newLimiter() *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(l.RPS), max(l.Burst, 1))
+}
+
+// RateLimiter enforces a request rate per tenant and per caller. Callers are
+// keyed by their authenticated identity, or by peer address when
+// authentication is off.
+type RateLimiter struct {
+	defaultTenant string
+	tenantLimits  map[string]RateLimit
+	clientLimit   RateLimit
+	now           func() time.Time
+
+	mu         sync.Mutex
+	tenants    map[string]*rate.Limiter
+	maxClients int
+	// clients indexes recent, a list of client buckets with the most
+	// recently seen first.
+	clients map[string]*list.Element
+	recent  *list.List
+}
+
+type clientBucket struct {
+	client  string
+	limiter *rate.Limiter
+}
+
+func NewRateLimiter(defaultTenant string, tenantLimits map[string]RateLimit, clientLimit RateLimit) *RateLimiter {
+	return &RateLimiter{
+		defaultTenant: defaultTenant,
+		tenantLimits:  tenantLimits,
+		clientLimit:   clientLimit,
+		now:           time.Now,
+		tenants:       make(map[string]*rate.Limiter),
+		maxClients:    maxClientBuckets,
+		clients:       make(map[string]*list.Element),
+		recent:        list.New(),
+	}
+}
+
+// Allow charges one request to client and tenant and returns the scope that
+// was exhausted, if any. The client is checked first so that one noisy caller
+// does not drain its tenant's budget; its token is given back when the tenant
+// is over its rate, so a throttled tenant does not use up its callers' budgets
+// too.
+func (l *RateLimiter) Allow(client, tenant string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var reserved *rate.Reservation
+	if l.clientLimit.RPS > 0 {
+		reserved = l.clientBucket(client).ReserveN(now, 1)
+		if !reserved.OK() || reserved.DelayFrom(now) > 0 {
+			reserved.CancelAt(now)
+			return scopeClient, false
+		}
+	}
+
+	limit, ok := l.tenantLimits[tenant]
+	if !ok || limit.RPS <= 0 {
+		return "", true
+	}
+	lim, ok := l.tenants[tenant]
+	if !ok {
+		lim = limit.newLimiter()
+		l.tenants[tenant] = lim
+	}
+	if !lim.AllowN(now, 1) {
+		if reserved != nil {
+			reserved.CancelAt(now)
+		}
+		return scopeTenant, false
+	}
+	return "", true
+}
+
+// clientBucket returns the bucket of client, dropping the least recently
+// seen one when there are too many. Callers must hold l.mu.
+func (l *RateLimiter) clientBucket(client string) *rate.Limiter {
+	if e, ok := l.clients[client]; ok {
+		l.recent.MoveToFront(e)
+		return e.Value.(*clientBucket).limiter
+	}
+
+	if l.recent.Len() >= l.maxClients {
+		oldest := l.recent.Back()
+		l.recent.Remove(oldest)
+		delete(l.clients, oldest.Value.(*clientBucket).client)
+	}
+	b := &clientBucket{client: client, limiter: l.clientLimit.newLimiter()}
+	l.clients[client] = l.recent.PushFront(b)
+	return b.limiter
+}
+
+// UnaryInterceptor rejects analysis requests over their caller's or tenant's
+// rate with ResourceExhausted. Admin calls are not limited, and unknown
+// tenants pass through to be rejected by the handler.
+func (l *RateLimiter) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, "/"+pb.RiskEngineService_ServiceDesc.ServiceName+"/") {
+		return handler(ctx, req)
+	}
+
+	tenant := tenantID(ctx, req)
+	if tenant == "" {
+		tenant = l.defaultTenant
+	}
+
+	if scope, ok := l.Allow(clientKey(ctx), tenant); !ok {
+		metrics.RateLimited.WithLabelValues(tenant, scope).Inc()
+		return nil, status.Errorf(codes.ResourceExhausted, "%s rate limit exceeded", scope)
+	}
+	return handler(ctx, req)
+}
+
+func clientKey(ctx context.Context) string {
+	if id, ok := auth.FromContext(ctx); ok {
+		return "id:" + id.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "addr:" + host
+	}
+	return ""
+}
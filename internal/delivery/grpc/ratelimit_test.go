@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_TenantRejectionKeepsClientBudget(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewRateLimiter("default", map[string]RateLimit{"acme": {RPS: 1, Burst: 1}}, RateLimit{RPS: 1, Burst: 2})
+	l.now = func() time.Time { return now }
+
+	if scope, ok := l.Allow("c1", "acme"); !ok {
+		t.Fatalf("Expected the first request to pass, got %s", scope)
+	}
+	if scope, ok := l.Allow("c1", "acme"); ok || scope != scopeTenant {
+		t.Fatalf("Expected the tenant limit, got %q %v", scope, ok)
+	}
+
+	// The tenant rejection gave the client's token back, so the caller can
+	// still use its last token on another tenant.
+	if scope, ok := l.Allow("c1", "other"); !ok {
+		t.Errorf("Expected the client budget to be intact, got %s", scope)
+	}
+	if scope, ok := l.Allow("c1", "other"); ok || scope != scopeClient {
+		t.Errorf("Expected the client limit, got %q %v", scope, ok)
+	}
+}
+
+func TestRateLimiter_CapsClientBuckets(t *testing.T) {
+	l := NewRateLimiter("default", nil, RateLimit{RPS: 1, Burst: 1})
+	l.maxClients = 3
+
+	for i := range 10 {
+		l.Allow(fmt.Sprintf("c%d", i), "default")
+		if i%2 == 0 {
+			// c0 stays recently seen.
+			l.Allow("c0", "default")
+		}
+	}
+
+	if len(l.clients) != 3 || l.recent.Len() != 3 {
+		t.Fatalf("Expected 3 client buckets, got %d", len(l.clients))
+	}
+	for _, c := range []string{"c0", "c8", "c9"} {
+		if _, ok := l.clients[c]; !ok {
+			t.Errorf("Expected the bucket of recently seen %s to be kept", c)
+		}
+	}
+	if _, ok := l.Allow("c0", "default"); ok {
+		t.Error("Expected c0 to keep its spent bucket rather than get a fresh one")
+	}
+}
@@ -0,0 +1,246 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/auth"
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultReviewPageSize = 100
+	maxReviewPageSize     = 1000
+	maxNotesLen           = 4 << 10
+)
+
+// ReviewManager is the review queue. An empty tenant matches every tenant.
+type ReviewManager interface {
+	List(f domain.ReviewFilter) []domain.ReviewCase
+	Get(tenant, id string) (domain.ReviewCase, error)
+	Claim(tenant, id, reviewer string) (domain.ReviewCase, error)
+	Resolve(tenant, id, reviewer, outcome, notes string) (domain.ReviewCase, error)
+}
+
+type ReviewHandler struct {
+	pb.UnimplementedRiskReviewServiceServer
+	reviews  ReviewManager
+	outcomes OutcomeReporter
+}
+
+// NewReviewHandler serves the review queue. Resolved cases are reported to
+// outcomes as labels, unless it is nil.
+func NewReviewHandler(reviews ReviewManager, outcomes OutcomeReporter) *ReviewHandler {
+	return &ReviewHandler{reviews: reviews, outcomes: outcomes}
+}
+
+var (
+	listReviewRules = []fieldRule[*pb.ListReviewCasesRequest]{
+		stringField("tenant_id", (*pb.ListReviewCasesRequest).GetTenantId, maxLen(maxIDLen), printable),
+		{"page_size", func(r *pb.ListReviewCasesRequest) string {
+			if r.PageSize < 0 || r.PageSize > maxReviewPageSize {
+				return "must be between 0 and 1000"
+			}
+			return ""
+		}},
+	}
+	getReviewRules = []fieldRule[*pb.GetReviewCaseRequest]{
+		stringField("id", (*pb.GetReviewCaseRequest).GetId, required, maxLen(maxIDLen), printable),
+		stringField("tenant_id", (*pb.GetReviewCaseRequest).GetTenantId, maxLen(maxIDLen), printable),
+	}
+	claimReviewRules = []fieldRule[*pb.ClaimReviewCaseRequest]{
+		stringField("id", (*pb.ClaimReviewCaseRequest).GetId, maxLen(maxIDLen), printable),
+		stringField("tenant_id", (*pb.ClaimReviewCaseRequest).GetTenantId, maxLen(maxIDLen), printable),
+		stringField("reviewer", (*pb.ClaimReviewCaseRequest).GetReviewer, maxLen(maxIDLen), printable),
+	}
+	submitReviewRules = []fieldRule[*pb.SubmitReviewOutcomeRequest]{
+		stringField("id", (*pb.SubmitReviewOutcomeRequest).GetId, required, maxLen(maxIDLen), printable),
+		stringField("tenant_id", (*pb.SubmitReviewOutcomeRequest).GetTenantId, maxLen(maxIDLen), printable),
+		stringField("reviewer", (*pb.SubmitReviewOutcomeRequest).GetReviewer, maxLen(maxIDLen), printable),
+		stringField("notes", (*pb.SubmitReviewOutcomeRequest).GetNotes, maxLen(maxNotesLen), validUTF8),
+		{"outcome", func(r *pb.SubmitReviewOutcomeRequest) string {
+			if _, ok := reviewOutcomes[r.Outcome]; !ok {
+				return "must be approved or fraud"
+			}
+			return ""
+		}},
+	}
+)
+
+func (h *ReviewHandler) ListReviewCases(ctx context.Context, req *pb.ListReviewCasesRequest) (*pb.ListReviewCasesResponse, error) {
+	if err := validate(req, listReviewRules); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.PageSize)
+	if limit == 0 {
+		limit = defaultReviewPageSize
+	}
+	cases := h.reviews.List(domain.ReviewFilter{
+		Tenant:      tenantID(ctx, req),
+		Status:      reviewStatuses[req.Status],
+		OverdueOnly: req.OverdueOnly,
+		Limit:       limit,
+	})
+
+	resp := &pb.ListReviewCasesResponse{}
+	for _, c := range cases {
+		resp.Cases = append(resp.Cases, toPBReviewCase(c))
+	}
+	return resp, nil
+}
+
+func (h *ReviewHandler) GetReviewCase(ctx context.Context, req *pb.GetReviewCaseRequest) (*pb.ReviewCase, error) {
+	if err := validate(req, getReviewRules); err != nil {
+		return nil, err
+	}
+
+	c, err := h.reviews.Get(tenantID(ctx, req), req.Id)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toPBReviewCase(c), nil
+}
+
+func (h *ReviewHandler) ClaimReviewCase(ctx context.Context, req *pb.ClaimReviewCaseRequest) (*pb.ReviewCase, error) {
+	if err := validate(req, claimReviewRules); err != nil {
+		return nil, err
+	}
+	who, err := reviewer(ctx, req.Reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := h.reviews.Claim(tenantID(ctx, req), req.Id, who)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toPBReviewCase(c), nil
+}
+
+func (h *ReviewHandler) SubmitReviewOutcome(ctx context.Context, req *pb.SubmitReviewOutcomeRequest) (*pb.ReviewCase, error) {
+	if err := validate(req, submitReviewRules); err != nil {
+		return nil, err
+	}
+	who, err := reviewer(ctx, req.Reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := h.reviews.Resolve(tenantID(ctx, req), req.Id, who, reviewOutcomes[req.Outcome], req.Notes)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	h.reportOutcome(ctx, c)
+	return toPBReviewCase(c), nil
+}
+
+// reportOutcome labels the transaction of a resolved case. The case stays
+// resolved if this fails.
+func (h *ReviewHandler) reportOutcome(ctx context.Context, c domain.ReviewCase) {
+	if h.outcomes == nil {
+		return
+	}
+	label := domain.LabelLegitimate
+	if c.Outcome == domain.OutcomeFraud {
+		label = domain.LabelFraud
+	}
+	_, err := h.outcomes.Report(ctx, domain.Label{
+		TransactionID: c.Transaction.ID,
+		TenantID:      c.TenantID,
+		Label:         label,
+		Source:        domain.SourceReview,
+		OccurredAt:    c.ResolvedAt,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to label reviewed transaction", "case_id", c.ID, "err", err)
+	}
+}
+
+// reviewer is the authenticated caller or, with authentication off, the
+// reviewer the request names.
+func reviewer(ctx context.Context, requested string) (string, error) {
+	if id, ok := auth.FromContext(ctx); ok {
+		return id.Subject, nil
+	}
+	if requested == "" {
+		return "", status.Error(codes.InvalidArgument, "reviewer is required when authentication is disabled")
+	}
+	return requested, nil
+}
+
+func toPBReviewCase(c domain.ReviewCase) *pb.ReviewCase {
+	tx := c.Transaction
+	amount := tx.Amount
+	if tx.OriginalAmount.Currency != "" {
+		amount = tx.OriginalAmount
+	}
+
+	return &pb.ReviewCase{
+		Id:       c.ID,
+		TenantId: c.TenantID,
+		Transaction: &pb.AnalyzeRequest{
+			TransactionId:      tx.ID,
+			UserId:             tx.UserID,
+			Merchant:           tx.Merchant,
+			Location:           tx.Location,
+			UserProfileContext: tx.UserProfile,
+			DeviceId:           tx.DeviceID,
+			IpAddress:          tx.IPAddress,
+			CardBin:            tx.CardBIN,
+			CountryCode:        tx.CountryCode,
+			Money:              &pb.Money{MinorUnits: amount.MinorUnits, Currency: amount.Currency},
+			TenantId:           tx.TenantID,
+		},
+		Reason: c.Reason,
+		Explanation: toPBExplanation(domain.RiskAssessment{
+			ConfidenceScore: c.ConfidenceScore,
+			Reason:          c.Reason,
+			Route:           c.Route,
+			RouteReason:     c.RouteReason,
+			Overrides:       c.Overrides,
+			Explanation:     c.Explanation,
+		}),
+		Status:      toPBReviewStatus(c.Status),
+		CreatedAt:   toPBTime(c.CreatedAt),
+		DueAt:       toPBTime(c.DueAt),
+		Escalations: int32(c.Escalations),
+		Assignee:    c.Assignee,
+		ClaimedAt:   toPBTime(c.ClaimedAt),
+		Outcome:     toPBReviewOutcome(c.Outcome),
+		Notes:       c.Notes,
+		ResolvedAt:  toPBTime(c.ResolvedAt),
+	}
+}
+
+var reviewStatuses = map[pb.ReviewStatus]string{
+	pb.ReviewStatus_REVIEW_STATUS_PENDING:  domain.ReviewPending,
+	pb.ReviewStatus_REVIEW_STATUS_CLAIMED:  domain.ReviewClaimed,
+	pb.ReviewStatus_REVIEW_STATUS_RESOLVED: domain.ReviewResolved,
+}
+
+var reviewOutcomes = map[pb.ReviewOutcome]string{
+	pb.ReviewOutcome_REVIEW_OUTCOME_APPROVED: domain.OutcomeApproved,
+	pb.ReviewOutcome_REVIEW_OUTCOME_FRAUD:    domain.OutcomeFraud,
+}
+
+func toPBReviewStatus(s string) pb.ReviewStatus {
+	for k, v := range reviewStatuses {
+		if v == s {
+			return k
+		}
+	}
+	return pb.ReviewStatus_REVIEW_STATUS_UNSPECIFIED
+}
+
+func toPBReviewOutcome(o string) pb.ReviewOutcome {
+	for k, v := range reviewOutcomes {
+		if v == o {
+			return k
+		}
+	}
+	return pb.ReviewOutcome_REVIEW_OUTCOME_UNSPECIFIED
+}
@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/auth"
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/review"
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestReviewHandler(t *testing.T, cases ...domain.Transaction) (*ReviewHandler, *stubReporter) {
+	t.Helper()
+
+	store, err := review.NewStore(filepath.Join(t.TempDir(), "reviews.json"), time.Hour, 0, nil)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Now()
+	for i, tx := range cases {
+		policy := domain.ReviewPolicy{SLA: time.Duration(i+1) * time.Hour}
+		if _, err := store.Enqueue(context.Background(), domain.NewReviewCase(tx, domain.RiskAssessment{Reason: "high value"}, policy, now)); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	reporter := &stubReporter{}
+	return NewReviewHandler(store, reporter), reporter
+}
+
+func TestReviewHandler_ClaimAndResolve(t *testing.T) {
+	h, reporter := newTestReviewHandler(t,
+		domain.Transaction{ID: "tx-1", TenantID: "acme", UserID: "u1"},
+		domain.Transaction{ID: "tx-2", TenantID: "acme", UserID: "u2"},
+		domain.Transaction{ID: "tx-3", TenantID: "other", UserID: "u3"},
+	)
+	jane := auth.WithIdentity(context.Background(), auth.Identity{Subject: "jane", Tenant: "acme"})
+	joe := auth.WithIdentity(context.Background(), auth.Identity{Subject: "joe", Tenant: "acme"})
+
+	list, err := h.ListReviewCases(jane, &pb.ListReviewCasesRequest{TenantId: "other"})
+	if err != nil {
+		t.Fatalf("ListReviewCases: %v", err)
+	}
+	if len(list.Cases) != 2 || list.Cases[0].TenantId != "acme" || list.Cases[0].Transaction.TransactionId != "tx-1" {
+		t.Fatalf("Expected the two acme cases, soonest due first, got %v", list.Cases)
+	}
+	other, err := h.ListReviewCases(context.Background(), &pb.ListReviewCasesRequest{TenantId: "other"})
+	if err != nil || len(other.Cases) != 1 {
+		t.Fatalf("Expected the other tenant's case, got %v, %v", other, err)
+	}
+	if _, err := h.GetReviewCase(jane, &pb.GetReviewCaseRequest{Id: other.Cases[0].Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected another tenant's case to be hidden, got %v", err)
+	}
+
+	claimed, err := h.ClaimReviewCase(jane, &pb.ClaimReviewCaseRequest{Reviewer: "mallory"})
+	if err != nil {
+		t.Fatalf("ClaimReviewCase: %v", err)
+	}
+	if claimed.Id != list.Cases[0].Id || claimed.Assignee != "jane" || claimed.Status != pb.ReviewStatus_REVIEW_STATUS_CLAIMED {
+		t.Errorf("Expected jane to claim the first case, got %v", claimed)
+	}
+	if _, err := h.ClaimReviewCase(joe, &pb.ClaimReviewCaseRequest{Id: claimed.Id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected a claimed case to be refused to another reviewer, got %v", err)
+	}
+	if _, err := h.SubmitReviewOutcome(joe, &pb.SubmitReviewOutcomeRequest{Id: claimed.Id, Outcome: pb.ReviewOutcome_REVIEW_OUTCOME_FRAUD}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected only the assignee to resolve the case, got %v", err)
+	}
+
+	resolved, err := h.SubmitReviewOutcome(jane, &pb.SubmitReviewOutcomeRequest{Id: claimed.Id, Outcome: pb.ReviewOutcome_REVIEW_OUTCOME_FRAUD, Notes: "card stolen"})
+	if err != nil {
+		t.Fatalf("SubmitReviewOutcome: %v", err)
+	}
+	if resolved.Status != pb.ReviewStatus_REVIEW_STATUS_RESOLVED || resolved.Outcome != pb.ReviewOutcome_REVIEW_OUTCOME_FRAUD || resolved.Notes != "card stolen" {
+		t.Errorf("Unexpected resolved case %v", resolved)
+	}
+	if len(reporter.reported) != 1 || reporter.reported[0].TransactionID != "tx-1" || reporter.reported[0].Label != domain.LabelFraud || reporter.reported[0].Source != domain.SourceReview {
+		t.Errorf("Expected the outcome to be reported as a fraud label, got %v", reporter.reported)
+	}
+	if _, err := h.SubmitReviewOutcome(jane, &pb.SubmitReviewOutcomeRequest{Id: claimed.Id, Outcome: pb.ReviewOutcome_REVIEW_OUTCOME_APPROVED}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected a resolved case to stay resolved, got %v", err)
+	}
+
+	if _, err := h.ClaimReviewCase(jane, &pb.ClaimReviewCaseRequest{}); err != nil {
+		t.Fatalf("ClaimReviewCase: %v", err)
+	}
+	if _, err := h.ClaimReviewCase(joe, &pb.ClaimReviewCaseRequest{}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound once the queue is empty, got %v", err)
+	}
+}
+
+func TestReviewHandler_Validation(t *testing.T) {
+	h, _ := newTestReviewHandler(t, domain.Transaction{ID: "tx-1", TenantID: "acme", UserID: "u1"})
+	ctx := context.Background()
+
+	if _, err := h.ClaimReviewCase(ctx, &pb.ClaimReviewCaseRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected a reviewer to be required without authentication, got %v", err)
+	}
+	if got := fieldViolations(t, func() error {
+		_, err := h.ListReviewCases(ctx, &pb.ListReviewCasesRequest{PageSize: maxReviewPageSize + 1})
+		return err
+	}()); got["page_size"] == "" {
+		t.Errorf("Expected a page_size violation, got %v", got)
+	}
+	if got := fieldViolations(t, func() error {
+		_, err := h.SubmitReviewOutcome(ctx, &pb.SubmitReviewOutcomeRequest{Reviewer: "jane"})
+		return err
+	}()); got["id"] == "" || got["outcome"] == "" {
+		t.Errorf("Expected id and outcome violations, got %v", got)
+	}
+	if _, err := h.GetReviewCase(ctx, &pb.GetReviewCaseRequest{Id: "rc_missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown case, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/auth"
+	"google.golang.org/grpc/metadata"
+)
+
+const tenantHeader = "x-tenant-id"
+
+type tenantRequest interface {
+	GetTenantId() string
+}
+
+// tenantID returns the tenant the caller is bound to or, for unbound callers,
+// the one the request names. Empty means the default tenant.
+func tenantID(ctx context.Context, req any) string {
+	if id, ok := auth.FromContext(ctx); ok && id.Tenant != "" {
+		return id.Tenant
+	}
+	return requestedTenant(ctx, req)
+}
+
+// requestedTenant returns the tenant named by the request, falling back to
+// the x-tenant-id metadata header.
+func requestedTenant(ctx context.Context, req any) string {
+	if r, ok := req.(tenantRequest); ok && r.GetTenantId() != "" {
+		return r.GetTenantId()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(tenantHeader); len(ids) > 0 {
+			return ids[0]
+		}
+	}
+	return ""
+}
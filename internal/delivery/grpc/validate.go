@@ -0,0 +1,183 @@
+package grpc
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tokyosplif/ai-risk-engine/pkg/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxIDLen      = 128
+	maxTextLen    = 256
+	maxProfileLen = 8 << 10
+)
+
+var (
+	currencyPattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+	countryPattern  = regexp.MustCompile(`^[A-Za-z]{2}$`)
+	cardBINPattern  = regexp.MustCompile(`^[0-9]{6,8}$`)
+)
+
+// fieldRule constrains one field of a request. check returns a description
+// of the violation, or "" when the field is valid.
+type fieldRule[T any] struct {
+	field string
+	check func(T) string
+}
+
+// stringCheck returns a description of why s is invalid, or "".
+type stringCheck func(s string) string
+
+// stringField applies checks in order to the value get returns and reports
+// the first violation.
+func stringField[T any](field string, get func(T) string, checks ...stringCheck) fieldRule[T] {
+	return fieldRule[T]{field: field, check: func(req T) string {
+		s := get(req)
+		for _, c := range checks {
+			if d := c(s); d != "" {
+				return d
+			}
+		}
+		return ""
+	}}
+}
+
+func required(s string) string {
+	if s == "" {
+		return "is required"
+	}
+	return ""
+}
+
+func maxLen(n int) stringCheck {
+	return func(s string) string {
+		if len(s) > n {
+			return fmt.Sprintf("must be at most %d bytes, got %d", n, len(s))
+		}
+		return ""
+	}
+}
+
+// printable rejects invalid UTF-8 and control characters, which have no
+// place in identifiers and break log lines.
+func printable(s string) string {
+	if !utf8.ValidString(s) {
+		return "must be valid UTF-8"
+	}
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			return "must not contain control characters"
+		}
+	}
+	return ""
+}
+
+func validUTF8(s string) string {
+	if !utf8.ValidString(s) {
+		return "must be valid UTF-8"
+	}
+	return ""
+}
+
+// optional skips checks for an empty value.
+func optional(checks ...stringCheck) stringCheck {
+	return func(s string) string {
+		if s == "" {
+			return ""
+		}
+		for _, c := range checks {
+			if d := c(s); d != "" {
+				return d
+			}
+		}
+		return ""
+	}
+}
+
+func matches(re *regexp.Regexp, desc string) stringCheck {
+	return func(s string) string {
+		if !re.MatchString(s) {
+			return desc
+		}
+		return ""
+	}
+}
+
+func ipAddress(s string) string {
+	if _, err := netip.ParseAddr(s); err != nil {
+		return "must be an IPv4 or IPv6 address"
+	}
+	return ""
+}
+
+var analyzeRules = []fieldRule[*pb.AnalyzeRequest]{
+	stringField("transaction_id", (*pb.AnalyzeRequest).GetTransactionId, required, maxLen(maxIDLen), printable),
+	stringField("user_id", (*pb.AnalyzeRequest).GetUserId, required, maxLen(maxIDLen), printable),
+	stringField("tenant_id", (*pb.AnalyzeRequest).GetTenantId, maxLen(maxIDLen), printable),
+	stringField("merchant", (*pb.AnalyzeRequest).GetMerchant, required, maxLen(maxTextLen), printable),
+	stringField("location", (*pb.AnalyzeRequest).GetLocation, maxLen(maxTextLen), printable),
+	stringField("user_profile_context", (*pb.AnalyzeRequest).GetUserProfileContext, maxLen(maxProfileLen), validUTF8),
+	stringField("device_id", (*pb.AnalyzeRequest).GetDeviceId, maxLen(maxIDLen), printable),
+	stringField("ip_address", (*pb.AnalyzeRequest).GetIpAddress, optional(ipAddress)),
+	stringField("card_bin", (*pb.AnalyzeRequest).GetCardBin, optional(matches(cardBINPattern, "must be 6 to 8 digits"))),
+	stringField("country_code", (*pb.AnalyzeRequest).GetCountryCode, optional(matches(countryPattern, "must be an ISO 3166-1 alpha-2 code"))),
+	{field: "money.minor_units", check: func(req *pb.AnalyzeRequest) string {
+		if m := req.GetMoney(); m != nil && m.MinorUnits < 0 {
+			return "must not be negative"
+		}
+		return ""
+	}},
+	stringField("money.currency", func(req *pb.AnalyzeRequest) string { return req.GetMoney().GetCurrency() },
+		optional(matches(currencyPattern, "must be an ISO 4217 code"))),
+	// The deprecated amount and currency are only read without money.
+	{field: "amount", check: func(req *pb.AnalyzeRequest) string {
+		if req.GetMoney() != nil {
+			return ""
+		}
+		switch a := req.Amount; {
+		case math.IsNaN(a) || math.IsInf(a, 0):
+			return "must be a finite number"
+		case a < 0:
+			return "must not be negative"
+		}
+		return ""
+	}},
+	stringField("currency", func(req *pb.AnalyzeRequest) string {
+		if req.GetMoney() != nil {
+			return ""
+		}
+		return req.Currency
+	}, optional(matches(currencyPattern, "must be an ISO 4217 code"))),
+}
+
+// validate checks req against rules and returns an InvalidArgument status
+// carrying a google.rpc.BadRequest with every violated field, or nil.
+func validate[T any](req T, rules []fieldRule[T]) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, r := range rules {
+		if d := r.check(req); d != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: r.field, Description: d})
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	msg := "invalid request: " + violations[0].Field + " " + violations[0].Description
+	if len(violations) > 1 {
+		msg += fmt.Sprintf(" (and %d more)", len(violations)-1)
+	}
+	st, err := status.New(codes.InvalidArgument, msg).WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return st.Err()
+}
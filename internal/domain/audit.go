@@ -0,0 +1,19 @@
+package domain
+
+import "errors"
+
+// ErrAuditUnavailable is returned when a decision could not be written to the
+// audit trail. The decision is not returned to the caller.
+var ErrAuditUnavailable = errors.New("audit trail unavailable")
+
+// AuditRecord is everything the audit trail keeps about a single analysis.
+type AuditRecord struct {
+	Transaction Transaction
+	TxData      string
+	Features    *UserFeatures
+	Geo         *GeoFeatures
+	LLM         *LLMTrace
+	LLMVerdict  RiskAssessment
+	LLMError    string
+	Final       RiskAssessment
+}
@@ -0,0 +1,105 @@
+package domain
+
+import "time"
+
+// Decision events are published for every decision. The schema version
+// changes only when a field is removed or changes meaning; new fields may be
+// added within a version.
+const (
+	EventTypeDecision    = "risk.decision"
+	DecisionEventVersion = 1
+)
+
+// DecisionEvent is a decision as published to downstream systems. Consumers
+// should deduplicate on ID: delivery is at least once.
+type DecisionEvent struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	SchemaVersion int       `json:"schema_version"`
+	TenantID      string    `json:"tenant_id"`
+	OccurredAt    time.Time `json:"occurred_at"`
+
+	// Transaction is the transaction as analyzed, in the base currency.
+	Transaction Transaction   `json:"transaction"`
+	Features    *UserFeatures `json:"features,omitempty"`
+	Geo         *GeoFeatures  `json:"geo,omitempty"`
+
+	Assessment EventAssessment `json:"assessment"`
+	// LLMVerdict is the model's verdict before the overrides in
+	// Assessment.Overrides were applied; empty unless the LLM decided.
+	LLMVerdict *EventVerdict `json:"llm_verdict,omitempty"`
+}
+
+// EventAssessment is the final RiskAssessment with its rule trace.
+type EventAssessment struct {
+	EventVerdict
+	Route       string      `json:"route"`
+	RouteReason string      `json:"route_reason"`
+	Overrides   []Override  `json:"overrides"`
+	Explanation Explanation `json:"explanation"`
+	// LLM names the model and prompt that produced the verdict. Prompts and
+	// raw responses stay in the audit trail.
+	LLM          *EventLLM `json:"llm,omitempty"`
+	ReviewCaseID string    `json:"review_case_id,omitempty"`
+}
+
+type EventVerdict struct {
+	Decision        string `json:"decision"`
+	IsBlocked       bool   `json:"is_blocked"`
+	ConfidenceScore int    `json:"confidence_score"`
+	Reason          string `json:"reason"`
+	AIPushMessage   string `json:"ai_push_message"`
+}
+
+type EventLLM struct {
+	Model            string `json:"model"`
+	PromptVersion    string `json:"prompt_version"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+}
+
+// NewDecisionEvent builds the event for the audited decision rec made at at.
+// The ID is assigned when the event is published.
+func NewDecisionEvent(rec AuditRecord, at time.Time) DecisionEvent {
+	final := rec.Final
+	e := DecisionEvent{
+		Type:          EventTypeDecision,
+		SchemaVersion: DecisionEventVersion,
+		TenantID:      rec.Transaction.TenantID,
+		OccurredAt:    at,
+		Transaction:   rec.Transaction,
+		Features:      rec.Features,
+		Geo:           rec.Geo,
+		Assessment: EventAssessment{
+			EventVerdict: newEventVerdict(final),
+			Route:        final.Route,
+			RouteReason:  final.RouteReason,
+			Overrides:    final.Overrides,
+			Explanation:  final.Explanation,
+			ReviewCaseID: final.ReviewCaseID,
+		},
+	}
+	if t := rec.LLM; t != nil {
+		e.Assessment.LLM = &EventLLM{
+			Model:            t.Model,
+			PromptVersion:    t.PromptVersion,
+			PromptTokens:     t.PromptTokens,
+			CompletionTokens: t.CompletionTokens,
+		}
+	}
+	if final.Route == RouteLLM && rec.LLMError == "" {
+		v := newEventVerdict(rec.LLMVerdict)
+		e.LLMVerdict = &v
+	}
+	return e
+}
+
+func newEventVerdict(r RiskAssessment) EventVerdict {
+	return EventVerdict{
+		Decision:        r.Decision(),
+		IsBlocked:       r.IsBlocked,
+		ConfidenceScore: r.ConfidenceScore,
+		Reason:          r.Reason,
+		AIPushMessage:   r.AIPushMessage,
+	}
+}
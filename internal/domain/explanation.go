@@ -0,0 +1,21 @@
+package domain
+
+const (
+	DirectionIncreasesRisk = "increases_risk"
+	DirectionDecreasesRisk = "decreases_risk"
+	DirectionNeutral       = "neutral"
+)
+
+// RiskFactor is one input the decision took into account. Weight is in [0, 1]
+// and expresses how strongly the factor pushed in Direction.
+type RiskFactor struct {
+	Name      string  `json:"name"`
+	Direction string  `json:"direction"`
+	Weight    float64 `json:"weight"`
+	Detail    string  `json:"detail"`
+}
+
+type Explanation struct {
+	LLMRationale string       `json:"llm_rationale"`
+	Factors      []RiskFactor `json:"factors"`
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// UserFeatures are behavioral aggregates over a user's previously analyzed
+// transactions. Amounts are in the base currency. Windowed values cover the
+// window ending at the current transaction and exclude it.
+type UserFeatures struct {
+	HasHistory           bool          `json:"has_history"`
+	TxCount1m            int           `json:"tx_count_1m"`
+	TxCount1h            int           `json:"tx_count_1h"`
+	TxCount24h           int           `json:"tx_count_24h"`
+	AmountSum1m          Money         `json:"amount_sum_1m"`
+	AmountSum1h          Money         `json:"amount_sum_1h"`
+	AmountSum24h         Money         `json:"amount_sum_24h"`
+	DistinctMerchants24h int           `json:"distinct_merchants_24h"`
+	DistinctCountries24h int           `json:"distinct_countries_24h"`
+	KnownMerchant        bool          `json:"known_merchant"`
+	SinceLastTx          time.Duration `json:"since_last_tx"`
+	MaxAmount24h         Money         `json:"max_amount_24h"`
+	AvgAmount24h         Money         `json:"avg_amount_24h"`
+	// LastGeo is the location of the most recent geolocated transaction.
+	LastGeo   *GeoPoint `json:"last_geo,omitempty"`
+	LastGeoAt time.Time `json:"last_geo_at,omitzero"`
+	// ConfirmedFraud and ConfirmedLegit count the user's transactions labelled
+	// by chargebacks, disputes or reviews over the last 180 days.
+	ConfirmedFraud int       `json:"confirmed_fraud"`
+	ConfirmedLegit int       `json:"confirmed_legit"`
+	LastFraudAt    time.Time `json:"last_fraud_at,omitzero"`
+}
+
+// Summary renders the features for the LLM prompt.
+func (f UserFeatures) Summary() string {
+	outcomes := ""
+	if f.ConfirmedFraud > 0 || f.ConfirmedLegit > 0 {
+		outcomes = fmt.Sprintf("; OUTCOMES: confirmed_fraud=%d, confirmed_legit=%d", f.ConfirmedFraud, f.ConfirmedLegit)
+	}
+	if !f.HasHistory {
+		return "BEHAVIOR: no previous transactions observed" + outcomes
+	}
+	return fmt.Sprintf(
+		"BEHAVIOR: tx_1m=%d (sum %s), tx_1h=%d (sum %s), tx_24h=%d (sum %s), "+
+			"distinct_merchants_24h=%d, distinct_countries_24h=%d, known_merchant=%t, since_last_tx=%s, "+
+			"max_24h=%s, avg_24h=%s",
+		f.TxCount1m, f.AmountSum1m.Decimal(), f.TxCount1h, f.AmountSum1h.Decimal(), f.TxCount24h, f.AmountSum24h.Decimal(),
+		f.DistinctMerchants24h, f.DistinctCountries24h, f.KnownMerchant, f.SinceLastTx.Round(time.Second),
+		f.MaxAmount24h.Decimal(), f.AvgAmount24h.Decimal(),
+	) + outcomes
+}
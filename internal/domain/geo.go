@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	GeoSourceLocation = "location"
+	GeoSourceIP       = "ip"
+)
+
+const earthRadiusKm = 6371.0
+
+// GeoPoint is a resolved place. City is empty when only the country is known.
+type GeoPoint struct {
+	City    string  `json:"city,omitempty"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	Source  string  `json:"source"`
+}
+
+func (p GeoPoint) String() string {
+	if p.City == "" {
+		return p.Country
+	}
+	return p.City + ", " + p.Country
+}
+
+// Precise reports whether p was resolved to a city. A country-only point is
+// the country's centroid, too coarse to measure distances from.
+func (p GeoPoint) Precise() bool {
+	return p.City != ""
+}
+
+// DistanceKm is the great-circle distance between a and b.
+func DistanceKm(a, b GeoPoint) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(b.Lon - a.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// GeoFeatures are the geographic facts derived for a single transaction.
+// Distances and speed are only meaningful when the matching Has flag is set.
+type GeoFeatures struct {
+	Location *GeoPoint `json:"location,omitempty"`
+	IP       *GeoPoint `json:"ip,omitempty"`
+	Home     *GeoPoint `json:"home,omitempty"`
+
+	HasHomeDistance bool    `json:"has_home_distance,omitempty"`
+	HomeDistanceKm  float64 `json:"home_distance_km,omitempty"`
+
+	HasIPDistance bool    `json:"has_ip_distance,omitempty"`
+	IPDistanceKm  float64 `json:"ip_distance_km,omitempty"`
+
+	// Travel compares the transaction with the user's previous geolocated one.
+	HasTravel        bool          `json:"has_travel,omitempty"`
+	TravelDistanceKm float64       `json:"travel_distance_km,omitempty"`
+	TravelElapsed    time.Duration `json:"travel_elapsed,omitempty"`
+	TravelSpeedKmh   float64       `json:"travel_speed_kmh,omitempty"`
+}
+
+// Point is where the transaction happened: the resolved location, falling
+// back to the IP address.
+func (g GeoFeatures) Point() *GeoPoint {
+	if g.Location != nil {
+		return g.Location
+	}
+	return g.IP
+}
+
+// Summary renders the geo features for the LLM prompt.
+func (g GeoFeatures) Summary() string {
+	var parts []string
+	if g.Location != nil {
+		parts = append(parts, "location="+g.Location.String())
+	}
+	if g.IP != nil {
+		parts = append(parts, "ip_location="+g.IP.String())
+	}
+	if g.HasHomeDistance {
+		parts = append(parts, fmt.Sprintf("distance_from_home_km=%.0f", g.HomeDistanceKm))
+	}
+	if g.HasIPDistance {
+		parts = append(parts, fmt.Sprintf("ip_to_location_km=%.0f", g.IPDistanceKm))
+	}
+	if g.HasTravel {
+		parts = append(parts, fmt.Sprintf("distance_from_last_tx_km=%.0f, travel_speed_kmh=%.0f", g.TravelDistanceKm, g.TravelSpeedKmh))
+	}
+	if len(parts) == 0 {
+		return "GEO: location could not be resolved"
+	}
+	return "GEO: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Outcome labels: what a transaction turned out to be.
+const (
+	LabelFraud      = "fraud"
+	LabelLegitimate = "legitimate"
+)
+
+// Sources of an outcome label.
+const (
+	SourceChargeback = "chargeback"
+	SourceDispute    = "dispute"
+	SourceReview     = "review"
+	SourceOther      = "other"
+)
+
+var ErrInvalidLabel = errors.New("invalid outcome label")
+
+// Label is the outcome of a transaction learned after the decision, from a
+// chargeback, a customer dispute or a manual review.
+type Label struct {
+	TransactionID string `json:"transaction_id"`
+	TenantID      string `json:"tenant_id"`
+	Label         string `json:"label"`
+	Source        string `json:"source"`
+	// OccurredAt is when the outcome happened as reported by the source, e.g.
+	// the chargeback date; ReportedAt is when the engine received it.
+	OccurredAt time.Time `json:"occurred_at"`
+	ReportedAt time.Time `json:"reported_at"`
+	// Decision is the engine's decision on the transaction, if it was still
+	// known when the label arrived.
+	Decision *LabeledDecision `json:"decision,omitempty"`
+}
+
+// LabeledDecision is the part of a decision kept with its label.
+type LabeledDecision struct {
+	Transaction     Transaction `json:"transaction"`
+	Decision        string      `json:"decision"`
+	Reason          string      `json:"reason"`
+	ConfidenceScore int         `json:"confidence_score"`
+	Route           string      `json:"route"`
+	DecidedAt       time.Time   `json:"decided_at"`
+}
+
+// OutcomeResult is what reporting a label did. A duplicate is a repeat of the
+// transaction's current label and changes nothing.
+type OutcomeResult struct {
+	Label      Label
+	Duplicate  bool
+	Denylisted []ListEntry
+}
+
+// NewLabeledDecision captures the decision r on tx.
+func NewLabeledDecision(tx Transaction, r RiskAssessment, at time.Time) LabeledDecision {
+	return LabeledDecision{
+		Transaction:     tx,
+		Decision:        r.Decision(),
+		Reason:          r.Reason,
+		ConfidenceScore: r.ConfidenceScore,
+		Route:           r.Route,
+		DecidedAt:       at,
+	}
+}
+
+func (l Label) Validate() error {
+	switch {
+	case l.TransactionID == "":
+		return fmt.Errorf("%w: transaction_id is required", ErrInvalidLabel)
+	case l.Label != LabelFraud && l.Label != LabelLegitimate:
+		return fmt.Errorf("%w: label must be %s or %s, got %q", ErrInvalidLabel, LabelFraud, LabelLegitimate, l.Label)
+	}
+	switch l.Source {
+	case SourceChargeback, SourceDispute, SourceReview, SourceOther:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown source %q", ErrInvalidLabel, l.Source)
+	}
+}
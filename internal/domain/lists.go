@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ListAllow = "allow"
+	ListDeny  = "deny"
+)
+
+const (
+	EntityMerchant = "merchant"
+	EntityUser     = "user"
+	EntityDevice   = "device"
+	EntityIP       = "ip"
+	EntityBIN      = "bin"
+	EntityCountry  = "country"
+)
+
+var (
+	ErrListEntryNotFound = errors.New("list entry not found")
+	ErrInvalidListEntry  = errors.New("invalid list entry")
+)
+
+// ListEntry is a managed allow/deny list item. For EntityIP the value may be a
+// single address or a CIDR range; for EntityBIN it is a card number prefix.
+type ListEntry struct {
+	// TenantID limits the entry to one tenant's transactions; empty applies
+	// it to every tenant.
+	TenantID  string    `json:"tenant_id,omitempty"`
+	List      string    `json:"list"`
+	Entity    string    `json:"entity"`
+	Value     string    `json:"value"`
+	Reason    string    `json:"reason"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+func (e ListEntry) Expired(at time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !at.Before(e.ExpiresAt)
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	RiskTierLow    = "low"
+	RiskTierMedium = "medium"
+	RiskTierHigh   = "high"
+)
+
+// Well-known merchant categories. The catalog may use others; these are the
+// ones policies key off.
+const (
+	CategoryCryptoExchange = "crypto_exchange"
+	CategoryP2PTransfer    = "p2p_transfer"
+	CategoryMoneyTransfer  = "money_transfer"
+	CategoryGambling       = "gambling"
+	CategoryGrocery        = "grocery"
+	CategoryRestaurant     = "restaurant"
+	CategoryRetail         = "retail"
+	CategoryElectronics    = "electronics"
+	CategoryDigitalGoods   = "digital_goods"
+	CategoryTravel         = "travel"
+)
+
+var (
+	ErrMerchantNotFound = errors.New("merchant not found")
+	ErrInvalidMerchant  = errors.New("invalid merchant")
+)
+
+// Merchant is a merchant catalog entry. Aliases are alternative descriptors
+// (e.g. "binance.com") that resolve to the same merchant.
+type Merchant struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	MCC      string   `json:"mcc,omitempty"`
+	Category string   `json:"category"`
+	RiskTier string   `json:"risk_tier"`
+	Aliases  []string `json:"aliases,omitempty"`
+}
+
+func (m Merchant) HighRisk() bool {
+	return m.RiskTier == RiskTierHigh
+}
+
+// Summary renders the merchant for the LLM prompt.
+func (m Merchant) Summary() string {
+	mcc := m.MCC
+	if mcc == "" {
+		mcc = "n/a"
+	}
+	return fmt.Sprintf("MERCHANT: %s (MCC %s, category %s, risk tier %s)", m.Name, mcc, m.Category, m.RiskTier)
+}
@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const DefaultBaseCurrency = "USD"
+
+var (
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrInvalidAmount       = errors.New("invalid amount")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
+)
+
+// currencyExponents lists ISO 4217 currencies whose minor unit is not 1/100.
+var currencyExponents = map[string]int{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+}
+
+// Money is an exact amount in the minor units (cents, kopiykas, ...) of an
+// ISO 4217 currency.
+type Money struct {
+	MinorUnits int64  `json:"minor_units"`
+	Currency   string `json:"currency"`
+}
+
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CurrencyExponent is the number of decimal places of the currency's minor unit.
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[NormalizeCurrency(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// ParseMoney parses a decimal amount such as "499.99" into Money. Digits
+// beyond the currency's minor unit are rounded half to even.
+func ParseMoney(amount, currency string) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	return fromRat(r, NormalizeCurrency(currency))
+}
+
+func MustParseMoney(amount, currency string) Money {
+	m, err := ParseMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// MoneyFromFloat converts a legacy floating point amount using its shortest
+// decimal representation, so 499.999999 becomes 500.00 rather than 499.99.
+func MoneyFromFloat(amount float64, currency string) (Money, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Money{}, fmt.Errorf("%w: %v", ErrInvalidAmount, amount)
+	}
+	return ParseMoney(strconv.FormatFloat(amount, 'f', -1, 64), currency)
+}
+
+func fromRat(r *big.Rat, currency string) (Money, error) {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(CurrencyExponent(currency))))
+	minor := roundHalfEven(scaled)
+	if !minor.IsInt64() {
+		return Money{}, fmt.Errorf("%w: amount out of range", ErrInvalidAmount)
+	}
+	return Money{MinorUnits: minor.Int64(), Currency: currency}, nil
+}
+
+// UnmarshalJSON also accepts a bare decimal number, the encoding used for
+// amounts before Money existed; such values carry no currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed != "" && (trimmed[0] == '-' || (trimmed[0] >= '0' && trimmed[0] <= '9')) {
+		parsed, err := ParseMoney(trimmed, "")
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+
+	type plain Money
+	return json.Unmarshal(data, (*plain)(m))
+}
+
+// Rat returns the amount in major units as an exact rational.
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.MinorUnits), pow10(CurrencyExponent(m.Currency)))
+}
+
+// Convert multiplies by rate (units of target per unit of m.Currency) and
+// rounds half to even to the target's minor unit.
+func (m Money) Convert(rate *big.Rat, target string) (Money, error) {
+	return fromRat(new(big.Rat).Mul(m.Rat(), rate), NormalizeCurrency(target))
+}
+
+// MulInt multiplies by n, saturating at the largest amount an int64 holds
+// instead of wrapping around.
+func (m Money) MulInt(n int64) Money {
+	return Money{MinorUnits: saturate(new(big.Int).Mul(big.NewInt(m.MinorUnits), big.NewInt(n))), Currency: m.Currency}
+}
+
+// Add adds o, which must be in the same currency, saturating like MulInt.
+func (m Money) Add(o Money) Money {
+	return Money{MinorUnits: saturate(new(big.Int).Add(big.NewInt(m.MinorUnits), big.NewInt(o.MinorUnits))), Currency: m.Currency}
+}
+
+func saturate(n *big.Int) int64 {
+	switch {
+	case n.IsInt64():
+		return n.Int64()
+	case n.Sign() > 0:
+		return math.MaxInt64
+	default:
+		return math.MinInt64
+	}
+}
+
+// DivInt divides by n rounding half to even; it is used for averages.
+func (m Money) DivInt(n int64) Money {
+	if n == 0 {
+		return Money{Currency: m.Currency}
+	}
+	q := roundHalfEven(new(big.Rat).SetFrac(big.NewInt(m.MinorUnits), big.NewInt(n)))
+	return Money{MinorUnits: q.Int64(), Currency: m.Currency}
+}
+
+// Cmp compares two amounts of the same currency and returns -1, 0 or +1.
+// Amounts in different currencies are not comparable and return
+// ErrCurrencyMismatch.
+func (m Money) Cmp(o Money) (int, error) {
+	if m.Currency != o.Currency {
+		return 0, fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	switch {
+	case m.MinorUnits < o.MinorUnits:
+		return -1, nil
+	case m.MinorUnits > o.MinorUnits:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) IsZero() bool     { return m.MinorUnits == 0 }
+func (m Money) IsNegative() bool { return m.MinorUnits < 0 }
+
+// Ratio returns m/o as a float for weights and display; never for decisions.
+func (m Money) Ratio(o Money) float64 {
+	if o.MinorUnits == 0 {
+		return 0
+	}
+	f, _ := new(big.Rat).Quo(m.Rat(), o.Rat()).Float64()
+	return f
+}
+
+// Decimal renders the amount in major units with the currency's precision.
+func (m Money) Decimal() string {
+	return m.Rat().FloatString(CurrencyExponent(m.Currency))
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Thresholds are the amount limits used by the prescreen and heuristic rules.
+// All of them are in the same currency.
+type Thresholds struct {
+	LowValue          Money `json:"low_value"`
+	HeuristicBlockMin Money `json:"heuristic_block_min"`
+	HighValue         Money `json:"high_value"`
+	PrescreenAllowMax Money `json:"prescreen_allow_max"`
+}
+
+// Convert re-expresses the thresholds in target currency using rate.
+func (t Thresholds) Convert(rate *big.Rat, target string) (Thresholds, error) {
+	var out Thresholds
+	pairs := []struct {
+		dst *Money
+		src Money
+	}{
+		{&out.LowValue, t.LowValue},
+		{&out.HeuristicBlockMin, t.HeuristicBlockMin},
+		{&out.HighValue, t.HighValue},
+		{&out.PrescreenAllowMax, t.PrescreenAllowMax},
+	}
+	for _, p := range pairs {
+		m, err := p.src.Convert(rate, target)
+		if err != nil {
+			return Thresholds{}, err
+		}
+		*p.dst = m
+	}
+	return out, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func roundHalfEven(r *big.Rat) *big.Int {
+	num, den := r.Num(), r.Denom()
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return q
+	}
+
+	twice := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+	switch twice.Cmp(den) {
+	case 1:
+		return awayFromZero(q, num)
+	case 0:
+		if q.Bit(0) == 1 {
+			return awayFromZero(q, num)
+		}
+	}
+	return q
+}
+
+func awayFromZero(q, num *big.Int) *big.Int {
+	if num.Sign() < 0 {
+		return q.Sub(q, big.NewInt(1))
+	}
+	return q.Add(q, big.NewInt(1))
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestMoneyFromFloat_Borderline(t *testing.T) {
+	low := MustParseMoney("500", "USD")
+
+	m, err := MoneyFromFloat(499.999999, "usd")
+	if err != nil {
+		t.Fatalf("MoneyFromFloat: %v", err)
+	}
+	if m.MinorUnits != 50000 || m.Currency != "USD" {
+		t.Errorf("Expected 500.00 USD, got %s", m)
+	}
+	if c, err := m.Cmp(low); err != nil || c != 0 {
+		t.Errorf("Expected %s to equal %s", m, low)
+	}
+}
+
+func TestParseMoney_RoundsHalfToEven(t *testing.T) {
+	cases := []struct {
+		in, currency, want string
+	}{
+		{"0.125", "USD", "0.12"},
+		{"0.135", "USD", "0.14"},
+		{"-0.125", "USD", "-0.12"},
+		{"1234.5", "JPY", "1234"},
+		{"1.0005", "KWD", "1.000"},
+	}
+	for _, c := range cases {
+		m, err := ParseMoney(c.in, c.currency)
+		if err != nil {
+			t.Fatalf("ParseMoney(%q): %v", c.in, err)
+		}
+		if got := m.Decimal(); got != c.want {
+			t.Errorf("ParseMoney(%q, %s) = %s, want %s", c.in, c.currency, got, c.want)
+		}
+	}
+}
+
+func TestMoney_ConvertIsExact(t *testing.T) {
+	// 0.1 + 0.2 style drift must not leak into conversions.
+	m := MustParseMoney("15000.10", "UAH")
+	got, err := m.Convert(big.NewRat(24, 1000), "USD")
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	if got.Decimal() != "360.00" {
+		t.Errorf("Expected 360.00 USD, got %s", got)
+	}
+}
+
+func TestMoney_UnmarshalLegacyNumber(t *testing.T) {
+	var m Money
+	if err := json.Unmarshal([]byte(`12.5`), &m); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if m.MinorUnits != 1250 || m.Currency != "" {
+		t.Errorf("Expected 1250 minor units without currency, got %+v", m)
+	}
+
+	if err := json.Unmarshal([]byte(`{"minor_units":700,"currency":"EUR"}`), &m); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if m.String() != "7.00 EUR" {
+		t.Errorf("Expected 7.00 EUR, got %s", m)
+	}
+}
+
+func TestMoney_CmpRejectsCurrencyMismatch(t *testing.T) {
+	if _, err := MustParseMoney("1", "USD").Cmp(MustParseMoney("1", "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+}
+
+func TestMoney_ArithmeticSaturates(t *testing.T) {
+	huge := Money{MinorUnits: math.MaxInt64 / 2, Currency: "USD"}
+
+	if got := huge.MulInt(10).MinorUnits; got != math.MaxInt64 {
+		t.Errorf("Expected MulInt to saturate at the maximum, got %d", got)
+	}
+	if got := huge.MulInt(-10).MinorUnits; got != math.MinInt64 {
+		t.Errorf("Expected MulInt to saturate at the minimum, got %d", got)
+	}
+	if got := huge.Add(huge).Add(huge).MinorUnits; got != math.MaxInt64 {
+		t.Errorf("Expected Add to saturate at the maximum, got %d", got)
+	}
+	if got := huge.MulInt(2).MinorUnits; got != math.MaxInt64-1 {
+		t.Errorf("Expected an exact product below the limit, got %d", got)
+	}
+}
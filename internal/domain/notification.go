@@ -0,0 +1,22 @@
+package domain
+
+// Sources of a composed push message.
+const (
+	MessageSourceLLM      = "llm"
+	MessageSourceTemplate = "template"
+)
+
+// Notification is the push message for a decision, as shown to the user.
+type Notification struct {
+	TenantID      string `json:"tenant_id"`
+	TransactionID string `json:"transaction_id"`
+	UserID        string `json:"user_id"`
+	Decision      string `json:"decision"`
+	Locale        string `json:"locale"`
+	Text          string `json:"text"`
+	// Source is llm when the model's message passed every policy, and
+	// template otherwise.
+	Source string `json:"source"`
+	// Rejected names the policy the model's message failed.
+	Rejected string `json:"rejected,omitempty"`
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Review case statuses. Pending and claimed cases are open.
+const (
+	ReviewPending  = "pending"
+	ReviewClaimed  = "claimed"
+	ReviewResolved = "resolved"
+)
+
+// Review outcomes an analyst can submit.
+const (
+	OutcomeApproved = "approved"
+	OutcomeFraud    = "fraud"
+)
+
+var (
+	ErrReviewCaseNotFound   = errors.New("review case not found")
+	ErrReviewCaseClaimed    = errors.New("review case claimed by another reviewer")
+	ErrReviewCaseResolved   = errors.New("review case already resolved")
+	ErrReviewCaseNotClaimed = errors.New("review case must be claimed before it is resolved")
+	ErrInvalidReviewOutcome = errors.New("invalid review outcome")
+	ErrNoReviewCase         = errors.New("no open review case")
+)
+
+// ReviewPolicy is how a tenant's review cases are handled. SLA is the time an
+// analyst has to resolve a case before it is escalated; CallbackURL, if set,
+// receives the outcome.
+type ReviewPolicy struct {
+	SLA         time.Duration
+	CallbackURL string
+}
+
+// ReviewCase is a decision marked for manual review, with the transaction as
+// it was analyzed and the verdict that sent it to review.
+type ReviewCase struct {
+	ID              string      `json:"id"`
+	TenantID        string      `json:"tenant_id"`
+	Transaction     Transaction `json:"transaction"`
+	Reason          string      `json:"reason"`
+	ConfidenceScore int         `json:"confidence_score"`
+	Route           string      `json:"route"`
+	RouteReason     string      `json:"route_reason"`
+	Explanation     Explanation `json:"explanation"`
+	Overrides       []Override  `json:"overrides,omitempty"`
+
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	// DueAt is CreatedAt plus the tenant's SLA. The case is escalated when it
+	// passes DueAt and again every SLA after that.
+	DueAt       time.Time `json:"due_at"`
+	Escalations int       `json:"escalations,omitempty"`
+	Assignee    string    `json:"assignee,omitempty"`
+	ClaimedAt   time.Time `json:"claimed_at,omitzero"`
+	Outcome     string    `json:"outcome,omitempty"`
+	Notes       string    `json:"notes,omitempty"`
+	ResolvedAt  time.Time `json:"resolved_at,omitzero"`
+
+	// CallbackURL receives the outcome once the case is resolved; NotifiedAt
+	// is set when it has accepted it.
+	CallbackURL      string    `json:"callback_url,omitempty"`
+	CallbackAttempts int       `json:"callback_attempts,omitempty"`
+	NotifiedAt       time.Time `json:"notified_at,omitzero"`
+}
+
+// ReviewFilter selects review cases. An empty Tenant matches every tenant
+// and an empty Status every open case.
+type ReviewFilter struct {
+	Tenant      string
+	Status      string
+	OverdueOnly bool
+	Limit       int
+}
+
+// NewReviewCase opens a case for a review decision on tx. The queue assigns
+// its ID.
+func NewReviewCase(tx Transaction, r RiskAssessment, policy ReviewPolicy, at time.Time) ReviewCase {
+	return ReviewCase{
+		TenantID:        tx.TenantID,
+		Transaction:     tx,
+		Reason:          r.Reason,
+		ConfidenceScore: r.ConfidenceScore,
+		Route:           r.Route,
+		RouteReason:     r.RouteReason,
+		Explanation:     r.Explanation,
+		Overrides:       r.Overrides,
+		Status:          ReviewPending,
+		CreatedAt:       at,
+		DueAt:           at.Add(policy.SLA),
+		CallbackURL:     policy.CallbackURL,
+	}
+}
+
+func (c ReviewCase) Open() bool {
+	return c.Status != ReviewResolved
+}
+
+func (c ReviewCase) Overdue(at time.Time) bool {
+	return c.Open() && !at.Before(c.DueAt)
+}
+
+// SLA is the time the case was given to be resolved.
+func (c ReviewCase) SLA() time.Duration {
+	return c.DueAt.Sub(c.CreatedAt)
+}
+
+// ValidOutcome reports whether outcome can resolve a case.
+func ValidOutcome(outcome string) bool {
+	return outcome == OutcomeApproved || outcome == OutcomeFraud
+}
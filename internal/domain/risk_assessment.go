@@ -1,8 +1,86 @@
 package domain
 
+import (
+	"errors"
+	"strings"
+)
+
+// ErrLLMOverloaded is returned when an LLM call could not get a slot before
+// the queue overflowed or timed out.
+var ErrLLMOverloaded = errors.New("llm overloaded")
+
+const (
+	DecisionAllow  = "allow"
+	DecisionBlock  = "block"
+	DecisionReview = "review"
+)
+
+const TagPendingReview = "[PENDING REVIEW]"
+
+const (
+	StageLLMPostprocess = "llm_postprocess"
+	StageAnalyzer       = "analyzer"
+)
+
+// Routes describe which stage produced the verdict.
+const (
+	RoutePrescreenAllow = "prescreen_allow"
+	RoutePrescreenBlock = "prescreen_block"
+	RouteLLM            = "llm"
+	// RouteDegraded means the LLM failed or was overloaded and the tenant's
+	// degradation policy decided.
+	RouteDegraded = "degraded"
+)
+
 type RiskAssessment struct {
-	IsBlocked       bool
-	ConfidenceScore int `json:"confidence_score"`
-	Reason          string
-	AIPushMessage   string
+	IsBlocked       bool   `json:"is_blocked"`
+	ConfidenceScore int    `json:"confidence_score"`
+	Reason          string `json:"reason"`
+	AIPushMessage   string `json:"ai_push_message"`
+	// AIPushLocale is the locale AIPushMessage is written in, once composed.
+	AIPushLocale string `json:"-"`
+
+	Route       string      `json:"-"`
+	RouteReason string      `json:"-"`
+	LLM         *LLMTrace   `json:"-"`
+	Overrides   []Override  `json:"-"`
+	Explanation Explanation `json:"-"`
+	// ReviewCaseID is the case opened for a review decision.
+	ReviewCaseID string `json:"-"`
+}
+
+// LLMTrace records what was sent to and received from the model for a verdict.
+type LLMTrace struct {
+	Model            string `json:"model"`
+	PromptVersion    string `json:"prompt_version"`
+	SystemPrompt     string `json:"system_prompt"`
+	UserPrompt       string `json:"user_prompt"`
+	RawResponse      string `json:"raw_response"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+}
+
+// Override is a deterministic adjustment applied on top of the model verdict.
+type Override struct {
+	Stage         string `json:"stage"`
+	Rule          string `json:"rule"`
+	BlockedBefore bool   `json:"blocked_before"`
+	BlockedAfter  bool   `json:"blocked_after"`
+	Reason        string `json:"reason"`
+}
+
+// ChangedOutcome reports whether the override flipped the block decision.
+func (o Override) ChangedOutcome() bool {
+	return o.BlockedBefore != o.BlockedAfter
+}
+
+func (r RiskAssessment) Decision() string {
+	switch {
+	case r.IsBlocked:
+		return DecisionBlock
+	case strings.Contains(r.Reason, TagPendingReview):
+		return DecisionReview
+	default:
+		return DecisionAllow
+	}
 }
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRiskAssessment_DecodesModelOutput(t *testing.T) {
+	var r RiskAssessment
+	content := `{"is_blocked": true, "confidence_score": 91, "reason": "Card used abroad", "ai_push_message": "We paused a payment abroad."}`
+	if err := json.Unmarshal([]byte(content), &r); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !r.IsBlocked || r.ConfidenceScore != 91 || r.Reason != "Card used abroad" || r.AIPushMessage != "We paused a payment abroad." {
+		t.Errorf("Expected every field of the prompt's output format, got %+v", r)
+	}
+}
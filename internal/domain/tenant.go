@@ -0,0 +1,8 @@
+package domain
+
+import "errors"
+
+// DefaultTenant serves requests that do not name a tenant.
+const DefaultTenant = "default"
+
+var ErrUnknownTenant = errors.New("unknown tenant")
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Transaction struct {
+	ID       string `json:"id"`
+	TenantID string `json:"tenant_id,omitempty"`
+	UserID   string `json:"user_id"`
+	// Amount is in the caller's currency; an empty currency means the base
+	// currency. Once the transaction is normalized Amount is in the base
+	// currency and OriginalAmount keeps the caller's value if it differed.
+	Amount         Money  `json:"amount"`
+	OriginalAmount Money  `json:"original_amount,omitzero"`
+	Merchant       string `json:"merchant"`
+	Location       string `json:"location"`
+	UserProfile    string `json:"user_profile"`
+	DeviceID       string `json:"device_id,omitempty"`
+	IPAddress      string `json:"ip_address,omitempty"`
+	CardBIN        string `json:"card_bin,omitempty"`
+	CountryCode    string `json:"country_code,omitempty"`
+	// Geo is where the transaction happened, if it could be resolved.
+	Geo *GeoPoint `json:"geo,omitempty"`
+	// MerchantInfo is the catalog entry the merchant descriptor matched.
+	MerchantInfo *Merchant `json:"merchant_info,omitempty"`
+}
+
+// Summary renders the transaction the way it is presented to the LLM.
+func (t Transaction) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Amount: %s", t.Amount.Decimal())
+	if t.Amount.Currency != "" {
+		fmt.Fprintf(&b, " %s", t.Amount.Currency)
+	}
+	if t.OriginalAmount.Currency != "" {
+		fmt.Fprintf(&b, " (original: %s)", t.OriginalAmount)
+	}
+	fmt.Fprintf(&b, ", Merchant: %s, Location: %s", t.Merchant, t.Location)
+	return b.String()
+}
@@ -0,0 +1,168 @@
+// Package health aggregates readiness checks and publishes them through the
+// standard gRPC health service and HTTP probe endpoints.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	DefaultPeriod = 5 * time.Second
+	checkTimeout  = 2 * time.Second
+)
+
+// Check returns an error while the dependency it covers is unusable.
+type Check func(ctx context.Context) error
+
+// Checker runs the readiness checks and reports the result as the serving
+// status of services on the gRPC health server.
+type Checker struct {
+	server   *health.Server
+	services []string
+
+	mu     sync.RWMutex
+	checks []namedCheck
+
+	stopping atomic.Bool
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+	// info checks are reported but do not affect readiness.
+	info bool
+}
+
+// Report is the outcome of one round of checks. Checks maps each readiness
+// check to "ok" or its error, and Info does the same for informational ones.
+type Report struct {
+	Ready  bool              `json:"ready"`
+	Checks map[string]string `json:"checks"`
+	Info   map[string]string `json:"info,omitempty"`
+}
+
+// NewChecker publishes readiness for services, and for the overall server
+// (the empty service name), on server.
+func NewChecker(server *health.Server, services ...string) *Checker {
+	c := &Checker{server: server, services: append([]string{""}, services...)}
+	c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// AddInfo adds a check whose result is reported but never makes the server
+// unready, for conditions that the server works around on its own.
+func (c *Checker) AddInfo(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check, info: true})
+}
+
+// Check runs every check. A stopping server is never ready.
+func (c *Checker) Check(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	c.mu.RLock()
+	checks := c.checks
+	c.mu.RUnlock()
+
+	r := Report{Ready: !c.stopping.Load(), Checks: make(map[string]string, len(checks))}
+	if !r.Ready {
+		r.Checks["server"] = "shutting down"
+	}
+	for _, nc := range checks {
+		results := r.Checks
+		if nc.info {
+			if r.Info == nil {
+				r.Info = make(map[string]string)
+			}
+			results = r.Info
+		}
+		if err := nc.check(ctx); err != nil {
+			r.Ready = r.Ready && nc.info
+			results[nc.name] = err.Error()
+			continue
+		}
+		results[nc.name] = "ok"
+	}
+	return r
+}
+
+// Run re-evaluates readiness every period until ctx is done.
+func (c *Checker) Run(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	wasReady := false
+	for {
+		r := c.Check(ctx)
+		if r.Ready != wasReady {
+			slog.Info("readiness changed", "ready", r.Ready, "checks", r.Checks)
+			wasReady = r.Ready
+		}
+		if r.Ready {
+			c.setStatus(healthpb.HealthCheckResponse_SERVING)
+		} else {
+			c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Shutdown reports every service as not serving from now on, so that load
+// balancers stop routing before the server drains.
+func (c *Checker) Shutdown() {
+	c.stopping.Store(true)
+	c.server.Shutdown()
+}
+
+func (c *Checker) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	if c.stopping.Load() {
+		return
+	}
+	for _, s := range c.services {
+		c.server.SetServingStatus(s, status)
+	}
+}
+
+// LivenessHandler answers 200 while the process is able to serve HTTP.
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok\n"))
+	})
+}
+
+// ReadinessHandler runs the checks and answers 200 when ready and 503
+// otherwise, with the report as JSON.
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Check(r.Context())
+
+		w.Header().Set("Content-Type", "application/json")
+		if !report.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
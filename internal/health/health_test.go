@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestChecker_ReadinessFollowsChecks(t *testing.T) {
+	srv := health.NewServer()
+	c := NewChecker(srv, "riskengine.RiskEngineService")
+
+	var promptErr error
+	c.Add("prompts", func(context.Context) error { return promptErr })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	promptErr = errors.New("prompt not loaded")
+	c.Run(ctx, DefaultPeriod)
+	assertStatus(t, srv, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	rec := httptest.NewRecorder()
+	c.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 while a check fails, got %d", rec.Code)
+	}
+
+	promptErr = nil
+	c.Run(ctx, DefaultPeriod)
+	assertStatus(t, srv, healthpb.HealthCheckResponse_SERVING)
+
+	c.Shutdown()
+	assertStatus(t, srv, healthpb.HealthCheckResponse_NOT_SERVING)
+	if c.Check(context.Background()).Ready {
+		t.Error("Expected a stopping server not to be ready")
+	}
+}
+
+func TestChecker_InfoChecksDoNotGateReadiness(t *testing.T) {
+	srv := health.NewServer()
+	c := NewChecker(srv, "riskengine.RiskEngineService")
+	c.Add("prompts", func(context.Context) error { return nil })
+	c.AddInfo("llm_provider", func(context.Context) error { return errors.New("llm provider circuit open for tenants acme") })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Run(ctx, DefaultPeriod)
+	assertStatus(t, srv, healthpb.HealthCheckResponse_SERVING)
+
+	r := c.Check(context.Background())
+	if !r.Ready || r.Info["llm_provider"] == "" || r.Info["llm_provider"] == "ok" {
+		t.Errorf("Expected a ready report that shows the open circuit, got %+v", r)
+	}
+	if _, ok := r.Checks["llm_provider"]; ok {
+		t.Errorf("Expected the circuit among the informational results only, got %+v", r.Checks)
+	}
+}
+
+func assertStatus(t *testing.T, srv *health.Server, want healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+
+	for _, service := range []string{"", "riskengine.RiskEngineService"} {
+		resp, err := srv.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q): %v", service, err)
+		}
+		if resp.Status != want {
+			t.Errorf("Expected %q to be %s, got %s", service, want, resp.Status)
+		}
+	}
+}
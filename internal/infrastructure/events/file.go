@@ -0,0 +1,38 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileSink appends each event as a line to a local JSON Lines file.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open events file: %w", err)
+	}
+	return &FileSink{file: f}, nil
+}
+
+func (s *FileSink) Name() string { return "file" }
+
+func (s *FileSink) Send(_ context.Context, m Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.file.Write(append(m.Payload, '\n'))
+	return err
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
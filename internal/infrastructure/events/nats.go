@@ -0,0 +1,157 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NATSSink publishes each event to a subject on a NATS server, speaking the
+// core client protocol over plain TCP. Every publish is followed by a PING
+// and counts as delivered once the server answers PONG, so the server has
+// accepted it. Streams capturing the subject persist the events.
+type NATSSink struct {
+	addr    string
+	subject string
+	connect []byte
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewNATSSink parses a nats://[user:password@|token@]host:port URL.
+func NewNATSSink(rawURL, subject string, timeout time.Duration) (*NATSSink, error) {
+	u, err := url.Parse(rawURL)
+	// The URL may carry credentials, so it is left out of the error.
+	if err != nil || u.Scheme != "nats" || u.Host == "" {
+		return nil, errors.New("invalid nats url: expected nats://[user:password@|token@]host:port")
+	}
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return nil, fmt.Errorf("invalid nats subject %q", subject)
+	}
+
+	opts := map[string]any{"verbose": false, "pedantic": false, "name": "ai-risk-engine", "lang": "go", "version": "1"}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			opts["user"], opts["pass"] = u.User.Username(), pass
+		} else {
+			opts["auth_token"] = u.User.Username()
+		}
+	}
+	connect, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	return &NATSSink{addr: addr, subject: subject, connect: connect, timeout: timeout}, nil
+}
+
+func (s *NATSSink) Name() string { return "nats" }
+
+func (s *NATSSink) Send(ctx context.Context, m Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.dial(ctx); err != nil {
+		return err
+	}
+	err := s.publish(m.Payload)
+	if err != nil {
+		s.reset()
+	}
+	return err
+}
+
+// dial connects and authenticates unless already connected. Callers must hold
+// s.mu.
+func (s *NATSSink) dial(ctx context.Context) error {
+	if s.conn != nil {
+		return nil
+	}
+
+	d := net.Dialer{Timeout: s.timeout}
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to nats: %w", err)
+	}
+	s.conn, s.r = conn, bufio.NewReader(conn)
+
+	_ = conn.SetDeadline(time.Now().Add(s.timeout))
+	line, err := s.r.ReadString('\n')
+	if err == nil && !strings.HasPrefix(line, "INFO ") {
+		err = fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	}
+	if err == nil {
+		_, err = fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", s.connect)
+	}
+	if err == nil {
+		err = s.awaitPong()
+	}
+	if err != nil {
+		s.reset()
+		return fmt.Errorf("nats handshake failed: %w", err)
+	}
+	return nil
+}
+
+func (s *NATSSink) publish(payload []byte) error {
+	_ = s.conn.SetDeadline(time.Now().Add(s.timeout))
+	msg := fmt.Appendf(nil, "PUB %s %d\r\n", s.subject, len(payload))
+	msg = append(msg, payload...)
+	msg = append(msg, "\r\nPING\r\n"...)
+	if _, err := s.conn.Write(msg); err != nil {
+		return err
+	}
+	return s.awaitPong()
+}
+
+// awaitPong reads until the server answers the last PING, answering the
+// server's own PINGs and failing on -ERR.
+func (s *NATSSink) awaitPong() error {
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := s.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("nats: " + strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+// reset drops the connection so the next Send reconnects. Callers must hold
+// s.mu.
+func (s *NATSSink) reset() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.conn, s.r = nil, nil
+}
+
+func (s *NATSSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reset()
+	return nil
+}
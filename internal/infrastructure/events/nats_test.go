@@ -0,0 +1,75 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeNATS accepts one connection, answers the handshake and every PING, and
+// sends each published payload to pubs.
+func fakeNATS(t *testing.T) (url string, connects <-chan string, pubs <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	connCh, pubCh := make(chan string, 1), make(chan string, 10)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		conn.Write([]byte("INFO {\"server_id\":\"fake\"}\r\n"))
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch line = strings.TrimSpace(line); {
+			case strings.HasPrefix(line, "CONNECT "):
+				connCh <- strings.TrimPrefix(line, "CONNECT ")
+			case line == "PING":
+				conn.Write([]byte("PONG\r\n"))
+			case strings.HasPrefix(line, "PUB "):
+				payload, _ := r.ReadString('\n')
+				pubCh <- line + " " + strings.TrimSpace(payload)
+			}
+		}
+	}()
+	return "nats://tok@" + ln.Addr().String(), connCh, pubCh
+}
+
+func TestNATSSink_Publishes(t *testing.T) {
+	url, connects, pubs := fakeNATS(t)
+	s, err := NewNATSSink(url, "risk.decisions", time.Second)
+	if err != nil {
+		t.Fatalf("NewNATSSink: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.Send(context.Background(), Message{ID: "evt_1", Payload: []byte(`{"id":"evt_1"}`)}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if c := <-connects; !strings.Contains(c, `"auth_token":"tok"`) {
+		t.Errorf("Expected the token from the URL in CONNECT, got %s", c)
+	}
+	if p := <-pubs; p != `PUB risk.decisions 14 {"id":"evt_1"}` {
+		t.Errorf("Unexpected publish %q", p)
+	}
+}
+
+func TestNewNATSSink_RejectsBadURL(t *testing.T) {
+	for _, u := range []string{"http://localhost:4222", "nats://", "::"} {
+		if _, err := NewNATSSink(u, "s", time.Second); err == nil {
+			t.Errorf("Expected %q to be rejected", u)
+		}
+	}
+}
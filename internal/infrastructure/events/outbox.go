@@ -0,0 +1,466 @@
+// Package events publishes decision events to downstream systems through a
+// durable outbox.
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+	"github.com/tokyosplif/ai-risk-engine/pkg/jsonl"
+)
+
+const (
+	// legacyOutboxFile is the single file older versions kept; it is taken
+	// over as the first segment.
+	legacyOutboxFile = "outbox.jsonl"
+	segmentFormat    = "outbox-%020d.jsonl"
+	segmentBytes     = 16 << 20
+	maxBatch         = 500
+	minBackoff       = time.Second
+	maxBackoff       = time.Minute
+	maxEventSize     = 1 << 20
+)
+
+var ErrOutboxFull = errors.New("event outbox full")
+
+// Message is an encoded event as handed to a sink.
+type Message struct {
+	ID      string
+	Payload []byte
+}
+
+// Sink delivers events to one downstream system. Send is retried until it
+// succeeds, so a sink may see an event more than once.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, m Message) error
+}
+
+// Outbox appends events to local JSON Lines segments and delivers them to
+// each sink in order, keeping a per-sink offset next to them. Offsets count
+// bytes across segments and only grow. Events written while a sink is down
+// are delivered when it comes back, including across restarts. The active
+// segment is rotated once it reaches segmentBytes, and a segment is deleted
+// once the slowest sink has passed it, so the disk holds the undelivered
+// backlog plus at most one segment.
+type Outbox struct {
+	dir          string
+	maxBytes     int64
+	segmentBytes int64
+
+	mu       sync.Mutex
+	segments []*segment
+	cursors  []*cursor
+}
+
+// segment is one file of the outbox holding the bytes from base on. The last
+// segment is the one written to.
+type segment struct {
+	base int64
+	size int64
+	file *os.File
+}
+
+func (s *segment) end() int64 { return s.base + s.size }
+
+type cursor struct {
+	sink   Sink
+	path   string
+	offset int64
+	wake   chan struct{}
+}
+
+// NewOutbox opens the outbox in dir for sinks. maxBytes bounds the
+// undelivered backlog; zero leaves it unbounded.
+func NewOutbox(dir string, maxBytes int64, sinks ...Sink) (*Outbox, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create outbox dir: %w", err)
+	}
+	o := &Outbox{dir: dir, maxBytes: maxBytes, segmentBytes: segmentBytes}
+	if err := o.openSegments(); err != nil {
+		o.closeSegments()
+		return nil, err
+	}
+
+	first, end := o.segments[0].base, o.end()
+	for _, s := range sinks {
+		c := &cursor{sink: s, path: filepath.Join(dir, s.Name()+".offset"), wake: make(chan struct{}, 1)}
+		offset, err := readOffset(c.path)
+		if err != nil {
+			o.closeSegments()
+			return nil, err
+		}
+		// An offset outside the segments belongs to a new sink or to an
+		// outbox that was wiped; start from the oldest event kept rather
+		// than skip events.
+		if offset < first || offset > end {
+			offset = first
+		}
+		c.offset = offset
+		o.cursors = append(o.cursors, c)
+		metrics.EventsBacklog.WithLabelValues(s.Name()).Set(float64(end - c.offset))
+	}
+	o.compact()
+	return o, nil
+}
+
+// openSegments opens the segments in dir, oldest first, creating the first
+// one if there are none, and drops a last event left incomplete by a crash.
+func (o *Outbox) openSegments() error {
+	if err := o.adoptLegacy(); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(o.dir)
+	if err != nil {
+		return fmt.Errorf("failed to list outbox: %w", err)
+	}
+	var bases []int64
+	for _, e := range entries {
+		var base int64
+		if n, _ := fmt.Sscanf(e.Name(), segmentFormat, &base); n == 1 && e.Name() == segmentName(base) {
+			bases = append(bases, base)
+		}
+	}
+	slices.Sort(bases)
+	if len(bases) == 0 {
+		bases = []int64{0}
+	}
+
+	for i, base := range bases {
+		flag := os.O_RDONLY
+		if i == len(bases)-1 {
+			flag = os.O_CREATE | os.O_RDWR | os.O_APPEND
+		}
+		f, err := os.OpenFile(filepath.Join(o.dir, segmentName(base)), flag, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open outbox: %w", err)
+		}
+		seg := &segment{base: base, file: f}
+		o.segments = append(o.segments, seg)
+
+		if i < len(bases)-1 {
+			info, err := f.Stat()
+			if err != nil {
+				return fmt.Errorf("failed to open outbox: %w", err)
+			}
+			seg.size = info.Size()
+			continue
+		}
+		size, dropped, err := jsonl.TrimPartial(f)
+		if err != nil {
+			return err
+		}
+		if dropped > 0 {
+			slog.Warn("dropping incomplete event at the end of the outbox", "bytes", dropped)
+		}
+		seg.size = size
+	}
+	return nil
+}
+
+// adoptLegacy renames the single outbox file of older versions to the first
+// segment, so that its events and the sinks' offsets into it carry over.
+func (o *Outbox) adoptLegacy() error {
+	legacy := filepath.Join(o.dir, legacyOutboxFile)
+	if _, err := os.Stat(legacy); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err := os.Rename(legacy, filepath.Join(o.dir, segmentName(0))); err != nil {
+		return fmt.Errorf("failed to migrate outbox: %w", err)
+	}
+	return nil
+}
+
+func segmentName(base int64) string {
+	return fmt.Sprintf(segmentFormat, base)
+}
+
+func readOffset(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read outbox offset: %w", err)
+	}
+	n, err := strconv.ParseInt(string(bytes.TrimSpace(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid outbox offset in %s: %w", path, err)
+	}
+	return n, nil
+}
+
+func writeOffset(path string, n int64) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatInt(n, 10)), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Publish assigns the event an ID, unless it has one, and queues it for every
+// sink. It fails only if the event cannot be stored.
+func (o *Outbox) Publish(_ context.Context, e domain.DecisionEvent) error {
+	if e.ID == "" {
+		e.ID = newID()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if len(data) > maxEventSize {
+		return fmt.Errorf("event %s is %d bytes, over the %d byte limit", e.ID, len(data), maxEventSize)
+	}
+	data = append(data, '\n')
+
+	o.mu.Lock()
+	end := o.end()
+	if backlog := end - o.minOffset(); o.maxBytes > 0 && backlog+int64(len(data)) > o.maxBytes {
+		o.mu.Unlock()
+		metrics.EventsDropped.Inc()
+		return fmt.Errorf("%w: %d bytes undelivered", ErrOutboxFull, backlog)
+	}
+	if err := o.write(data); err != nil {
+		o.mu.Unlock()
+		return err
+	}
+	for _, c := range o.cursors {
+		metrics.EventsBacklog.WithLabelValues(c.sink.Name()).Set(float64(end + int64(len(data)) - c.offset))
+	}
+	o.mu.Unlock()
+
+	for _, c := range o.cursors {
+		select {
+		case c.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// write appends data to the active segment, rotating it first once it is
+// full. A failed write is cut off so that the next event starts on a line of
+// its own. Callers must hold o.mu.
+func (o *Outbox) write(data []byte) error {
+	active := o.segments[len(o.segments)-1]
+	if active.size >= o.segmentBytes {
+		next, err := o.rotate(active)
+		if err != nil {
+			return fmt.Errorf("failed to rotate outbox: %w", err)
+		}
+		active = next
+	}
+
+	n, err := active.file.Write(data)
+	if err != nil {
+		if n > 0 {
+			_ = active.file.Truncate(active.size)
+		}
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	active.size += int64(n)
+	return nil
+}
+
+// rotate starts a new segment after active. Callers must hold o.mu.
+func (o *Outbox) rotate(active *segment) (*segment, error) {
+	if err := active.file.Sync(); err != nil {
+		return nil, err
+	}
+	base := active.end()
+	f, err := os.OpenFile(filepath.Join(o.dir, segmentName(base)), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	next := &segment{base: base, file: f}
+	o.segments = append(o.segments, next)
+	return next, nil
+}
+
+// end is the offset just past the last event. Callers must hold o.mu.
+func (o *Outbox) end() int64 {
+	return o.segments[len(o.segments)-1].end()
+}
+
+// minOffset is how far every sink has got. Callers must hold o.mu.
+func (o *Outbox) minOffset() int64 {
+	least := o.end()
+	for _, c := range o.cursors {
+		least = min(least, c.offset)
+	}
+	return least
+}
+
+// segmentAt returns the segment holding offset. Callers must hold o.mu.
+func (o *Outbox) segmentAt(offset int64) *segment {
+	i, _ := slices.BinarySearchFunc(o.segments, offset, func(s *segment, offset int64) int {
+		return cmp.Compare(s.end(), offset)
+	})
+	// A segment ending exactly at offset is behind it unless it is the
+	// last one.
+	for i < len(o.segments)-1 && o.segments[i].end() <= offset {
+		i++
+	}
+	return o.segments[min(i, len(o.segments)-1)]
+}
+
+// Run delivers events to every sink until ctx is cancelled.
+func (o *Outbox) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, c := range o.cursors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.deliverLoop(ctx, c)
+		}()
+	}
+	wg.Wait()
+}
+
+func (o *Outbox) deliverLoop(ctx context.Context, c *cursor) {
+	backoff := minBackoff
+	for {
+		more, err := o.deliver(ctx, c)
+		var wait <-chan time.Time
+		switch {
+		case err != nil:
+			slog.Warn("event delivery failed", "sink", c.sink.Name(), "retry_in", backoff, "err", err)
+			wait = time.After(backoff)
+			backoff = min(2*backoff, maxBackoff)
+		case more:
+			backoff = minBackoff
+			continue
+		default:
+			backoff = minBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.wake:
+		case <-wait:
+		}
+	}
+}
+
+// deliver sends the next batch of events to c's sink and reports whether
+// more are waiting. A batch stops at the end of a segment.
+func (o *Outbox) deliver(ctx context.Context, c *cursor) (more bool, err error) {
+	o.mu.Lock()
+	start, last := c.offset, o.end()
+	seg := o.segmentAt(start)
+	end := seg.end()
+	o.mu.Unlock()
+	if start >= last {
+		return false, nil
+	}
+
+	offset := start
+	defer func() {
+		if offset == start {
+			return
+		}
+		if werr := writeOffset(c.path, offset); werr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to save outbox offset: %w", werr))
+		}
+		o.advance(c, offset)
+	}()
+
+	r := bufio.NewReader(io.NewSectionReader(seg.file, start-seg.base, end-start))
+	for n := 0; n < maxBatch; n++ {
+		line, rerr := r.ReadBytes('\n')
+		if errors.Is(rerr, io.EOF) {
+			return offset < last, nil
+		}
+		if rerr != nil {
+			return false, fmt.Errorf("failed to read outbox: %w", rerr)
+		}
+
+		var head struct {
+			ID string `json:"id"`
+		}
+		if jerr := json.Unmarshal(line, &head); jerr != nil {
+			slog.Error("skipping unreadable event in outbox", "sink", c.sink.Name(), "offset", offset, "err", jerr)
+			offset += int64(len(line))
+			continue
+		}
+		if serr := c.sink.Send(ctx, Message{ID: head.ID, Payload: bytes.TrimSuffix(line, []byte("\n"))}); serr != nil {
+			metrics.EventsPublished.WithLabelValues(c.sink.Name(), "error").Inc()
+			return false, serr
+		}
+		metrics.EventsPublished.WithLabelValues(c.sink.Name(), "ok").Inc()
+		offset += int64(len(line))
+	}
+	return offset < last, nil
+}
+
+// advance records that c has delivered up to offset and deletes the
+// segments every sink has passed.
+func (o *Outbox) advance(c *cursor, offset int64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	c.offset = offset
+	metrics.EventsBacklog.WithLabelValues(c.sink.Name()).Set(float64(o.end() - c.offset))
+	o.compact()
+}
+
+// compact deletes the segments before the slowest sink's offset, keeping the
+// active one. Callers must hold o.mu, or own o.
+func (o *Outbox) compact() {
+	least := o.minOffset()
+	for len(o.segments) > 1 && o.segments[0].end() <= least {
+		seg := o.segments[0]
+		_ = seg.file.Close()
+		if err := os.Remove(seg.file.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			slog.Error("failed to delete delivered outbox segment", "path", seg.file.Name(), "err", err)
+			return
+		}
+		o.segments = o.segments[1:]
+	}
+}
+
+// Close closes the outbox and every sink that is an io.Closer.
+func (o *Outbox) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	errs := []error{o.closeSegments()}
+	for _, c := range o.cursors {
+		if cl, ok := c.sink.(io.Closer); ok {
+			errs = append(errs, cl.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (o *Outbox) closeSegments() error {
+	var errs []error
+	for _, seg := range o.segments {
+		errs = append(errs, seg.file.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "evt_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,270 @@
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+type recordingSink struct {
+	mu   sync.Mutex
+	down bool
+	ids  []string
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Send(_ context.Context, m Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.down {
+		return errors.New("sink down")
+	}
+	s.ids = append(s.ids, m.ID)
+	return nil
+}
+
+func (s *recordingSink) delivered() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ids...)
+}
+
+func publish(t *testing.T, o *Outbox, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		if err := o.Publish(context.Background(), domain.DecisionEvent{ID: id, Type: domain.EventTypeDecision}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+}
+
+func TestOutbox_KeepsEventsWhileSinkIsDownAcrossRestarts(t *testing.T) {
+	dir := t.TempDir()
+	sink := &recordingSink{down: true}
+
+	o, err := NewOutbox(dir, 0, sink)
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	publish(t, o, "evt_1", "evt_2")
+	if more, err := o.deliver(context.Background(), o.cursors[0]); err == nil || more {
+		t.Fatalf("Expected delivery to fail while the sink is down, got more=%v err=%v", more, err)
+	}
+	if err := o.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	sink.down = false
+	o, err = NewOutbox(dir, 0, sink)
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	defer o.Close()
+	publish(t, o, "evt_3")
+	if _, err := o.deliver(context.Background(), o.cursors[0]); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+
+	if got := strings.Join(sink.delivered(), ","); got != "evt_1,evt_2,evt_3" {
+		t.Errorf("Expected every event in order, got %s", got)
+	}
+}
+
+func drain(t *testing.T, o *Outbox, c *cursor) {
+	t.Helper()
+	for {
+		more, err := o.deliver(context.Background(), c)
+		if err != nil {
+			t.Fatalf("deliver: %v", err)
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	names, err := filepath.Glob(filepath.Join(dir, "outbox-*.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return names
+}
+
+func TestOutbox_DeletesSegmentsOnceEverySinkHasPassed(t *testing.T) {
+	dir := t.TempDir()
+	up := &recordingSink{}
+	lagging := &namedSink{recordingSink{down: true}, "lagging"}
+
+	o, err := NewOutbox(dir, 0, up, lagging)
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	o.segmentBytes = 1
+	publish(t, o, "evt_1", "evt_2", "evt_3")
+	drain(t, o, o.cursors[0])
+
+	if got := strings.Join(up.delivered(), ","); got != "evt_1,evt_2,evt_3" {
+		t.Errorf("Expected the healthy sink to read across segments in order, got %s", got)
+	}
+	if got := segmentFiles(t, dir); len(got) != 3 {
+		t.Errorf("Expected every segment to be kept for the lagging sink, got %v", got)
+	}
+
+	lagging.down = false
+	drain(t, o, o.cursors[1])
+	if got := segmentFiles(t, dir); len(got) != 1 {
+		t.Errorf("Expected only the active segment to be left, got %v", got)
+	}
+	if err := o.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	o, err = NewOutbox(dir, 0, up, lagging)
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	defer o.Close()
+	publish(t, o, "evt_4")
+	drain(t, o, o.cursors[0])
+	drain(t, o, o.cursors[1])
+	if got := strings.Join(lagging.delivered(), ","); got != "evt_1,evt_2,evt_3,evt_4" {
+		t.Errorf("Expected each event once after a restart, got %s", got)
+	}
+}
+
+func TestOutbox_TracksEachSinkSeparately(t *testing.T) {
+	dir := t.TempDir()
+	up := &recordingSink{}
+	down := &namedSink{recordingSink{down: true}, "down"}
+
+	o, err := NewOutbox(dir, 0, up, down)
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	defer o.Close()
+	publish(t, o, "evt_1")
+	if _, err := o.deliver(context.Background(), o.cursors[0]); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+
+	if len(up.delivered()) != 1 {
+		t.Errorf("Expected the healthy sink to receive the event, got %v", up.delivered())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, segmentName(0)))
+	if err != nil || len(data) == 0 {
+		t.Errorf("Expected the event to stay in the outbox for the failing sink, got %q %v", data, err)
+	}
+	if off, _ := readOffset(filepath.Join(dir, "recording.offset")); off != int64(len(data)) {
+		t.Errorf("Expected the healthy sink's offset to be saved, got %d", off)
+	}
+}
+
+type namedSink struct {
+	recordingSink
+	name string
+}
+
+func (s *namedSink) Name() string { return s.name }
+
+func TestOutbox_RejectsEventsOverTheBacklogLimit(t *testing.T) {
+	o, err := NewOutbox(t.TempDir(), 2000, &recordingSink{down: true})
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	defer o.Close()
+
+	publish(t, o, "evt_1")
+	err = o.Publish(context.Background(), domain.DecisionEvent{ID: "evt_2", TenantID: strings.Repeat("x", 2000)})
+	if !errors.Is(err, ErrOutboxFull) {
+		t.Errorf("Expected ErrOutboxFull, got %v", err)
+	}
+}
+
+func TestOutbox_AdoptsLegacyFileAndDropsIncompleteLastLine(t *testing.T) {
+	dir := t.TempDir()
+	line := `{"id":"evt_1"}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, legacyOutboxFile), []byte(line+`{"id":"evt_`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &recordingSink{}
+	o, err := NewOutbox(dir, 0, sink)
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	defer o.Close()
+	if _, err := o.deliver(context.Background(), o.cursors[0]); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if got := sink.delivered(); len(got) != 1 || got[0] != "evt_1" {
+		t.Errorf("Expected only the complete event, got %v", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, legacyOutboxFile)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected the legacy outbox to become the first segment, got %v", err)
+	}
+}
+
+func TestWebhookSink_SignsPayload(t *testing.T) {
+	secret := []byte("s3cret")
+	var got struct {
+		sig, key string
+		body     []byte
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.sig, got.key = r.Header.Get(SignatureHeader), r.Header.Get("Idempotency-Key")
+		got.body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	payload, _ := json.Marshal(map[string]string{"id": "evt_1"})
+	if err := NewWebhookSink(srv.URL, secret, time.Second).Send(context.Background(), Message{ID: "evt_1", Payload: payload}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if got.key != "evt_1" {
+		t.Errorf("Expected the event ID as idempotency key, got %q", got.key)
+	}
+	ts, sig, ok := strings.Cut(got.sig, ",v1=")
+	if !ok || !strings.HasPrefix(ts, "t=") {
+		t.Fatalf("Expected t=<unix>,v1=<hex>, got %q", got.sig)
+	}
+	if _, err := strconv.ParseInt(ts[2:], 10, 64); err != nil {
+		t.Errorf("Expected a unix timestamp, got %q", ts)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts[2:] + "."))
+	mac.Write(got.body)
+	if want := hex.EncodeToString(mac.Sum(nil)); sig != want {
+		t.Errorf("Expected signature %s, got %s", want, sig)
+	}
+}
+
+func TestWebhookSink_FailsOnErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	if err := NewWebhookSink(srv.URL, []byte("k"), time.Second).Send(context.Background(), Message{ID: "evt_1", Payload: []byte("{}")}); err == nil {
+		t.Error("Expected a 503 to fail delivery")
+	}
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SignatureHeader carries the webhook signature: "t=<unix seconds>,v1=<hex
+// HMAC-SHA256 of "<t>.<body>" keyed with the shared secret>". Receivers
+// should recompute it and reject stale timestamps.
+const SignatureHeader = "X-Risk-Signature"
+
+// WebhookSink posts each event as JSON to a URL, signed with a shared secret.
+// Any 2xx response counts as delivered.
+type WebhookSink struct {
+	url    string
+	secret []byte
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewWebhookSink(url string, secret []byte, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{url: url, secret: secret, client: &http.Client{Timeout: timeout}, now: time.Now}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+func (s *WebhookSink) Send(ctx context.Context, m Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(m.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", m.ID)
+	req.Header.Set(SignatureHeader, Sign(s.secret, s.now(), m.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for body sent at t.
+func Sign(secret []byte, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,273 @@
+package features
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+const (
+	retention          = 24 * time.Hour
+	labelRetention     = 180 * 24 * time.Hour
+	maxEventsPerUser   = 1000
+	DefaultSweepPeriod = 5 * time.Minute
+)
+
+type event struct {
+	at       time.Time
+	amount   domain.Money
+	merchant string
+	country  string
+	geo      *domain.GeoPoint
+}
+
+type history struct {
+	events []event
+	lastAt time.Time
+}
+
+type outcome struct {
+	label string
+	at    time.Time
+}
+
+// MemoryStore keeps a per-user window of recent transactions in process memory,
+// together with the outcome labels reported on the user's transactions.
+type MemoryStore struct {
+	mu       sync.Mutex
+	users    map[string]*history
+	outcomes map[string]map[string]outcome
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:    make(map[string]*history),
+		outcomes: make(map[string]map[string]outcome),
+	}
+}
+
+func (s *MemoryStore) Features(_ context.Context, tx domain.Transaction, at time.Time) (domain.UserFeatures, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.features(tx, at), nil
+}
+
+func (s *MemoryStore) Observe(_ context.Context, tx domain.Transaction, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observe(tx, at)
+	return nil
+}
+
+// ObserveAndFeatures returns the user's features before tx and records tx,
+// in one step, so that concurrent transactions of a user each see the ones
+// recorded before them.
+func (s *MemoryStore) ObserveAndFeatures(_ context.Context, tx domain.Transaction, at time.Time) (domain.UserFeatures, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f := s.features(tx, at)
+	s.observe(tx, at)
+	return f, nil
+}
+
+func (s *MemoryStore) features(tx domain.Transaction, at time.Time) domain.UserFeatures {
+	var f domain.UserFeatures
+	for _, o := range s.outcomes[userKey(tx)] {
+		if at.Sub(o.at) > labelRetention {
+			continue
+		}
+		switch o.label {
+		case domain.LabelFraud:
+			f.ConfirmedFraud++
+			if o.at.After(f.LastFraudAt) {
+				f.LastFraudAt = o.at
+			}
+		case domain.LabelLegitimate:
+			f.ConfirmedLegit++
+		}
+	}
+
+	h, ok := s.users[userKey(tx)]
+	if !ok || h.lastAt.IsZero() {
+		return f
+	}
+
+	zero := domain.Money{Currency: tx.Amount.Currency}
+	f.HasHistory = true
+	f.SinceLastTx = max(at.Sub(h.lastAt), 0)
+	f.AmountSum1m = zero
+	f.AmountSum1h = zero
+	f.AmountSum24h = zero
+	f.MaxAmount24h = zero
+	f.AvgAmount24h = zero
+
+	merchants := make(map[string]struct{})
+	countries := make(map[string]struct{})
+	merchant := normalizeMerchant(tx.Merchant)
+
+	for _, e := range h.events {
+		// A concurrent request that started a moment later may have been
+		// recorded first; its event counts as simultaneous.
+		age := max(at.Sub(e.at), 0)
+		if age > retention {
+			continue
+		}
+
+		f.TxCount24h++
+		f.AmountSum24h = f.AmountSum24h.Add(e.amount)
+		if e.amount.MinorUnits > f.MaxAmount24h.MinorUnits {
+			f.MaxAmount24h = e.amount
+		}
+		if e.merchant != "" {
+			merchants[e.merchant] = struct{}{}
+		}
+		if e.country != "" {
+			countries[e.country] = struct{}{}
+		}
+
+		if age <= time.Hour {
+			f.TxCount1h++
+			f.AmountSum1h = f.AmountSum1h.Add(e.amount)
+		}
+		if e.geo != nil && !e.at.Before(f.LastGeoAt) {
+			f.LastGeo = e.geo
+			f.LastGeoAt = e.at
+		}
+
+		if age <= time.Minute {
+			f.TxCount1m++
+			f.AmountSum1m = f.AmountSum1m.Add(e.amount)
+		}
+	}
+
+	_, f.KnownMerchant = merchants[merchant]
+	f.DistinctMerchants24h = len(merchants)
+	f.DistinctCountries24h = len(countries)
+	if f.TxCount24h > 0 {
+		f.AvgAmount24h = f.AmountSum24h.DivInt(int64(f.TxCount24h))
+	}
+
+	return f
+}
+
+// observe inserts tx into the user's history, which is kept in time order
+// even when concurrent requests record their transactions out of order.
+func (s *MemoryStore) observe(tx domain.Transaction, at time.Time) {
+	key := userKey(tx)
+	h, ok := s.users[key]
+	if !ok {
+		h = &history{}
+		s.users[key] = h
+	}
+
+	h.events = prune(h.events, at)
+	i := sort.Search(len(h.events), func(i int) bool { return h.events[i].at.After(at) })
+	h.events = slices.Insert(h.events, i, event{
+		at:       at,
+		amount:   tx.Amount,
+		merchant: normalizeMerchant(tx.Merchant),
+		country:  countryOf(tx),
+		geo:      tx.Geo,
+	})
+	if len(h.events) > maxEventsPerUser {
+		h.events = h.events[len(h.events)-maxEventsPerUser:]
+	}
+	if at.After(h.lastAt) {
+		h.lastAt = at
+	}
+}
+
+// ObserveLabel counts the outcome label l towards the aggregates of the user
+// whose transaction it judges. A label without a known decision has no user
+// and is ignored; a relabelled transaction replaces its previous label.
+func (s *MemoryStore) ObserveLabel(_ context.Context, l domain.Label) error {
+	if l.Decision == nil || l.Decision.Transaction.UserID == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := userKey(domain.Transaction{TenantID: l.TenantID, UserID: l.Decision.Transaction.UserID})
+	byTx, ok := s.outcomes[key]
+	if !ok {
+		byTx = make(map[string]outcome)
+		s.outcomes[key] = byTx
+	}
+	byTx[l.TransactionID] = outcome{label: l.Label, at: l.OccurredAt}
+	return nil
+}
+
+// Sweep drops events older than the retention window and forgets idle users.
+func (s *MemoryStore) Sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, h := range s.users {
+		h.events = prune(h.events, now)
+		if len(h.events) == 0 {
+			delete(s.users, id)
+		}
+	}
+	for id, byTx := range s.outcomes {
+		for tx, o := range byTx {
+			if now.Sub(o.at) > labelRetention {
+				delete(byTx, tx)
+			}
+		}
+		if len(byTx) == 0 {
+			delete(s.outcomes, id)
+		}
+	}
+}
+
+// Run sweeps the store every period until ctx is cancelled.
+func (s *MemoryStore) Run(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.Sweep(now)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// prune drops the events older than the retention window. events must be in
+// time order.
+func prune(events []event, now time.Time) []event {
+	i := 0
+	for i < len(events) && now.Sub(events[i].at) > retention {
+		i++
+	}
+	return events[i:]
+}
+
+// userKey scopes histories by tenant so that tenants sharing user IDs never
+// see each other's activity.
+func userKey(tx domain.Transaction) string {
+	return tx.TenantID + "/" + tx.UserID
+}
+
+func normalizeMerchant(merchant string) string {
+	return strings.ToLower(strings.TrimSpace(merchant))
+}
+
+// countryOf prefers the resolved country and otherwise treats the last
+// comma-separated part of a free-text location ("Lviv, Ukraine") as it.
+func countryOf(tx domain.Transaction) string {
+	if tx.Geo != nil {
+		return strings.ToLower(tx.Geo.Country)
+	}
+	parts := strings.Split(tx.Location, ",")
+	return strings.ToLower(strings.TrimSpace(parts[len(parts)-1]))
+}
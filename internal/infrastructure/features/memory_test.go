@@ -0,0 +1,77 @@
+package features
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+func TestMemoryStore_Windows(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	observe := func(ago time.Duration, amount string, merchant, location string) {
+		tx := domain.Transaction{UserID: "u1", Amount: domain.MustParseMoney(amount, "USD"), Merchant: merchant, Location: location}
+		if err := store.Observe(ctx, tx, now.Add(-ago)); err != nil {
+			t.Fatalf("Observe: %v", err)
+		}
+	}
+
+	observe(25*time.Hour, "5000", "Old", "Kyiv, Ukraine")
+	observe(3*time.Hour, "300", "Silpo", "Lviv, Ukraine")
+	observe(30*time.Minute, "100", "Steam", "Warsaw, Poland")
+	observe(30*time.Second, "1", "Steam", "Warsaw, Poland")
+	observe(10*time.Second, "2", "Steam", "Warsaw, Poland")
+
+	f, err := store.Features(ctx, domain.Transaction{UserID: "u1", Amount: domain.Money{Currency: "USD"}, Merchant: "steam "}, now)
+	if err != nil {
+		t.Fatalf("Features: %v", err)
+	}
+
+	if f.TxCount1m != 2 || f.TxCount1h != 3 || f.TxCount24h != 4 {
+		t.Errorf("Unexpected window counts: 1m=%d 1h=%d 24h=%d", f.TxCount1m, f.TxCount1h, f.TxCount24h)
+	}
+	if f.AmountSum24h.Decimal() != "403.00" || f.MaxAmount24h.Decimal() != "300.00" {
+		t.Errorf("Unexpected 24h aggregates: sum=%s max=%s", f.AmountSum24h, f.MaxAmount24h)
+	}
+	if f.DistinctMerchants24h != 2 || f.DistinctCountries24h != 2 {
+		t.Errorf("Unexpected distinct counts: merchants=%d countries=%d", f.DistinctMerchants24h, f.DistinctCountries24h)
+	}
+	if !f.KnownMerchant {
+		t.Errorf("Expected merchant to be known from history")
+	}
+	if f.SinceLastTx != 10*time.Second {
+		t.Errorf("Expected 10s since last tx, got %s", f.SinceLastTx)
+	}
+}
+
+func TestMemoryStore_ObserveAndFeaturesOutOfOrder(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tx := domain.Transaction{UserID: "u1", Amount: domain.MustParseMoney("10", "USD")}
+
+	// A request that started later is recorded before one that started first.
+	for _, at := range []time.Time{now.Add(-25 * time.Hour), now, now.Add(-time.Millisecond)} {
+		if _, err := store.ObserveAndFeatures(ctx, tx, at); err != nil {
+			t.Fatalf("ObserveAndFeatures: %v", err)
+		}
+	}
+	f, err := store.ObserveAndFeatures(ctx, tx, now.Add(-2*time.Millisecond))
+	if err != nil {
+		t.Fatalf("ObserveAndFeatures: %v", err)
+	}
+	if f.TxCount1m != 2 || f.SinceLastTx != 0 {
+		t.Errorf("Expected both concurrent transactions to count, got 1m=%d since=%s", f.TxCount1m, f.SinceLastTx)
+	}
+
+	store.Sweep(now.Add(2 * time.Minute))
+	h := store.users[userKey(tx)]
+	if len(h.events) != 3 || !slices.IsSortedFunc(h.events, func(a, b event) int { return a.at.Compare(b.at) }) {
+		t.Errorf("Expected the expired event dropped and the rest in time order, got %+v", h.events)
+	}
+}
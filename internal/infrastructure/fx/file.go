@@ -0,0 +1,140 @@
+package fx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+// fileConfig is the on-disk layout. Rates are units of Base per one unit of
+// the keyed currency; threshold overrides are in the keyed currency's units.
+// Numbers are decoded as json.Number so that they stay exact decimals.
+type fileConfig struct {
+	Base       string                    `json:"base"`
+	Rates      map[string]json.Number    `json:"rates"`
+	Thresholds map[string]fileThresholds `json:"thresholds"`
+}
+
+type fileThresholds struct {
+	LowValue          json.Number `json:"low_value"`
+	HeuristicBlockMin json.Number `json:"heuristic_block_min"`
+	HighValue         json.Number `json:"high_value"`
+	PrescreenAllowMax json.Number `json:"prescreen_allow_max"`
+}
+
+// FileProvider serves FX rates and per-currency threshold overrides from a
+// JSON file.
+type FileProvider struct {
+	mu         sync.RWMutex
+	base       string
+	rates      map[string]*big.Rat
+	thresholds map[string]domain.Thresholds
+}
+
+func NewFileProvider(path string) (*FileProvider, error) {
+	p := &FileProvider{}
+	if err := p.Load(path); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *FileProvider) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read fx rates file: %w", err)
+	}
+
+	var cfg fileConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("failed to parse fx rates json: %w", err)
+	}
+
+	base := domain.NormalizeCurrency(cfg.Base)
+	if base == "" {
+		base = domain.DefaultBaseCurrency
+	}
+
+	rates := map[string]*big.Rat{base: big.NewRat(1, 1)}
+	for code, n := range cfg.Rates {
+		rate, ok := new(big.Rat).SetString(n.String())
+		if !ok || rate.Sign() <= 0 {
+			return fmt.Errorf("fx rate for %s must be a positive number, got %q", code, n)
+		}
+		rates[domain.NormalizeCurrency(code)] = rate
+	}
+
+	thresholds := make(map[string]domain.Thresholds, len(cfg.Thresholds))
+	for code, ft := range cfg.Thresholds {
+		currency := domain.NormalizeCurrency(code)
+		t, err := ft.parse(currency)
+		if err != nil {
+			return fmt.Errorf("invalid thresholds for %s: %w", code, err)
+		}
+		thresholds[currency] = t
+	}
+
+	p.mu.Lock()
+	p.base = base
+	p.rates = rates
+	p.thresholds = thresholds
+	p.mu.Unlock()
+
+	return nil
+}
+
+func (ft fileThresholds) parse(currency string) (domain.Thresholds, error) {
+	var t domain.Thresholds
+	fields := []struct {
+		dst *domain.Money
+		src json.Number
+	}{
+		{&t.LowValue, ft.LowValue},
+		{&t.HeuristicBlockMin, ft.HeuristicBlockMin},
+		{&t.HighValue, ft.HighValue},
+		{&t.PrescreenAllowMax, ft.PrescreenAllowMax},
+	}
+	for _, f := range fields {
+		src := f.src.String()
+		if src == "" {
+			src = "0"
+		}
+		m, err := domain.ParseMoney(src, currency)
+		if err != nil {
+			return domain.Thresholds{}, err
+		}
+		*f.dst = m
+	}
+	return t, nil
+}
+
+func (p *FileProvider) Base() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.base
+}
+
+// Rate returns how many units of the base currency one unit of currency buys.
+func (p *FileProvider) Rate(currency string) (*big.Rat, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	rate, ok := p.rates[domain.NormalizeCurrency(currency)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedCurrency, currency)
+	}
+	return new(big.Rat).Set(rate), nil
+}
+
+// Thresholds returns the override for currency, in that currency's units.
+func (p *FileProvider) Thresholds(currency string) (domain.Thresholds, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	t, ok := p.thresholds[domain.NormalizeCurrency(currency)]
+	return t, ok
+}
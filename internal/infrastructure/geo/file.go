@@ -0,0 +1,194 @@
+package geo
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+// fileDB is the on-disk layout. Countries carry a centroid used when a
+// location names only a country; IP ranges point at a city or a country.
+type fileDB struct {
+	Countries []country `json:"countries"`
+	Cities    []city    `json:"cities"`
+	IPRanges  []ipRange `json:"ip_ranges"`
+}
+
+type country struct {
+	Code    string   `json:"code"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+	Lat     float64  `json:"lat"`
+	Lon     float64  `json:"lon"`
+}
+
+type city struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+	Country string   `json:"country"`
+	Lat     float64  `json:"lat"`
+	Lon     float64  `json:"lon"`
+}
+
+type ipRange struct {
+	CIDR    string `json:"cidr"`
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type prefixPoint struct {
+	prefix netip.Prefix
+	point  domain.GeoPoint
+}
+
+// FileDB resolves free-text locations and IP addresses against an offline
+// geo database in JSON form.
+type FileDB struct {
+	mu        sync.RWMutex
+	countries map[string]domain.GeoPoint
+	cities    map[string][]domain.GeoPoint
+	ranges    []prefixPoint
+}
+
+func NewFileDB(path string) (*FileDB, error) {
+	db := &FileDB{}
+	if err := db.Load(path); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func (db *FileDB) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read geo database: %w", err)
+	}
+
+	var raw fileDB
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse geo database json: %w", err)
+	}
+
+	countries := make(map[string]domain.GeoPoint)
+	for _, c := range raw.Countries {
+		code := strings.ToUpper(c.Code)
+		p := domain.GeoPoint{Country: code, Lat: c.Lat, Lon: c.Lon}
+		for _, name := range append([]string{c.Code, c.Name}, c.Aliases...) {
+			countries[key(name)] = p
+		}
+	}
+
+	cities := make(map[string][]domain.GeoPoint)
+	for _, c := range raw.Cities {
+		p := domain.GeoPoint{City: c.Name, Country: strings.ToUpper(c.Country), Lat: c.Lat, Lon: c.Lon}
+		for _, name := range append([]string{c.Name}, c.Aliases...) {
+			cities[key(name)] = append(cities[key(name)], p)
+		}
+	}
+
+	ranges := make([]prefixPoint, 0, len(raw.IPRanges))
+	for _, r := range raw.IPRanges {
+		prefix, err := netip.ParsePrefix(r.CIDR)
+		if err != nil {
+			return fmt.Errorf("invalid ip range %q: %w", r.CIDR, err)
+		}
+		p, ok := lookupCity(cities, r.City, r.Country)
+		if !ok {
+			if p, ok = countries[key(r.Country)]; !ok {
+				return fmt.Errorf("ip range %s points at unknown place %q/%q", r.CIDR, r.City, r.Country)
+			}
+		}
+		ranges = append(ranges, prefixPoint{prefix: prefix.Masked(), point: p})
+	}
+	// Most specific first so that the first match is the longest prefix.
+	slices.SortFunc(ranges, func(a, b prefixPoint) int {
+		return cmp.Compare(b.prefix.Bits(), a.prefix.Bits())
+	})
+
+	db.mu.Lock()
+	db.countries = countries
+	db.cities = cities
+	db.ranges = ranges
+	db.mu.Unlock()
+
+	return nil
+}
+
+// Resolve normalizes a free-text location such as "Lviv, Ukraine" or
+// "Nigeria". The first comma-separated part that names a known city wins,
+// disambiguated by any country named in the other parts; otherwise the first
+// known country is used.
+func (db *FileDB) Resolve(location string) (domain.GeoPoint, bool) {
+	parts := strings.Split(location, ",")
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var countryHint string
+	for _, part := range parts {
+		if c, ok := db.countries[key(part)]; ok {
+			countryHint = c.Country
+			break
+		}
+	}
+
+	for _, part := range parts {
+		if p, ok := lookupCity(db.cities, part, countryHint); ok {
+			p.Source = domain.GeoSourceLocation
+			return p, true
+		}
+	}
+
+	if countryHint != "" {
+		p := db.countries[key(countryHint)]
+		p.Source = domain.GeoSourceLocation
+		return p, true
+	}
+	return domain.GeoPoint{}, false
+}
+
+func (db *FileDB) ResolveIP(ip string) (domain.GeoPoint, bool) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return domain.GeoPoint{}, false
+	}
+	addr = addr.Unmap()
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, r := range db.ranges {
+		if r.prefix.Contains(addr) {
+			p := r.point
+			p.Source = domain.GeoSourceIP
+			return p, true
+		}
+	}
+	return domain.GeoPoint{}, false
+}
+
+func lookupCity(cities map[string][]domain.GeoPoint, name, country string) (domain.GeoPoint, bool) {
+	candidates := cities[key(name)]
+	if len(candidates) == 0 {
+		return domain.GeoPoint{}, false
+	}
+	if country != "" {
+		for _, c := range candidates {
+			if strings.EqualFold(c.Country, country) {
+				return c, true
+			}
+		}
+	}
+	return candidates[0], true
+}
+
+func key(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,74 @@
+package geo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testDB = `{
+  "countries": [
+    {"code": "UA", "name": "Ukraine", "lat": 48.38, "lon": 31.17},
+    {"code": "NG", "name": "Nigeria", "lat": 9.08, "lon": 8.68},
+    {"code": "US", "name": "United States", "lat": 37.09, "lon": -95.71}
+  ],
+  "cities": [
+    {"name": "Kyiv", "aliases": ["Kiev"], "country": "UA", "lat": 50.45, "lon": 30.52},
+    {"name": "Odesa", "country": "UA", "lat": 46.48, "lon": 30.72},
+    {"name": "Odesa", "country": "US", "lat": 31.85, "lon": -102.37}
+  ],
+  "ip_ranges": [
+    {"cidr": "10.0.0.0/8", "country": "NG"},
+    {"cidr": "10.1.0.0/16", "city": "Kyiv", "country": "UA"}
+  ]
+}`
+
+func newTestDB(t *testing.T) *FileDB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "geo.json")
+	if err := os.WriteFile(path, []byte(testDB), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	db, err := NewFileDB(path)
+	if err != nil {
+		t.Fatalf("NewFileDB: %v", err)
+	}
+	return db
+}
+
+func TestFileDB_Resolve(t *testing.T) {
+	db := newTestDB(t)
+
+	cases := []struct {
+		location, city, country string
+	}{
+		{"Kiev", "Kyiv", "UA"},
+		{"Odesa, United States", "Odesa", "US"},
+		{"Odesa, Ukraine", "Odesa", "UA"},
+		{"Nigeria", "", "NG"},
+	}
+	for _, c := range cases {
+		p, ok := db.Resolve(c.location)
+		if !ok || p.City != c.city || p.Country != c.country {
+			t.Errorf("Resolve(%q) = %+v, %v; want %s/%s", c.location, p, ok, c.city, c.country)
+		}
+	}
+
+	if _, ok := db.Resolve("Atlantis"); ok {
+		t.Errorf("Expected unknown location not to resolve")
+	}
+}
+
+func TestFileDB_ResolveIPLongestPrefix(t *testing.T) {
+	db := newTestDB(t)
+
+	if p, ok := db.ResolveIP("10.1.2.3"); !ok || p.City != "Kyiv" {
+		t.Errorf("Expected the /16 Kyiv range to win, got %+v", p)
+	}
+	if p, ok := db.ResolveIP("10.9.9.9"); !ok || p.Country != "NG" || p.City != "" {
+		t.Errorf("Expected country-level match, got %+v", p)
+	}
+	if _, ok := db.ResolveIP("not-an-ip"); ok {
+		t.Errorf("Expected invalid ip not to resolve")
+	}
+}
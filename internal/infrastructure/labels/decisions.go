@@ -0,0 +1,208 @@
+package labels
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/pkg/jsonl"
+)
+
+// DefaultDecisionRetention is how long decisions are kept on disk for joining.
+const DefaultDecisionRetention = 180 * 24 * time.Hour
+
+const (
+	segmentPrefix = "decisions-"
+	segmentSuffix = ".jsonl"
+	segmentDay    = "20060102"
+)
+
+// decisionRecord is one line of the decision log.
+type decisionRecord struct {
+	Tenant   string                 `json:"tenant"`
+	Decision domain.LabeledDecision `json:"decision"`
+}
+
+// decisionLog keeps every decision in one JSON Lines segment per UTC day in
+// dir and deletes segments older than retention, so that a label reported
+// after a restart or long after its decision, as chargebacks are, can still
+// be joined to it.
+type decisionLog struct {
+	dir       string
+	retention time.Duration
+	now       func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	day  string
+	size int64
+}
+
+func newDecisionLog(dir string, retention time.Duration) (*decisionLog, error) {
+	if retention <= 0 {
+		retention = DefaultDecisionRetention
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create decision log dir: %w", err)
+	}
+	l := &decisionLog{dir: dir, retention: retention, now: time.Now}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.open(l.now().UTC().Format(segmentDay)); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Append writes d to the segment of the current day. A failed write is cut
+// off so that the next record starts on a line of its own.
+func (l *decisionLog) Append(tenant string, d domain.LabeledDecision) error {
+	data, err := json.Marshal(decisionRecord{Tenant: tenant, Decision: d})
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if day := l.now().UTC().Format(segmentDay); day != l.day {
+		if err := l.open(day); err != nil {
+			return err
+		}
+	}
+	n, err := l.file.Write(append(data, '\n'))
+	if err != nil {
+		if n > 0 {
+			_ = l.file.Truncate(l.size)
+		}
+		return fmt.Errorf("failed to write decision: %w", err)
+	}
+	l.size += int64(n)
+	return nil
+}
+
+// Find returns the last decision on a transaction, reading the segments
+// newest first. It reads the log from disk and is meant for the labels that
+// miss the in-memory cache.
+func (l *decisionLog) Find(tenant, txID string) (domain.LabeledDecision, bool, error) {
+	segments, err := l.segments()
+	if err != nil {
+		return domain.LabeledDecision{}, false, err
+	}
+	for _, name := range slices.Backward(segments) {
+		d, ok, err := findInSegment(filepath.Join(l.dir, name), tenant, txID)
+		if err != nil || ok {
+			return d, ok, err
+		}
+	}
+	return domain.LabeledDecision{}, false, nil
+}
+
+func findInSegment(path, tenant, txID string) (domain.LabeledDecision, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return domain.LabeledDecision{}, false, err
+	}
+	defer f.Close()
+
+	var (
+		found domain.LabeledDecision
+		ok    bool
+	)
+	id := []byte(txID)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+	for sc.Scan() {
+		if !bytes.Contains(sc.Bytes(), id) {
+			continue
+		}
+		// A line that does not parse is one being written.
+		var rec decisionRecord
+		if json.Unmarshal(sc.Bytes(), &rec) != nil {
+			continue
+		}
+		if rec.Tenant == tenant && rec.Decision.Transaction.ID == txID {
+			found, ok = rec.Decision, true
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return domain.LabeledDecision{}, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return found, ok, nil
+}
+
+// open switches to the segment of day, dropping a record left incomplete by
+// a crash, and deletes the segments past the retention. Callers must hold
+// l.mu.
+func (l *decisionLog) open(day string) error {
+	f, err := os.OpenFile(filepath.Join(l.dir, segmentPrefix+day+segmentSuffix), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to open decision log: %w", err)
+	}
+	size, dropped, err := jsonl.TrimPartial(f)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	if dropped > 0 {
+		slog.Warn("dropped incomplete decision log record", "path", f.Name(), "bytes", dropped)
+	}
+
+	if l.file != nil {
+		_ = l.file.Close()
+	}
+	l.file, l.day, l.size = f, day, size
+	l.prune()
+	return nil
+}
+
+// prune deletes the segments of days past the retention.
+func (l *decisionLog) prune() {
+	segments, err := l.segments()
+	if err != nil {
+		slog.Error("failed to list decision log", "dir", l.dir, "err", err)
+		return
+	}
+	oldest := l.now().UTC().Add(-l.retention).Format(segmentDay)
+	for _, name := range segments {
+		if day := strings.TrimSuffix(strings.TrimPrefix(name, segmentPrefix), segmentSuffix); day >= oldest {
+			continue
+		}
+		if err := os.Remove(filepath.Join(l.dir, name)); err != nil {
+			slog.Error("failed to delete expired decision log", "path", name, "err", err)
+		}
+	}
+}
+
+// segments returns the segment names, oldest first.
+func (l *decisionLog) segments() ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.Type().IsRegular() && strings.HasPrefix(name, segmentPrefix) && strings.HasSuffix(name, segmentSuffix) {
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+func (l *decisionLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
@@ -0,0 +1,242 @@
+package labels
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/pkg/jsonl"
+)
+
+// DefaultDecisionCache is how many recent decisions are kept for joining.
+const DefaultDecisionCache = 100_000
+
+// Store keeps outcome labels in an append-only JSON Lines file: a relabelled
+// transaction gets a new line and the last line wins. Decisions are written
+// to a log and the recent ones kept in memory, bounded to a fixed count, so
+// that a label can be stored together with the decision it judges.
+type Store struct {
+	path string
+	log  *decisionLog
+
+	mu     sync.RWMutex
+	file   *os.File
+	labels map[string]domain.Label
+
+	decisions map[string]domain.LabeledDecision
+	order     []string
+	next      int
+}
+
+// NewStore loads the labels at path, creating the file if needed, and keeps
+// up to cache recent decisions in memory. Decisions are logged in
+// decisionsDir for retention; an empty decisionsDir joins labels to the
+// cached decisions only.
+func NewStore(path, decisionsDir string, cache int, retention time.Duration) (*Store, error) {
+	if cache <= 0 {
+		cache = DefaultDecisionCache
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open labels file: %w", err)
+	}
+	// A crash mid-append leaves a torn last line, which is dropped rather
+	// than stopping startup.
+	_, dropped, err := jsonl.TrimPartial(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if dropped > 0 {
+		slog.Warn("dropped incomplete label", "path", path, "bytes", dropped)
+	}
+	labels, err := load(path)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	var log *decisionLog
+	if decisionsDir != "" {
+		if log, err = newDecisionLog(decisionsDir, retention); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+	}
+
+	s := &Store{
+		path:      path,
+		log:       log,
+		file:      f,
+		labels:    make(map[string]domain.Label, len(labels)),
+		decisions: make(map[string]domain.LabeledDecision),
+		order:     make([]string, cache),
+	}
+	for _, l := range labels {
+		s.labels[Key(l.TenantID, l.TransactionID)] = l
+	}
+	return s, nil
+}
+
+// Key identifies a transaction across tenants.
+func Key(tenant, txID string) string {
+	return tenant + "/" + txID
+}
+
+// ReadFile returns the labels in the file at path, keeping the last label of
+// each transaction, in the order they were first reported.
+func ReadFile(path string) ([]domain.Label, error) {
+	labels, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(labels))
+	var out []domain.Label
+	for _, l := range labels {
+		k := Key(l.TenantID, l.TransactionID)
+		if i, ok := index[k]; ok {
+			out[i] = l
+			continue
+		}
+		index[k] = len(out)
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+func load(path string) ([]domain.Label, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open labels file: %w", err)
+	}
+	defer f.Close()
+
+	var out []domain.Label
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var l domain.Label
+		if err := json.Unmarshal(sc.Bytes(), &l); err != nil {
+			return nil, fmt.Errorf("labels file line %d: %w", n, err)
+		}
+		out = append(out, l)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read labels file: %w", err)
+	}
+	return out, nil
+}
+
+// Label returns the current label of a transaction.
+func (s *Store) Label(tenant, txID string) (domain.Label, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	l, ok := s.labels[Key(tenant, txID)]
+	return l, ok
+}
+
+// Labels returns every current label.
+func (s *Store) Labels() []domain.Label {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]domain.Label, 0, len(s.labels))
+	for _, l := range s.labels {
+		out = append(out, l)
+	}
+	return out
+}
+
+// Save appends l to the file and makes it the current label of its
+// transaction.
+func (s *Store) Save(l domain.Label) error {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write label: %w", err)
+	}
+	s.labels[Key(l.TenantID, l.TransactionID)] = l
+	return nil
+}
+
+// RecordDecision logs d and caches it, evicting the oldest cached decision
+// once the cache is full. A failed write is logged: the decision can then
+// only be joined while it is cached.
+func (s *Store) RecordDecision(tenant string, d domain.LabeledDecision) {
+	if s.log != nil {
+		if err := s.log.Append(tenant, d); err != nil {
+			slog.Error("failed to log decision", "tenant", tenant, "transaction_id", d.Transaction.ID, "err", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache(Key(tenant, d.Transaction.ID), d)
+}
+
+// cache keeps d under k. Callers must hold s.mu.
+func (s *Store) cache(k string, d domain.LabeledDecision) {
+	if _, ok := s.decisions[k]; !ok {
+		if old := s.order[s.next]; old != "" {
+			delete(s.decisions, old)
+		}
+		s.order[s.next] = k
+		s.next = (s.next + 1) % len(s.order)
+	}
+	s.decisions[k] = d
+}
+
+// Decision returns the decision on a transaction, from the cache or else
+// from the decision log.
+func (s *Store) Decision(tenant, txID string) (domain.LabeledDecision, bool) {
+	k := Key(tenant, txID)
+
+	s.mu.RLock()
+	d, ok := s.decisions[k]
+	s.mu.RUnlock()
+	if ok || s.log == nil {
+		return d, ok
+	}
+
+	d, ok, err := s.log.Find(tenant, txID)
+	if err != nil {
+		slog.Error("failed to read decision log", "tenant", tenant, "transaction_id", txID, "err", err)
+		return domain.LabeledDecision{}, false
+	}
+	if ok {
+		s.mu.Lock()
+		s.cache(k, d)
+		s.mu.Unlock()
+	}
+	return d, ok
+}
+
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.log != nil {
+		return errors.Join(s.file.Close(), s.log.Close())
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,167 @@
+package labels
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+func TestStore_LastLabelWinsAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "labels.jsonl")
+	s, err := NewStore(path, "", 2, 0)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	at := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	for _, l := range []domain.Label{
+		{TransactionID: "tx-1", TenantID: "acme", Label: domain.LabelLegitimate, Source: domain.SourceReview, OccurredAt: at},
+		{TransactionID: "tx-2", TenantID: "acme", Label: domain.LabelFraud, Source: domain.SourceDispute, OccurredAt: at},
+		{TransactionID: "tx-1", TenantID: "acme", Label: domain.LabelFraud, Source: domain.SourceChargeback, OccurredAt: at.Add(time.Hour)},
+	} {
+		if err := s.Save(l); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reloaded, err := NewStore(path, "", 2, 0)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer reloaded.Close()
+	if l, ok := reloaded.Label("acme", "tx-1"); !ok || l.Label != domain.LabelFraud || l.Source != domain.SourceChargeback {
+		t.Errorf("Expected the chargeback to replace the review label, got %+v", l)
+	}
+	if _, ok := reloaded.Label("other", "tx-1"); ok {
+		t.Error("Expected labels to be scoped by tenant")
+	}
+
+	all, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if len(all) != 2 || all[0].TransactionID != "tx-1" || all[0].Label != domain.LabelFraud {
+		t.Errorf("Expected one current label per transaction in report order, got %+v", all)
+	}
+}
+
+func TestStore_DecisionCacheEvictsOldest(t *testing.T) {
+	s, err := NewStore(filepath.Join(t.TempDir(), "labels.jsonl"), "", 2, 0)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer s.Close()
+
+	for _, id := range []string{"tx-1", "tx-2", "tx-2", "tx-3"} {
+		s.RecordDecision("acme", domain.LabeledDecision{Transaction: domain.Transaction{ID: id}, Decision: domain.DecisionAllow})
+	}
+
+	if _, ok := s.Decision("acme", "tx-1"); ok {
+		t.Error("Expected the oldest decision to be evicted")
+	}
+	for _, id := range []string{"tx-2", "tx-3"} {
+		if _, ok := s.Decision("acme", id); !ok {
+			t.Errorf("Expected %s to be kept", id)
+		}
+	}
+}
+
+func TestStore_JoinsDecisionsFromTheLogAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "labels.jsonl")
+	decisions := filepath.Join(dir, "decisions")
+
+	// The records are dated by a fixed clock; keep them whenever this runs.
+	retention := 100 * 365 * 24 * time.Hour
+	s, err := NewStore(path, decisions, 1, retention)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	s.log.now = func() time.Time { return now }
+	s.RecordDecision("acme", domain.LabeledDecision{Transaction: domain.Transaction{ID: "tx-1"}, Decision: domain.DecisionReview})
+	now = now.Add(24 * time.Hour)
+	s.RecordDecision("acme", domain.LabeledDecision{Transaction: domain.Transaction{ID: "tx-1"}, Decision: domain.DecisionAllow})
+	s.RecordDecision("acme", domain.LabeledDecision{Transaction: domain.Transaction{ID: "tx-2"}, Decision: domain.DecisionBlock})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reloaded, err := NewStore(path, decisions, 1, retention)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer reloaded.Close()
+	if d, ok := reloaded.Decision("acme", "tx-1"); !ok || d.Decision != domain.DecisionAllow {
+		t.Errorf("Expected the latest decision on tx-1 from the log, got %+v, %v", d, ok)
+	}
+	if _, ok := reloaded.Decision("other", "tx-2"); ok {
+		t.Error("Expected logged decisions to be scoped by tenant")
+	}
+	if _, ok := reloaded.Decision("acme", "tx-3"); ok {
+		t.Error("Expected no decision for an unknown transaction")
+	}
+}
+
+func TestDecisionLog_DropsTornRecordsAndExpiredDays(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	expired := filepath.Join(dir, segmentPrefix+"20260101"+segmentSuffix)
+	today := filepath.Join(dir, segmentPrefix+"20261019"+segmentSuffix)
+	if err := os.WriteFile(expired, []byte(`{"tenant":"acme","decision":{"transaction":{"id":"tx-old"}}}`+"\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(today, []byte(`{"tenant":"acme","decision":{"transaction":{"id":"tx-1"},"decision":"allow"}}`+"\n"+`{"tenant":"acme","deci`), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &decisionLog{dir: dir, retention: 30 * 24 * time.Hour, now: func() time.Time { return now }}
+	l.mu.Lock()
+	err := l.open("20261019")
+	l.mu.Unlock()
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer l.Close()
+
+	if _, err := os.Stat(expired); !os.IsNotExist(err) {
+		t.Errorf("Expected the expired day to be deleted, got %v", err)
+	}
+	if err := l.Append("acme", domain.LabeledDecision{Transaction: domain.Transaction{ID: "tx-2"}, Decision: domain.DecisionBlock}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	for id, want := range map[string]string{"tx-1": domain.DecisionAllow, "tx-2": domain.DecisionBlock} {
+		if d, ok, err := l.Find("acme", id); err != nil || !ok || d.Decision != want {
+			t.Errorf("%s: expected %s after the torn record, got %+v, %v, %v", id, want, d, ok, err)
+		}
+	}
+}
+
+func TestNewStore_DropsTornLastLabel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "labels.jsonl")
+	data := `{"transaction_id":"tx-1","tenant_id":"acme","label":"fraud","source":"chargeback"}` + "\n" + `{"transaction_id":"tx-2","ten`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewStore(path, "", 2, 0)
+	if err != nil {
+		t.Fatalf("Expected a torn last line not to stop startup, got %v", err)
+	}
+	defer s.Close()
+	if _, ok := s.Label("acme", "tx-1"); !ok {
+		t.Error("Expected the complete label to be loaded")
+	}
+	if err := s.Save(domain.Label{TransactionID: "tx-3", TenantID: "acme", Label: domain.LabelFraud, Source: domain.SourceOther}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if all, err := ReadFile(path); err != nil || len(all) != 2 {
+		t.Errorf("Expected the new label on a line of its own, got %+v, %v", all, err)
+	}
+}
@@ -0,0 +1,278 @@
+package lists
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/pkg/atomicfile"
+	"github.com/tokyosplif/ai-risk-engine/pkg/watch"
+)
+
+// Store holds the allow and deny lists. The JSON file at path is the source of
+// truth: admin changes are written back to it and external edits are picked up
+// by Watch.
+type Store struct {
+	path string
+	now  func() time.Time
+
+	mu      sync.RWMutex
+	entries []domain.ListEntry
+	// loadErr is the error of the last load. While it is set, admin changes
+	// are refused so that they do not overwrite the file being fixed.
+	loadErr error
+}
+
+// NewStore loads the lists file at path, creating an empty one if it does not
+// exist. A file that cannot be loaded is an error: starting with empty lists
+// would let denylisted traffic through.
+func NewStore(path string) (*Store, error) {
+	s := &Store{path: path, now: time.Now}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := s.persist(); err != nil {
+			return nil, fmt.Errorf("failed to create lists file: %w", err)
+		}
+		return s, nil
+	}
+
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load lists file: %w", err)
+	}
+	return s, nil
+}
+
+// load replaces the entries with the file's. On error the current entries are
+// kept and admin changes are refused until a load succeeds.
+func (s *Store) load() error {
+	entries, err := s.read()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadErr = err
+	if err != nil {
+		return err
+	}
+	s.entries = entries
+
+	slog.Debug("lists loaded/reloaded", "count", len(entries))
+	return nil
+}
+
+func (s *Store) read() ([]domain.ListEntry, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []domain.ListEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse lists json: %w", err)
+	}
+	for i := range entries {
+		entries[i] = normalize(entries[i])
+		if err := validate(entries[i]); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// persist replaces the file through a temporary file, so a crash mid-write
+// never leaves it truncated. Callers must hold s.mu.
+func (s *Store) persist() error {
+	if s.loadErr != nil {
+		return fmt.Errorf("lists file failed to load, fix it before changing the lists: %w", s.loadErr)
+	}
+	entries := s.entries
+	if entries == nil {
+		entries = []domain.ListEntry{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(s.path, data, 0o640)
+}
+
+// Lookup returns the first active entry on list that matches tx.
+func (s *Store) Lookup(tx domain.Transaction, list string) (domain.ListEntry, bool) {
+	now := s.now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, e := range s.entries {
+		if e.List != list || e.Expired(now) || e.TenantID != "" && e.TenantID != tx.TenantID {
+			continue
+		}
+		if matches(e, tx) {
+			return e, true
+		}
+	}
+	return domain.ListEntry{}, false
+}
+
+func (s *Store) Add(e domain.ListEntry) (domain.ListEntry, error) {
+	e = normalize(e)
+	if err := validate(e); err != nil {
+		return domain.ListEntry{}, err
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = s.now().UTC()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.entries
+	s.entries = append(slices.DeleteFunc(slices.Clone(s.entries), func(existing domain.ListEntry) bool {
+		return sameKey(existing, e)
+	}), e)
+	if err := s.persist(); err != nil {
+		s.entries = prev
+		return domain.ListEntry{}, err
+	}
+	return e, nil
+}
+
+// Remove deletes the entry for value. tenant names the tenant of a
+// tenant-scoped entry; empty removes the entry that applies to every tenant.
+func (s *Store) Remove(tenant, list, entity, value string) error {
+	key := normalize(domain.ListEntry{TenantID: tenant, List: list, Entity: entity, Value: value})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.entries
+	s.entries = slices.DeleteFunc(slices.Clone(s.entries), func(existing domain.ListEntry) bool {
+		return sameKey(existing, key)
+	})
+	if len(s.entries) == len(prev) {
+		s.entries = prev
+		return domain.ErrListEntryNotFound
+	}
+	if err := s.persist(); err != nil {
+		s.entries = prev
+		return err
+	}
+	return nil
+}
+
+// List returns active entries, optionally filtered by list and entity.
+func (s *Store) List(list, entity string) []domain.ListEntry {
+	now := s.now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []domain.ListEntry
+	for _, e := range s.entries {
+		if e.Expired(now) {
+			continue
+		}
+		if list != "" && e.List != list {
+			continue
+		}
+		if entity != "" && e.Entity != entity {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// Watch reloads the lists file when it changes until ctx is done.
+func (s *Store) Watch(ctx context.Context) {
+	watch.Files(ctx, "lists", func(string) {
+		if err := s.load(); err != nil {
+			slog.Error("failed to reload lists file, keeping current lists", "path", s.path, "err", err)
+		}
+	}, s.path)
+}
+
+func matches(e domain.ListEntry, tx domain.Transaction) bool {
+	switch e.Entity {
+	case domain.EntityMerchant:
+		return strings.EqualFold(e.Value, strings.TrimSpace(tx.Merchant))
+	case domain.EntityUser:
+		return tx.UserID != "" && e.Value == tx.UserID
+	case domain.EntityDevice:
+		return tx.DeviceID != "" && e.Value == tx.DeviceID
+	case domain.EntityCountry:
+		return strings.EqualFold(e.Value, tx.CountryCode)
+	case domain.EntityBIN:
+		return tx.CardBIN != "" && strings.HasPrefix(tx.CardBIN, e.Value)
+	case domain.EntityIP:
+		return matchIP(e.Value, tx.IPAddress)
+	default:
+		return false
+	}
+}
+
+func matchIP(value, ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	if prefix, err := netip.ParsePrefix(value); err == nil {
+		return prefix.Contains(addr)
+	}
+	if single, err := netip.ParseAddr(value); err == nil {
+		return single == addr
+	}
+	return false
+}
+
+func normalize(e domain.ListEntry) domain.ListEntry {
+	e.TenantID = strings.TrimSpace(e.TenantID)
+	e.List = strings.ToLower(strings.TrimSpace(e.List))
+	e.Entity = strings.ToLower(strings.TrimSpace(e.Entity))
+	e.Value = strings.TrimSpace(e.Value)
+	if e.Entity == domain.EntityCountry {
+		e.Value = strings.ToUpper(e.Value)
+	}
+	return e
+}
+
+func validate(e domain.ListEntry) error {
+	if e.List != domain.ListAllow && e.List != domain.ListDeny {
+		return fmt.Errorf("%w: unknown list %q", domain.ErrInvalidListEntry, e.List)
+	}
+	if e.Value == "" {
+		return fmt.Errorf("%w: empty value", domain.ErrInvalidListEntry)
+	}
+
+	switch e.Entity {
+	case domain.EntityMerchant, domain.EntityUser, domain.EntityDevice, domain.EntityCountry:
+		return nil
+	case domain.EntityBIN:
+		if strings.Trim(e.Value, "0123456789") != "" {
+			return fmt.Errorf("%w: bin %q must be digits", domain.ErrInvalidListEntry, e.Value)
+		}
+		return nil
+	case domain.EntityIP:
+		if _, err := netip.ParsePrefix(e.Value); err == nil {
+			return nil
+		}
+		if _, err := netip.ParseAddr(e.Value); err == nil {
+			return nil
+		}
+		return fmt.Errorf("%w: %q is not an ip address or cidr range", domain.ErrInvalidListEntry, e.Value)
+	default:
+		return fmt.Errorf("%w: unknown entity %q", domain.ErrInvalidListEntry, e.Entity)
+	}
+}
+
+func sameKey(a, b domain.ListEntry) bool {
+	return a.TenantID == b.TenantID && a.List == b.List && a.Entity == b.Entity && strings.EqualFold(a.Value, b.Value)
+}
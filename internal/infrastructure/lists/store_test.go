@@ -0,0 +1,128 @@
+package lists
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+func TestStore_LookupAndPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lists.json")
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	store.now = func() time.Time { return now }
+
+	entries := []domain.ListEntry{
+		{List: domain.ListDeny, Entity: domain.EntityIP, Value: "203.0.113.0/24", Reason: "botnet"},
+		{List: domain.ListDeny, Entity: domain.EntityBIN, Value: "411111", Reason: "leaked"},
+		{List: domain.ListAllow, Entity: domain.EntityUser, Value: "vip-1", Reason: "VIP", ExpiresAt: now.Add(-time.Minute)},
+	}
+	for _, e := range entries {
+		if _, err := store.Add(e); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	if _, ok := store.Lookup(domain.Transaction{IPAddress: "203.0.113.7"}, domain.ListDeny); !ok {
+		t.Errorf("Expected IP inside denied range to match")
+	}
+	if _, ok := store.Lookup(domain.Transaction{CardBIN: "41111122"}, domain.ListDeny); !ok {
+		t.Errorf("Expected card BIN prefix to match")
+	}
+	if _, ok := store.Lookup(domain.Transaction{UserID: "vip-1"}, domain.ListAllow); ok {
+		t.Errorf("Expected expired entry to be ignored")
+	}
+
+	reloaded, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	reloaded.now = store.now
+	if got := len(reloaded.List("", "")); got != 2 {
+		t.Errorf("Expected 2 active entries after reload, got %d", got)
+	}
+
+	if _, err := store.Add(domain.ListEntry{List: domain.ListDeny, Entity: domain.EntityIP, Value: "not-an-ip"}); !errors.Is(err, domain.ErrInvalidListEntry) {
+		t.Errorf("Expected ErrInvalidListEntry, got %v", err)
+	}
+}
+
+func TestStore_RefusesToOverwriteBrokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lists.json")
+	broken := []byte(`[{"list":"deny","entity":"ip","value":"203.0.113.7"},`)
+	if err := os.WriteFile(path, broken, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewStore(path); err == nil {
+		t.Fatal("Expected a broken lists file to fail startup")
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"list":"deny","entity":"ip","value":"203.0.113.7"}]`), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	// A bad external edit keeps the loaded entries and blocks admin changes
+	// until the file is fixed.
+	if err := os.WriteFile(path, broken, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.load(); err == nil {
+		t.Fatal("Expected the reload to fail")
+	}
+	if _, ok := store.Lookup(domain.Transaction{IPAddress: "203.0.113.7"}, domain.ListDeny); !ok {
+		t.Error("Expected the loaded entries to stay in effect")
+	}
+	if _, err := store.Add(domain.ListEntry{List: domain.ListDeny, Entity: domain.EntityUser, Value: "u1"}); err == nil {
+		t.Error("Expected Add to be refused while the file is broken")
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(broken) {
+		t.Errorf("Expected the broken file to be left for fixing, got %s", data)
+	}
+	if got := len(store.List("", "")); got != 1 {
+		t.Errorf("Expected the refused entry to be rolled back, got %d entries", got)
+	}
+}
+
+func TestStore_TenantScopedEntries(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "lists.json"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	for _, e := range []domain.ListEntry{
+		{TenantID: "acme", List: domain.ListDeny, Entity: domain.EntityDevice, Value: "dev-1", Reason: "fraud"},
+		{List: domain.ListDeny, Entity: domain.EntityDevice, Value: "dev-2", Reason: "fraud"},
+	} {
+		if _, err := store.Add(e); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	if _, ok := store.Lookup(domain.Transaction{TenantID: "acme", DeviceID: "dev-1"}, domain.ListDeny); !ok {
+		t.Error("Expected a tenant entry to match its tenant")
+	}
+	if _, ok := store.Lookup(domain.Transaction{TenantID: "other", DeviceID: "dev-1"}, domain.ListDeny); ok {
+		t.Error("Expected a tenant entry not to match another tenant")
+	}
+	if _, ok := store.Lookup(domain.Transaction{TenantID: "other", DeviceID: "dev-2"}, domain.ListDeny); !ok {
+		t.Error("Expected an entry without a tenant to match every tenant")
+	}
+
+	if err := store.Remove("", domain.ListDeny, domain.EntityDevice, "dev-1"); !errors.Is(err, domain.ErrListEntryNotFound) {
+		t.Errorf("Expected a tenant entry to need its tenant to be removed, got %v", err)
+	}
+	if err := store.Remove("acme", domain.ListDeny, domain.EntityDevice, "dev-1"); err != nil {
+		t.Errorf("Remove: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+)
+
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// ErrCircuitOpen is returned without calling the provider while the breaker
+// is open.
+var ErrCircuitOpen = errors.New("llm provider circuit open")
+
+// Breaker stops calling the provider after threshold consecutive failures.
+// While open, calls fail immediately with ErrCircuitOpen; after the cooldown a
+// single trial call decides whether to close it again.
+type Breaker struct {
+	provider  string
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewBreaker returns a breaker for provider; a non-positive threshold
+// disables it.
+func NewBreaker(provider string, threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		return nil
+	}
+	b := &Breaker{provider: provider, threshold: threshold, cooldown: cooldown, now: time.Now, state: CircuitClosed}
+	metrics.SetCircuitState(provider, CircuitClosed)
+	return b
+}
+
+// Allow reports whether a call may go to the provider.
+func (b *Breaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.setState(CircuitHalfOpen)
+		b.trial = true
+		return nil
+	case CircuitHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record reports the outcome of an allowed call made on behalf of the
+// caller's ctx. Calls the caller cancelled or let run past its own deadline
+// say nothing about the provider and are not counted; a timeout of the
+// provider call itself is.
+func (b *Breaker) Record(ctx context.Context, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if err != nil && (ctx.Err() != nil || errors.Is(err, context.Canceled)) {
+		return
+	}
+	if err == nil {
+		b.failures = 0
+		b.setState(CircuitClosed)
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		b.setState(CircuitOpen)
+	}
+}
+
+// State returns the circuit state; a disabled breaker is always closed.
+func (b *Breaker) State() string {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// setState records a transition. Callers must hold b.mu.
+func (b *Breaker) setState(state string) {
+	if b.state != state {
+		b.state = state
+		metrics.SetCircuitState(b.provider, state)
+	}
+}
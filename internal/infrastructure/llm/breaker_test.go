@@ -0,0 +1,65 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker_OpensAndRecovers(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewBreaker("test", 2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	failure := errors.New("503")
+	for range 2 {
+		if err := b.Allow(); err != nil {
+			t.Fatalf("Expected a closed circuit to allow calls, got %v", err)
+		}
+		b.Record(context.Background(), failure)
+	}
+
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected the circuit to open after 2 failures, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Expected a trial call after the cooldown, got %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected a single trial call while half-open, got %v", err)
+	}
+
+	b.Record(context.Background(), nil)
+	if b.State() != CircuitClosed {
+		t.Errorf("Expected a successful trial to close the circuit, got %s", b.State())
+	}
+}
+
+func TestBreaker_IgnoresCallsAbandonedByTheCaller(t *testing.T) {
+	b := NewBreaker("test", 1, time.Minute)
+
+	_ = b.Allow()
+	b.Record(context.Background(), context.Canceled)
+	if b.State() != CircuitClosed {
+		t.Errorf("Expected a cancelled call not to open the circuit, got %s", b.State())
+	}
+
+	// The caller's own short deadline expired.
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	_ = b.Allow()
+	b.Record(ctx, context.DeadlineExceeded)
+	if b.State() != CircuitClosed {
+		t.Errorf("Expected the caller's deadline not to open the circuit, got %s", b.State())
+	}
+
+	// The provider call timed out while the caller was still waiting.
+	_ = b.Allow()
+	b.Record(context.Background(), context.DeadlineExceeded)
+	if b.State() != CircuitOpen {
+		t.Errorf("Expected a provider timeout to open the circuit, got %s", b.State())
+	}
+}
@@ -0,0 +1,89 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+)
+
+const (
+	rejectQueueFull    = "queue_full"
+	rejectQueueTimeout = "queue_timeout"
+)
+
+// Gate bounds the number of concurrent provider calls across all tenants.
+// Calls beyond the limit wait in a bounded queue; a call that finds the queue
+// full or waits longer than the timeout fails with domain.ErrLLMOverloaded so
+// the analyzer can apply its degradation policy.
+type Gate struct {
+	slots    chan struct{}
+	maxQueue int64
+	timeout  time.Duration
+	queued   atomic.Int64
+}
+
+// NewGate returns a gate for concurrent calls with up to queue waiters. A
+// non-positive concurrent disables the gate.
+func NewGate(concurrent, queue int, timeout time.Duration) *Gate {
+	if concurrent <= 0 {
+		return nil
+	}
+	return &Gate{
+		slots:    make(chan struct{}, concurrent),
+		maxQueue: int64(max(queue, 0)),
+		timeout:  timeout,
+	}
+}
+
+// Acquire takes a slot and returns the function that releases it.
+func (g *Gate) Acquire(ctx context.Context, tenant string) (func(), error) {
+	if g == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case g.slots <- struct{}{}:
+		metrics.LLMQueueWait.Observe(0)
+		return g.acquired(), nil
+	default:
+	}
+
+	if g.queued.Add(1) > g.maxQueue {
+		g.queued.Add(-1)
+		metrics.LLMRejected.WithLabelValues(tenant, rejectQueueFull).Inc()
+		return nil, fmt.Errorf("%w: queue full", domain.ErrLLMOverloaded)
+	}
+	metrics.LLMQueueDepth.Inc()
+	defer func() {
+		g.queued.Add(-1)
+		metrics.LLMQueueDepth.Dec()
+	}()
+
+	start := time.Now()
+	timer := time.NewTimer(g.timeout)
+	defer timer.Stop()
+
+	select {
+	case g.slots <- struct{}{}:
+		metrics.LLMQueueWait.Observe(time.Since(start).Seconds())
+		return g.acquired(), nil
+	case <-timer.C:
+		metrics.LLMQueueWait.Observe(time.Since(start).Seconds())
+		metrics.LLMRejected.WithLabelValues(tenant, rejectQueueTimeout).Inc()
+		return nil, fmt.Errorf("%w: waited %s for a slot", domain.ErrLLMOverloaded, g.timeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (g *Gate) acquired() func() {
+	metrics.LLMInFlight.Inc()
+	return func() {
+		metrics.LLMInFlight.Dec()
+		<-g.slots
+	}
+}
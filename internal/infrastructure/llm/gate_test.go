@@ -0,0 +1,68 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+func TestGate_QueueOverflowAndTimeout(t *testing.T) {
+	g := NewGate(1, 1, 20*time.Millisecond)
+
+	release, err := g.Acquire(context.Background(), "t")
+	if err != nil {
+		t.Fatalf("Expected a free slot, got %v", err)
+	}
+
+	waited := make(chan error, 1)
+	go func() {
+		_, err := g.Acquire(context.Background(), "t")
+		waited <- err
+	}()
+
+	// Wait until the goroutine is queued, then overflow the queue.
+	for g.queued.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := g.Acquire(context.Background(), "t"); !errors.Is(err, domain.ErrLLMOverloaded) {
+		t.Errorf("Expected queue overflow, got %v", err)
+	}
+
+	if err := <-waited; !errors.Is(err, domain.ErrLLMOverloaded) {
+		t.Errorf("Expected queue timeout, got %v", err)
+	}
+
+	release()
+	release, err = g.Acquire(context.Background(), "t")
+	if err != nil {
+		t.Fatalf("Expected the released slot to be reusable, got %v", err)
+	}
+	release()
+}
+
+func TestGate_QueuedCallGetsReleasedSlot(t *testing.T) {
+	g := NewGate(1, 1, time.Second)
+
+	release, _ := g.Acquire(context.Background(), "t")
+
+	acquired := make(chan error, 1)
+	go func() {
+		r, err := g.Acquire(context.Background(), "t")
+		if err == nil {
+			r()
+		}
+		acquired <- err
+	}()
+
+	for g.queued.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	release()
+
+	if err := <-acquired; err != nil {
+		t.Errorf("Expected the queued call to get the slot, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package llm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -11,19 +12,22 @@ import (
 	"sync"
 	"time"
 
-	"github.com/fsnotify/fsnotify"
 	"github.com/sashabaranov/go-openai"
 	"github.com/tokyosplif/ai-risk-engine/internal/config"
 	"github.com/tokyosplif/ai-risk-engine/internal/domain"
-	"github.com/tokyosplif/ai-risk-engine/pkg/closer"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+	"github.com/tokyosplif/ai-risk-engine/internal/tracing"
+	"github.com/tokyosplif/ai-risk-engine/pkg/watch"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 )
 
-const (
-	DefaultLLMTimeout = 15 * time.Second
-	maxIdleConns      = 50
-	idleConnTimeout   = 30 * time.Second
-	llmTemperature    = 0.1
-)
+var tracer = otel.Tracer("github.com/tokyosplif/ai-risk-engine/internal/infrastructure/llm")
+
+// BaseCurrencyPlaceholder in a prompt is replaced with the base currency
+// amounts are normalized to.
+const BaseCurrencyPlaceholder = "{base_currency}"
 
 type PromptConfig struct {
 	SystemRole        string   `json:"system_role"`
@@ -31,177 +35,310 @@ type PromptConfig struct {
 	OutputFormat      string   `json:"output_format"`
 }
 
+// GroqClient calls an OpenAI-compatible chat completion API on behalf of one
+// tenant. Clients derived with ForTenant share the prompts of their parent.
 type GroqClient struct {
-	client  *openai.Client
-	model   string
+	client        *openai.Client
+	cfg           config.GroqConfig
+	keys          *secrets.KeyRing
+	tenant        string
+	base          string
+	promptVersion string
+	prompts       *promptStore
+	gate          *Gate
+	breaker       *Breaker
+}
+
+type promptStore struct {
 	mu      sync.RWMutex
 	prompts map[string]PromptConfig
 }
 
-func NewGroqClient(cfg config.GroqConfig, promptsPath string) *GroqClient {
-	openaiCfg := openai.DefaultConfig(cfg.APIKey)
+func (s *promptStore) get(version string) (PromptConfig, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.prompts[version]
+	return p, ok
+}
+
+// NewGroqClient authenticates with keys, which may be rotated while the
+// client is in use. base is the currency amounts are normalized to; it fills
+// BaseCurrencyPlaceholder in the prompts.
+func NewGroqClient(cfg config.GroqConfig, keys *secrets.KeyRing, promptsPath, base string) *GroqClient {
+	gc := &GroqClient{
+		client:        newOpenAIClient(cfg, keys),
+		cfg:           cfg,
+		keys:          keys,
+		tenant:        domain.DefaultTenant,
+		base:          base,
+		promptVersion: cfg.PromptVersion,
+		prompts:       &promptStore{prompts: make(map[string]PromptConfig)},
+		gate:          NewGate(cfg.MaxConcurrent, cfg.MaxQueued, cfg.QueueTimeout),
+		breaker:       NewBreaker(cfg.BaseURL, cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+
+	gc.loadPrompts(promptsPath)
+
+	return gc
+}
+
+// newOpenAIClient leaves the client without a token; keyTransport sets one
+// per request.
+func newOpenAIClient(cfg config.GroqConfig, keys *secrets.KeyRing) *openai.Client {
+	openaiCfg := openai.DefaultConfig("")
 	openaiCfg.BaseURL = cfg.BaseURL
 
 	openaiCfg.HTTPClient = &http.Client{
-		Transport: &http.Transport{
-			MaxIdleConns:        maxIdleConns,
-			MaxIdleConnsPerHost: maxIdleConns,
-			IdleConnTimeout:     idleConnTimeout,
-			DisableCompression:  true,
+		Transport: &keyTransport{
+			base: &http.Transport{
+				MaxIdleConns:        cfg.MaxIdleConns,
+				MaxIdleConnsPerHost: cfg.MaxIdleConns,
+				IdleConnTimeout:     cfg.IdleConnTimeout,
+				DisableCompression:  true,
+			},
+			keys: keys,
 		},
 	}
 
-	gc := &GroqClient{
-		client:  openai.NewClientWithConfig(openaiCfg),
-		model:   cfg.Model,
-		prompts: make(map[string]PromptConfig),
+	return openai.NewClientWithConfig(openaiCfg)
+}
+
+// ForTenant returns a client for tenant. Empty fields of cfg, nil keys and an
+// empty version inherit g's provider, model, keys and prompt version. The
+// concurrency gate is always shared, so the limit holds across tenants.
+func (g *GroqClient) ForTenant(tenant string, cfg config.GroqConfig, keys *secrets.KeyRing, version string) *GroqClient {
+	t := &GroqClient{
+		client:        g.client,
+		cfg:           g.cfg,
+		keys:          g.keys,
+		tenant:        tenant,
+		base:          g.base,
+		promptVersion: g.promptVersion,
+		prompts:       g.prompts,
+		gate:          g.gate,
+		breaker:       g.breaker,
+	}
+	if cfg.Model != "" {
+		t.cfg.Model = cfg.Model
+	}
+	if version != "" {
+		t.promptVersion = version
+	}
+	if cfg.BaseURL != "" || keys != nil {
+		if cfg.BaseURL != "" {
+			t.cfg.BaseURL = cfg.BaseURL
+		}
+		if keys != nil {
+			t.keys = keys
+		}
+		t.client = newOpenAIClient(t.cfg, t.keys)
+		if t.cfg.BaseURL != g.cfg.BaseURL {
+			t.breaker = NewBreaker(t.cfg.BaseURL, t.cfg.BreakerThreshold, t.cfg.BreakerCooldown)
+		}
 	}
 
-	gc.loadPrompts(promptsPath)
+	if t.HasPrompt() {
+		metrics.SetPromptVersion(tenant, t.promptVersion)
+	}
+	return t
+}
 
-	go gc.WatchPrompts(context.Background(), promptsPath)
+// Credentials fails while the client has no usable API key.
+func (g *GroqClient) Credentials() error {
+	return g.keys.Ready()
+}
 
-	return gc
+// CircuitState returns the state of the provider circuit breaker.
+func (g *GroqClient) CircuitState() string {
+	return g.breaker.State()
+}
+
+// HasPrompt reports whether the client's prompt version is currently loaded.
+func (g *GroqClient) HasPrompt() bool {
+	_, ok := g.prompts.get(g.promptVersion)
+	return ok
 }
 
 func (g *GroqClient) loadPrompts(path string) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		slog.Error("failed to load prompts file", "path", path, "err", err)
+		metrics.PromptReloads.WithLabelValues("failure").Inc()
 		return
 	}
 
 	var newPrompts map[string]PromptConfig
 	if err := json.Unmarshal(data, &newPrompts); err != nil {
 		slog.Error("failed to parse prompts json", "err", err)
+		metrics.PromptReloads.WithLabelValues("failure").Inc()
 		return
 	}
 
-	g.mu.Lock()
-	g.prompts = newPrompts
-	g.mu.Unlock()
+	g.prompts.mu.Lock()
+	g.prompts.prompts = newPrompts
+	g.prompts.mu.Unlock()
+
+	metrics.PromptReloads.WithLabelValues("success").Inc()
+	if _, ok := newPrompts[g.promptVersion]; ok {
+		metrics.SetPromptVersion(g.tenant, g.promptVersion)
+	}
 
 	slog.Debug("ai prompts loaded/reloaded", "count", len(newPrompts))
 }
 
+// WatchPrompts reloads the prompts when the file at path is written,
+// replaced or created, until ctx is done.
 func (g *GroqClient) WatchPrompts(ctx context.Context, path string) {
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		slog.Error("failed to create watcher", "err", err)
-		return
-	}
-
-	defer closer.Close(watcher, "fsnotify watcher")
-
-	events := make(chan fsnotify.Event)
-	errs := make(chan error)
-
-	go func() {
-		for {
-			select {
-			case event, ok := <-watcher.Events:
-				if !ok {
-					return
-				}
-				events <- event
-			case e, ok := <-watcher.Errors:
-				if !ok {
-					return
-				}
-				errs <- e
-			}
-		}
-	}()
-
-	if err := watcher.Add(path); err != nil {
-		slog.Error("failed to add file to watcher", "path", path, "err", err)
-		return
-	}
-
-	for {
-		select {
-		case event := <-events:
-			if event.Op&fsnotify.Write == fsnotify.Write {
-				slog.Info("Detected change in prompts file, reloading...", "path", path)
-				g.loadPrompts(path)
-			}
-		case e := <-errs:
-			slog.Error("watcher error", "err", e)
-		case <-ctx.Done():
-			slog.Debug("stopping prompts watcher", "path", path)
-			return
-		}
-	}
+	watch.Files(ctx, "prompts", g.loadPrompts, path)
 }
 
 func (g *GroqClient) buildPrompt(version string, userProfile string) string {
-	g.mu.RLock()
-	p, ok := g.prompts[version]
-	g.mu.RUnlock()
-
+	p, ok := g.prompts.get(version)
 	if !ok {
 		return "Analyze for fraud. Return JSON."
 	}
 
 	protocols := strings.Join(p.SecurityProtocols, "\n- ")
-	return fmt.Sprintf("%s\n\nUSER CONTEXT: %s\n\nPROTOCOLS:\n- %s\n\n%s",
+	prompt := fmt.Sprintf("%s\n\nUSER CONTEXT: %s\n\nPROTOCOLS:\n- %s\n\n%s",
 		p.SystemRole, userProfile, protocols, p.OutputFormat)
+	return strings.ReplaceAll(prompt, BaseCurrencyPlaceholder, g.base)
 }
 
 func (g *GroqClient) Analyze(ctx context.Context, txData string, userProfile string) (domain.RiskAssessment, error) {
-	ctx, cancel := context.WithTimeout(ctx, DefaultLLMTimeout)
+	release, err := g.gate.Acquire(ctx, g.tenant)
+	if err != nil {
+		slog.WarnContext(ctx, "ai provider call not admitted", "err", err)
+		return domain.RiskAssessment{}, err
+	}
+	defer release()
+
+	if err := g.breaker.Allow(); err != nil {
+		metrics.LLMErrors.WithLabelValues(g.tenant, g.cfg.Model, metrics.ErrClassCircuitOpen).Inc()
+		return domain.RiskAssessment{}, err
+	}
+
+	callerCtx := ctx
+	ctx, cancel := context.WithTimeout(ctx, g.cfg.Timeout)
 	defer cancel()
 
-	systemPrompt := g.buildPrompt("antifraud_v1", userProfile)
+	systemPrompt := g.buildPrompt(g.promptVersion, userProfile)
+	userPrompt := fmt.Sprintf("Analyze Transaction: %s", txData)
 
+	trace := &domain.LLMTrace{
+		Model:         g.cfg.Model,
+		PromptVersion: g.promptVersion,
+		SystemPrompt:  systemPrompt,
+		UserPrompt:    userPrompt,
+	}
+
+	ctx, span := tracer.Start(ctx, "openai.CreateChatCompletion")
+	defer span.End()
+
+	span.SetAttributes(
+		tracing.AttrModel.String(g.cfg.Model),
+		tracing.AttrPromptVersion.String(g.promptVersion),
+	)
+
+	start := time.Now()
 	resp, err := g.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: g.model,
+		Model: g.cfg.Model,
 		Messages: []openai.ChatCompletionMessage{
 			{Role: openai.ChatMessageRoleSystem, Content: systemPrompt},
-			{Role: openai.ChatMessageRoleUser, Content: fmt.Sprintf("Analyze Transaction: %s", txData)},
+			{Role: openai.ChatMessageRoleUser, Content: userPrompt},
 		},
 		ResponseFormat: &openai.ChatCompletionResponseFormat{
 			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
 		},
-		Temperature: llmTemperature,
+		Temperature: float32(g.cfg.Temperature),
 	})
+	metrics.LLMDuration.WithLabelValues(g.tenant, g.cfg.Model).Observe(time.Since(start).Seconds())
+	g.breaker.Record(callerCtx, err)
 
 	if err != nil {
-		slog.Error("ai provider request failed", "err", err)
-		return domain.RiskAssessment{}, err
+		err = g.keys.Scrub(err)
+		slog.ErrorContext(ctx, "ai provider request failed", "err", err)
+		metrics.LLMErrors.WithLabelValues(g.tenant, g.cfg.Model, classifyError(err)).Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "ai provider request failed")
+		return domain.RiskAssessment{LLM: trace}, err
 	}
 
+	trace.PromptTokens = resp.Usage.PromptTokens
+	trace.CompletionTokens = resp.Usage.CompletionTokens
+
+	span.SetAttributes(
+		tracing.AttrPromptTokens.Int(resp.Usage.PromptTokens),
+		tracing.AttrCompletionTokens.Int(resp.Usage.CompletionTokens),
+	)
+
+	metrics.LLMTokens.WithLabelValues(g.tenant, g.cfg.Model, "prompt").Add(float64(resp.Usage.PromptTokens))
+	metrics.LLMTokens.WithLabelValues(g.tenant, g.cfg.Model, "completion").Add(float64(resp.Usage.CompletionTokens))
+
 	if len(resp.Choices) == 0 {
-		slog.Error("ai provider returned empty choices")
-		return domain.RiskAssessment{}, fmt.Errorf("empty choices from ai provider")
+		slog.ErrorContext(ctx, "ai provider returned empty choices")
+		metrics.LLMErrors.WithLabelValues(g.tenant, g.cfg.Model, metrics.ErrClassEmptyChoices).Inc()
+		return domain.RiskAssessment{LLM: trace}, fmt.Errorf("empty choices from ai provider")
 	}
 
 	content := resp.Choices[0].Message.Content
+	trace.RawResponse = content
 	if strings.TrimSpace(content) == "" {
-		slog.Error("ai provider returned empty content in choice")
-		return domain.RiskAssessment{}, fmt.Errorf("empty content from ai provider")
+		slog.ErrorContext(ctx, "ai provider returned empty content in choice")
+		metrics.LLMErrors.WithLabelValues(g.tenant, g.cfg.Model, metrics.ErrClassEmptyContent).Inc()
+		return domain.RiskAssessment{LLM: trace}, fmt.Errorf("empty content from ai provider")
 	}
 
 	var res domain.RiskAssessment
 
 	if err := json.Unmarshal([]byte(content), &res); err != nil {
-		slog.Error("ai response parse failed", "content", content, "err", err)
-		return domain.RiskAssessment{}, err
+		slog.ErrorContext(ctx, "ai response parse failed", "content_length", len(content), "err", err)
+		metrics.LLMErrors.WithLabelValues(g.tenant, g.cfg.Model, metrics.ErrClassParseFailure).Inc()
+		span.SetStatus(codes.Error, "ai response parse failed")
+		return domain.RiskAssessment{LLM: trace}, err
 	}
+	res.LLM = trace
+	res.Explanation.LLMRationale = res.Reason
 
 	if !res.IsBlocked {
 		reasonLower := strings.ToLower(res.Reason)
-		suspicious := []string{"exceeds", "high-risk", "anomaly", "binance", "suspicious", "nigeria", "singapore"}
+		suspicious := []string{"exceeds", "high-risk", "anomaly", "suspicious"}
 		for _, word := range suspicious {
 			if strings.Contains(reasonLower, word) {
 				res.IsBlocked = true
 				res.Reason = "[Heuristic Block] " + res.Reason
-				slog.Warn("heuristic block triggered", "pattern", word)
+				res.Overrides = append(res.Overrides, domain.Override{
+					Stage:         domain.StageLLMPostprocess,
+					Rule:          "suspicious_keyword:" + word,
+					BlockedBefore: false,
+					BlockedAfter:  true,
+					Reason:        res.Reason,
+				})
+				slog.WarnContext(ctx, "heuristic block triggered", "pattern", word)
+				metrics.RulesFired.WithLabelValues(g.tenant, "[Heuristic Block]").Inc()
 				break
 			}
 		}
 	}
 
-	slog.Debug("risk analysis complete", "blocked", res.IsBlocked, "reason", res.Reason)
+	span.SetAttributes(tracing.AttrDecision.String(res.Decision()))
+	slog.DebugContext(ctx, "risk analysis complete", "blocked", res.IsBlocked, "reason", res.Reason)
 	return res, nil
 }
+
+func classifyError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return metrics.ErrClassTimeout
+	}
+
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) && apiErr.HTTPStatusCode == http.StatusTooManyRequests {
+		return metrics.ErrClassRateLimited
+	}
+
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) && reqErr.HTTPStatusCode == http.StatusTooManyRequests {
+		return metrics.ErrClassRateLimited
+	}
+
+	return metrics.ErrClassProviderOther
+}
@@ -0,0 +1,24 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildPrompt_FillsBaseCurrency(t *testing.T) {
+	g := &GroqClient{
+		base: "EUR",
+		prompts: &promptStore{prompts: map[string]PromptConfig{
+			"v1": {
+				SystemRole:        "Analyst.",
+				SecurityProtocols: []string{"Amounts are in {base_currency}.", "Block above 1000 {base_currency}."},
+				OutputFormat:      "JSON.",
+			},
+		}},
+	}
+
+	prompt := g.buildPrompt("v1", "MaxTx: 100")
+	if strings.Contains(prompt, BaseCurrencyPlaceholder) || strings.Count(prompt, "EUR") != 2 {
+		t.Errorf("Expected the base currency in every protocol, got %q", prompt)
+	}
+}
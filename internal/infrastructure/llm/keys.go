@@ -0,0 +1,58 @@
+package llm
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+)
+
+// keyTransport authenticates each provider request with a key from the ring.
+// A key answered with 429 is benched and one answered with 401 or 403 is
+// rejected (see secrets.KeyRing.Rejected); the request is then retried with
+// the next key, so a single bad key costs one round trip rather than a
+// degraded verdict.
+type keyTransport struct {
+	base http.RoundTripper
+	keys *secrets.KeyRing
+}
+
+func (t *keyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempts := max(t.keys.Len(), 1)
+	for attempt := 1; ; attempt++ {
+		key, err := t.keys.Pick()
+		if err != nil {
+			return nil, err
+		}
+
+		r := req.Clone(req.Context())
+		if attempt > 1 {
+			if r.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		r.Header.Set("Authorization", "Bearer "+key.Value.Reveal())
+
+		resp, err := t.base.RoundTrip(r)
+		if err != nil {
+			return nil, err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests:
+			t.keys.RateLimited(key)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			t.keys.Rejected(key)
+		default:
+			return resp, nil
+		}
+
+		if attempt >= attempts || req.GetBody == nil {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		slog.DebugContext(req.Context(), "retrying provider call with the next key", "credential", t.keys.Name(), "status", resp.StatusCode)
+	}
+}
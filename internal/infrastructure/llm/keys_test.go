@@ -0,0 +1,66 @@
+package llm
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/secrets"
+)
+
+func TestKeyTransport_FailsOverRejectedKeys(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		switch r.Header.Get("Authorization") {
+		case "Bearer limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "Bearer revoked":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			_, _ = io.WriteString(w, "ok")
+		}
+	}))
+	defer srv.Close()
+
+	keys, err := secrets.NewKeyRing("llm api key", []secrets.Value{"limited", "revoked", "good"}, "", secrets.RoundRobin, time.Minute)
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	client := &http.Client{Transport: &keyTransport{base: http.DefaultTransport, keys: keys}}
+
+	resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{"model":"m"}`))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected the third key to succeed, got %d", resp.StatusCode)
+	}
+	if len(bodies) != 3 || bodies[2] != `{"model":"m"}` {
+		t.Errorf("Expected the body to be replayed on every attempt, got %q", bodies)
+	}
+
+	for range 3 {
+		k, err := keys.Pick()
+		if err != nil {
+			t.Fatalf("Pick: %v", err)
+		}
+		if k.Value.Reveal() != "good" {
+			t.Errorf("Expected only the good key to stay in rotation, got %s", k.ID)
+		}
+	}
+}
+
+func TestKeyTransport_NoKeys(t *testing.T) {
+	client := &http.Client{Transport: &keyTransport{base: http.DefaultTransport, keys: secrets.Static()}}
+
+	if _, err := client.Get("http://127.0.0.1:0"); err == nil || !strings.Contains(err.Error(), "no usable key") {
+		t.Errorf("Expected the call to fail without a key, got %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package merchants
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/pkg/atomicfile"
+	"github.com/tokyosplif/ai-risk-engine/pkg/watch"
+)
+
+// Catalog holds the merchant catalog. Like the allow/deny lists, the JSON file
+// at path is the source of truth: admin changes are written back to it and
+// external edits are picked up by Watch.
+type Catalog struct {
+	path string
+
+	mu        sync.RWMutex
+	merchants []domain.Merchant
+	index     map[string]int
+	// loadErr is the error of the last load. While it is set, admin changes
+	// are refused so that they do not overwrite the file being fixed.
+	loadErr error
+}
+
+// NewCatalog loads the catalog at path, creating an empty one if it does not
+// exist. A file that cannot be loaded is an error: starting with an empty
+// catalog would let high-risk merchants through the prescreen.
+func NewCatalog(path string) (*Catalog, error) {
+	c := &Catalog{path: path}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := c.persist(); err != nil {
+			return nil, fmt.Errorf("failed to create merchant catalog: %w", err)
+		}
+		return c, nil
+	}
+
+	if err := c.load(); err != nil {
+		return nil, fmt.Errorf("failed to load merchant catalog: %w", err)
+	}
+	return c, nil
+}
+
+// load replaces the catalog with the file's. On error the current catalog is
+// kept and admin changes are refused until a load succeeds.
+func (c *Catalog) load() error {
+	merchants, err := c.read()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadErr = err
+	if err != nil {
+		return err
+	}
+	c.merchants = merchants
+	c.index = buildIndex(merchants)
+
+	slog.Debug("merchant catalog loaded/reloaded", "count", len(merchants))
+	return nil
+}
+
+func (c *Catalog) read() ([]domain.Merchant, error) {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var merchants []domain.Merchant
+	if err := json.Unmarshal(data, &merchants); err != nil {
+		return nil, fmt.Errorf("failed to parse merchant catalog json: %w", err)
+	}
+	for i := range merchants {
+		merchants[i] = normalize(merchants[i])
+		if err := validate(merchants[i]); err != nil {
+			return nil, err
+		}
+	}
+	return merchants, nil
+}
+
+// persist replaces the file through a temporary file, so a crash mid-write
+// never leaves it truncated. Callers must hold c.mu.
+func (c *Catalog) persist() error {
+	if c.loadErr != nil {
+		return fmt.Errorf("merchant catalog failed to load, fix it before changing the catalog: %w", c.loadErr)
+	}
+	merchants := c.merchants
+	if merchants == nil {
+		merchants = []domain.Merchant{}
+	}
+	data, err := json.MarshalIndent(merchants, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(c.path, data, 0o640)
+}
+
+// Match resolves a card descriptor such as "BINANCE.COM*8817 LONDON" to a
+// catalog merchant.
+func (c *Catalog) Match(descriptor string) (domain.Merchant, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i, ok := match(c.index, descriptor)
+	if !ok {
+		return domain.Merchant{}, false
+	}
+	return c.merchants[i], true
+}
+
+// Upsert adds m or replaces the merchant with the same ID.
+func (c *Catalog) Upsert(m domain.Merchant) (domain.Merchant, error) {
+	m = normalize(m)
+	if err := validate(m); err != nil {
+		return domain.Merchant{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev := c.merchants
+	c.merchants = append(slices.DeleteFunc(slices.Clone(c.merchants), func(existing domain.Merchant) bool {
+		return existing.ID == m.ID
+	}), m)
+	if err := c.persist(); err != nil {
+		c.merchants = prev
+		return domain.Merchant{}, err
+	}
+	c.index = buildIndex(c.merchants)
+	return m, nil
+}
+
+func (c *Catalog) Remove(id string) error {
+	id = normalizeID(id)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev := c.merchants
+	c.merchants = slices.DeleteFunc(slices.Clone(c.merchants), func(existing domain.Merchant) bool {
+		return existing.ID == id
+	})
+	if len(c.merchants) == len(prev) {
+		c.merchants = prev
+		return domain.ErrMerchantNotFound
+	}
+	if err := c.persist(); err != nil {
+		c.merchants = prev
+		return err
+	}
+	c.index = buildIndex(c.merchants)
+	return nil
+}
+
+// List returns catalog merchants, optionally filtered by category.
+func (c *Catalog) List(category string) []domain.Merchant {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var out []domain.Merchant
+	for _, m := range c.merchants {
+		if category != "" && m.Category != category {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// Watch reloads the catalog when its file changes until ctx is done.
+func (c *Catalog) Watch(ctx context.Context) {
+	watch.Files(ctx, "merchant catalog", func(string) {
+		if err := c.load(); err != nil {
+			slog.Error("failed to reload merchant catalog, keeping current catalog", "path", c.path, "err", err)
+		}
+	}, c.path)
+}
+
+func normalize(m domain.Merchant) domain.Merchant {
+	m.Name = strings.TrimSpace(m.Name)
+	m.ID = normalizeID(m.ID)
+	if m.ID == "" {
+		m.ID = normalizeID(m.Name)
+	}
+	m.MCC = strings.TrimSpace(m.MCC)
+	m.Category = strings.ToLower(strings.TrimSpace(m.Category))
+	m.RiskTier = strings.ToLower(strings.TrimSpace(m.RiskTier))
+	if m.RiskTier == "" {
+		m.RiskTier = domain.RiskTierMedium
+	}
+	return m
+}
+
+func normalizeID(id string) string {
+	return strings.Join(tokens(id), "_")
+}
+
+func validate(m domain.Merchant) error {
+	if m.ID == "" || m.Name == "" {
+		return fmt.Errorf("%w: name is required", domain.ErrInvalidMerchant)
+	}
+	if m.Category == "" {
+		return fmt.Errorf("%w: category is required", domain.ErrInvalidMerchant)
+	}
+	if m.MCC != "" && (len(m.MCC) != 4 || strings.Trim(m.MCC, "0123456789") != "") {
+		return fmt.Errorf("%w: mcc %q must be 4 digits", domain.ErrInvalidMerchant, m.MCC)
+	}
+	switch m.RiskTier {
+	case domain.RiskTierLow, domain.RiskTierMedium, domain.RiskTierHigh:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown risk tier %q", domain.ErrInvalidMerchant, m.RiskTier)
+	}
+}
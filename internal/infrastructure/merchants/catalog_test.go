@@ -0,0 +1,123 @@
+package merchants
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+func newTestCatalog(t *testing.T, path string) *Catalog {
+	t.Helper()
+	catalog, err := NewCatalog(path)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+	return catalog
+}
+
+func TestCatalog_Match(t *testing.T) {
+	catalog := newTestCatalog(t, filepath.Join(t.TempDir(), "merchants.json"))
+
+	merchants := []domain.Merchant{
+		{Name: "Binance", MCC: "6051", Category: domain.CategoryCryptoExchange, RiskTier: domain.RiskTierHigh, Aliases: []string{"binance.com"}},
+		{Name: "Apple Store", MCC: "5732", Category: domain.CategoryElectronics, Aliases: []string{"apple.com/bill"}},
+		{Name: "Apple", MCC: "5818", Category: domain.CategoryDigitalGoods},
+		{Name: "Coinbase", MCC: "6051", Category: domain.CategoryCryptoExchange, RiskTier: domain.RiskTierHigh},
+		{Name: "Steam", MCC: "5816", Category: domain.CategoryDigitalGoods},
+	}
+	for _, m := range merchants {
+		if _, err := catalog.Upsert(m); err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+	}
+
+	cases := []struct {
+		descriptor, id string
+	}{
+		{"BINANCE.COM*8817 LONDON", "binance"},
+		{"POS Binanse", "binance"},
+		{"APPLE STORE #0042 KYIV", "apple_store"},
+		{"apple.com/bill", "apple_store"},
+		{"Apple", "apple"},
+		{"COINBSE 4411", "coinbase"},
+		{"STEAM PURCHASE", "steam"},
+	}
+	for _, c := range cases {
+		m, ok := catalog.Match(c.descriptor)
+		if !ok || m.ID != c.id {
+			t.Errorf("Match(%q) = %q, %v; want %q", c.descriptor, m.ID, ok, c.id)
+		}
+	}
+
+	for _, descriptor := range []string{"Corner Shop 17", "STREAM TV", "Steem", "Doinbase"} {
+		if m, ok := catalog.Match(descriptor); ok {
+			t.Errorf("Match(%q): expected no match, got %q", descriptor, m.ID)
+		}
+	}
+}
+
+func TestCatalog_PersistAndValidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "merchants.json")
+	catalog := newTestCatalog(t, path)
+
+	if _, err := catalog.Upsert(domain.Merchant{Name: "Paxful", Category: "p2p_transfer", RiskTier: "extreme"}); !errors.Is(err, domain.ErrInvalidMerchant) {
+		t.Errorf("Expected ErrInvalidMerchant for unknown tier, got %v", err)
+	}
+	if _, err := catalog.Upsert(domain.Merchant{Name: "Paxful", MCC: "60511", Category: "p2p_transfer"}); !errors.Is(err, domain.ErrInvalidMerchant) {
+		t.Errorf("Expected ErrInvalidMerchant for bad MCC, got %v", err)
+	}
+
+	m, err := catalog.Upsert(domain.Merchant{Name: "Paxful", Category: "P2P_Transfer"})
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if m.ID != "paxful" || m.RiskTier != domain.RiskTierMedium || m.Category != domain.CategoryP2PTransfer {
+		t.Errorf("Unexpected normalized merchant: %+v", m)
+	}
+
+	reloaded := newTestCatalog(t, path)
+	if _, ok := reloaded.Match("paxful"); !ok {
+		t.Errorf("Expected merchant to survive a reload")
+	}
+
+	if err := reloaded.Remove("paxful"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := reloaded.Remove("paxful"); !errors.Is(err, domain.ErrMerchantNotFound) {
+		t.Errorf("Expected ErrMerchantNotFound, got %v", err)
+	}
+}
+
+func TestCatalog_RefusesToOverwriteBrokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "merchants.json")
+	broken := []byte(`[{"name":"Binance","category":"crypto_exchange"`)
+	if err := os.WriteFile(path, broken, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCatalog(path); err == nil {
+		t.Fatal("Expected a broken catalog to fail startup")
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"name":"Binance","category":"crypto_exchange"}]`), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	catalog := newTestCatalog(t, path)
+	if err := os.WriteFile(path, broken, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.load(); err == nil {
+		t.Fatal("Expected the reload to fail")
+	}
+	if _, ok := catalog.Match("BINANCE"); !ok {
+		t.Error("Expected the loaded catalog to stay in effect")
+	}
+	if _, err := catalog.Upsert(domain.Merchant{Name: "Paxful", Category: "p2p_transfer"}); err == nil {
+		t.Error("Expected Upsert to be refused while the file is broken")
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(broken) {
+		t.Errorf("Expected the broken file to be left for fixing, got %s", data)
+	}
+}
@@ -0,0 +1,159 @@
+package merchants
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+const (
+	// minFuzzySimilarity is the share of characters that must agree for a
+	// misspelt descriptor ("BINANSE") to match: one edit in seven.
+	minFuzzySimilarity = 0.85
+	// minFuzzyLength keeps short names, where a single edit turns one real
+	// merchant into another ("Stream" and "Steam"), to exact matches.
+	minFuzzyLength = 7
+)
+
+// noiseTokens are descriptor fragments added by acquirers and payment
+// processors that never identify the merchant.
+var noiseTokens = map[string]struct{}{
+	"pos": {}, "purchase": {}, "payment": {}, "pmt": {}, "sq": {},
+	"inc": {}, "ltd": {}, "llc": {}, "gmbh": {}, "the": {},
+	"www": {}, "com": {}, "net": {}, "org": {},
+}
+
+// tokens lower-cases s, splits it on anything but letters and digits and
+// drops processor noise and store numbers.
+func tokens(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	out := fields[:0]
+	for _, f := range fields {
+		if _, noise := noiseTokens[f]; noise {
+			continue
+		}
+		if len(f) >= 3 && strings.Trim(f, "0123456789") == "" {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// buildIndex maps the normalized name and every alias to the merchant's
+// position in merchants.
+func buildIndex(merchants []domain.Merchant) map[string]int {
+	index := make(map[string]int)
+	for i, m := range merchants {
+		for _, name := range append([]string{m.Name}, m.Aliases...) {
+			if key := strings.Join(tokens(name), " "); key != "" {
+				index[key] = i
+			}
+		}
+	}
+	return index
+}
+
+// match tries, in order: the whole descriptor, a catalog name appearing as a
+// run of descriptor tokens (longest wins), and a near-miss spelling of a long
+// one.
+func match(index map[string]int, descriptor string) (int, bool) {
+	toks := tokens(descriptor)
+	if len(toks) == 0 {
+		return 0, false
+	}
+
+	if i, ok := index[strings.Join(toks, " ")]; ok {
+		return i, true
+	}
+
+	best, bestKey := -1, ""
+	for key, i := range index {
+		if containsRun(toks, strings.Split(key, " ")) && better(key, bestKey) {
+			best, bestKey = i, key
+		}
+	}
+	if best >= 0 {
+		return best, true
+	}
+
+	bestScore := 0.0
+	for key, i := range index {
+		if len(key) < minFuzzyLength {
+			continue
+		}
+		n := strings.Count(key, " ") + 1
+		for start := 0; start+n <= len(toks); start++ {
+			candidate := strings.Join(toks[start:start+n], " ")
+			// Misspellings rarely touch the first letter; requiring it
+			// keeps unrelated names with a similar shape apart.
+			if candidate[0] != key[0] {
+				continue
+			}
+			score := similarity(key, candidate)
+			if score >= minFuzzySimilarity && (score > bestScore || (score == bestScore && better(key, bestKey))) {
+				best, bestKey, bestScore = i, key, score
+			}
+		}
+	}
+	return best, best >= 0
+}
+
+// better prefers longer keys and breaks ties alphabetically so that matching
+// does not depend on map iteration order.
+func better(key, current string) bool {
+	if len(key) != len(current) {
+		return len(key) > len(current)
+	}
+	return key < current
+}
+
+func containsRun(toks, run []string) bool {
+	for start := 0; start+len(run) <= len(toks); start++ {
+		ok := true
+		for j, t := range run {
+			if toks[start+j] != t {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
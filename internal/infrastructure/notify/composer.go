@@ -0,0 +1,330 @@
+// Package notify composes the push message shown to the user for a decision
+// and sends it through a notifier.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/pkg/watch"
+)
+
+// Policies the model's message can fail. A message that fails one is
+// replaced by a template.
+const (
+	RejectEmpty   = "empty"
+	RejectStale   = "stale"
+	RejectLocale  = "locale"
+	RejectLength  = "length"
+	RejectContent = "content"
+	RejectTone    = "tone"
+)
+
+const maxMerchantLen = 40
+
+var (
+	localeRegex = regexp.MustCompile(`(?i)\b(?:lang(?:uage)?|locale)\s*[:=]\s*([a-z]{2,3}(?:[-_][a-z0-9]{2,8})?)\b`)
+
+	// builtinForbidden keeps links, email addresses and phone numbers, card
+	// numbers, one-time codes and the engine's internal tags out of messages.
+	// Dates and amounts are left alone.
+	builtinForbidden = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`),
+		regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}\b`),
+		regexp.MustCompile(`\+\d[\d\s().-]{6,}\d|\(?\b\d{3}\)?[\s.-]\d{3}[\s.-]\d{4}\b`),
+		regexp.MustCompile(`\b\d(?:[\s-]?\d){12,18}\b`),
+		regexp.MustCompile(`(?i)\b(?:code|otp|pin|passcode|password)\b\D{0,20}\b\d{4,8}\b|\b\d{4,8}\b\D{0,20}\b(?:code|otp|pin|passcode)\b`),
+		regexp.MustCompile(`\[[A-Za-z][A-Za-z ]+\]`),
+	}
+)
+
+// Policy is the content of the templates file.
+type Policy struct {
+	// DefaultLocale is used when the profile names no locale, or one without
+	// templates.
+	DefaultLocale string `json:"default_locale"`
+	// MaxLength is the longest message, in characters.
+	MaxLength int `json:"max_length"`
+	// LLMLocales are the locales the model writes messages in. Users with
+	// another locale get a template.
+	LLMLocales []string `json:"llm_locales"`
+	// Forbidden are extra regular expressions a model message must not
+	// match.
+	Forbidden []string `json:"forbidden"`
+	// Templates maps a decision and locale to a message. {amount} and
+	// {merchant} are replaced with the transaction's.
+	Templates map[string]map[string]string `json:"templates"`
+
+	forbidden []*regexp.Regexp
+}
+
+// DefaultPolicy is used until a templates file is loaded.
+func DefaultPolicy() Policy {
+	p := Policy{
+		DefaultLocale: "en",
+		MaxLength:     160,
+		LLMLocales:    []string{"en"},
+		Templates: map[string]map[string]string{
+			domain.DecisionAllow:  {"en": "Your payment of {amount} at {merchant} was approved."},
+			domain.DecisionBlock:  {"en": "We declined your payment of {amount} at {merchant} to protect your account. If it was you, please contact support."},
+			domain.DecisionReview: {"en": "Your payment of {amount} at {merchant} is being checked. We will let you know shortly."},
+		},
+	}
+	_ = p.compile()
+	return p
+}
+
+// compile normalizes the locales and checks that every decision has a
+// template in the default locale.
+func (p *Policy) compile() error {
+	p.DefaultLocale = normalizeLocale(p.DefaultLocale)
+	if p.DefaultLocale == "" {
+		return errors.New("default_locale is required")
+	}
+	if p.MaxLength <= 0 {
+		return errors.New("max_length must be positive")
+	}
+	for i, l := range p.LLMLocales {
+		p.LLMLocales[i] = normalizeLocale(l)
+	}
+
+	p.forbidden = p.forbidden[:0]
+	for _, expr := range p.Forbidden {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("forbidden pattern %q: %w", expr, err)
+		}
+		p.forbidden = append(p.forbidden, re)
+	}
+
+	templates := make(map[string]map[string]string, len(p.Templates))
+	for decision, byLocale := range p.Templates {
+		normalized := make(map[string]string, len(byLocale))
+		for locale, text := range byLocale {
+			normalized[normalizeLocale(locale)] = strings.TrimSpace(text)
+		}
+		templates[strings.ToLower(decision)] = normalized
+	}
+	p.Templates = templates
+	for _, decision := range []string{domain.DecisionAllow, domain.DecisionBlock, domain.DecisionReview} {
+		if p.Templates[decision][p.DefaultLocale] == "" {
+			return fmt.Errorf("no %s template for the default locale %q", decision, p.DefaultLocale)
+		}
+	}
+	return nil
+}
+
+// Composer writes the push message for a decision: the model's message when
+// it passes every policy, otherwise the decision's template in the user's
+// locale. The templates file at path is reloaded when it changes.
+type Composer struct {
+	path string
+
+	mu     sync.RWMutex
+	policy Policy
+}
+
+// NewComposer loads the templates file at path. A missing file leaves the
+// built-in English templates in place until it is created.
+func NewComposer(path string) (*Composer, error) {
+	c := &Composer{path: path, policy: DefaultPolicy()}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		slog.Warn("notification templates not found, using built-in templates", "path", path)
+		return c, nil
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Composer) load() error {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return err
+	}
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return fmt.Errorf("failed to parse notification templates json: %w", err)
+	}
+	if err := p.compile(); err != nil {
+		return fmt.Errorf("invalid notification templates: %w", err)
+	}
+
+	c.mu.Lock()
+	c.policy = p
+	c.mu.Unlock()
+
+	slog.Debug("notification templates loaded/reloaded", "path", c.path, "decisions", len(p.Templates))
+	return nil
+}
+
+// Watch reloads the templates file when it is written, replaced or created,
+// until ctx is done. A file that fails to load leaves the current templates
+// in place.
+func (c *Composer) Watch(ctx context.Context) {
+	watch.Files(ctx, "notification templates", func(string) {
+		if err := c.load(); err != nil {
+			slog.Error("failed to reload notification templates", "path", c.path, "err", err)
+		}
+	}, c.path)
+}
+
+// Compose returns the message for the final verdict on tx. llm is the
+// model's verdict before overrides; it is empty when the model did not
+// decide.
+func (c *Composer) Compose(tx domain.Transaction, final, llm domain.RiskAssessment) domain.Notification {
+	c.mu.RLock()
+	p := c.policy
+	c.mu.RUnlock()
+
+	decision := final.Decision()
+	n := domain.Notification{
+		TenantID:      tx.TenantID,
+		TransactionID: tx.ID,
+		UserID:        tx.UserID,
+		Decision:      decision,
+	}
+
+	wanted := ProfileLocale(tx.UserProfile)
+	text, locale := p.template(decision, wanted)
+	n.Locale = locale
+
+	n.Rejected = p.check(final.AIPushMessage, decision, llm.Decision(), wanted)
+	if n.Rejected == "" {
+		n.Text = strings.TrimSpace(final.AIPushMessage)
+		n.Source = domain.MessageSourceLLM
+		if wanted != "" {
+			n.Locale = wanted
+		}
+		return n
+	}
+
+	n.Text = truncate(render(text, tx), p.MaxLength)
+	n.Source = domain.MessageSourceTemplate
+	return n
+}
+
+// check returns the policy msg fails, or "" if it may be shown to a user
+// with locale wanted. llmDecision is the decision msg was written for.
+func (p Policy) check(msg, decision, llmDecision, wanted string) string {
+	msg = strings.TrimSpace(msg)
+	switch {
+	case msg == "":
+		return RejectEmpty
+	case llmDecision != decision:
+		// An override changed the outcome the model wrote the message for.
+		return RejectStale
+	case !p.llmLocale(wanted):
+		return RejectLocale
+	case utf8.RuneCountInString(msg) > p.MaxLength:
+		return RejectLength
+	case !cleanText(msg) || slices.ContainsFunc(builtinForbidden, func(re *regexp.Regexp) bool { return re.MatchString(msg) }) ||
+		slices.ContainsFunc(p.forbidden, func(re *regexp.Regexp) bool { return re.MatchString(msg) }):
+		return RejectContent
+	case shouting(msg):
+		return RejectTone
+	}
+	return ""
+}
+
+// llmLocale reports whether the model's messages suit locale: its language
+// is one the model writes in. An unknown locale means the default one.
+func (p Policy) llmLocale(locale string) bool {
+	if locale == "" {
+		locale = p.DefaultLocale
+	}
+	return slices.Contains(p.LLMLocales, locale) || slices.Contains(p.LLMLocales, language(locale))
+}
+
+// template returns the decision's template for locale, falling back to its
+// language and then to the default locale, and the locale it is written in.
+func (p Policy) template(decision, locale string) (string, string) {
+	byLocale := p.Templates[decision]
+	for _, l := range []string{locale, language(locale)} {
+		if text := byLocale[l]; l != "" && text != "" {
+			return text, l
+		}
+	}
+	return byLocale[p.DefaultLocale], p.DefaultLocale
+}
+
+// ProfileLocale returns the locale named in a user profile ("Lang: uk",
+// "Locale: pt-BR"), normalized to lower case with a hyphen, or "".
+func ProfileLocale(profile string) string {
+	m := localeRegex.FindStringSubmatch(profile)
+	if m == nil {
+		return ""
+	}
+	return normalizeLocale(m[1])
+}
+
+func normalizeLocale(l string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(l)), "_", "-")
+}
+
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
+func render(text string, tx domain.Transaction) string {
+	amount := tx.Amount
+	if tx.OriginalAmount.Currency != "" {
+		amount = tx.OriginalAmount
+	}
+	merchant := tx.Merchant
+	if tx.MerchantInfo != nil && tx.MerchantInfo.Name != "" {
+		merchant = tx.MerchantInfo.Name
+	}
+	merchant = truncate(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(merchant)), maxMerchantLen)
+
+	return strings.NewReplacer("{amount}", amount.String(), "{merchant}", merchant).Replace(text)
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:n-1]) + "…"
+}
+
+// cleanText reports whether s is valid UTF-8 without control characters.
+func cleanText(s string) bool {
+	return utf8.ValidString(s) && !strings.ContainsFunc(s, unicode.IsControl)
+}
+
+// shouting reports whether most of s's letters are capitals, or it stacks
+// exclamation marks.
+func shouting(s string) bool {
+	if strings.Contains(s, "!!") {
+		return true
+	}
+	var letters, upper int
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= 10 && upper*2 > letters
+}
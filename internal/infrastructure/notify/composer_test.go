@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+const templates = `{
+  "default_locale": "en",
+  "max_length": 80,
+  "llm_locales": ["en"],
+  "forbidden": ["(?i)\\bcvv\\b"],
+  "templates": {
+    "allow":  {"en": "Payment of {amount} at {merchant} approved."},
+    "block":  {"en": "Payment of {amount} at {merchant} declined.", "uk": "Платіж {amount} у {merchant} відхилено."},
+    "review": {"en": "Payment of {amount} at {merchant} is being checked."}
+  }
+}`
+
+func newTestComposer(t *testing.T) *Composer {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "notifications.json")
+	if err := os.WriteFile(path, []byte(templates), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewComposer(path)
+	if err != nil {
+		t.Fatalf("NewComposer: %v", err)
+	}
+	return c
+}
+
+func TestComposer_UsesModelMessageThatPassesPolicies(t *testing.T) {
+	c := newTestComposer(t)
+	tx := domain.Transaction{ID: "tx-1", TenantID: "acme", UserID: "u1", UserProfile: "MaxTx: 500, Lang: en_GB"}
+	verdict := domain.RiskAssessment{IsBlocked: true, AIPushMessage: " We paused this payment to keep your card safe. "}
+
+	n := c.Compose(tx, verdict, verdict)
+	if n.Source != domain.MessageSourceLLM || n.Rejected != "" {
+		t.Fatalf("Expected the model's message, got %+v", n)
+	}
+	if n.Text != "We paused this payment to keep your card safe." || n.Locale != "en-gb" || n.Decision != domain.DecisionBlock {
+		t.Errorf("Unexpected notification %+v", n)
+	}
+
+	verdict.AIPushMessage = "We paused your 1250.00 USD payment on 2026-10-19."
+	if n := c.Compose(tx, verdict, verdict); n.Source != domain.MessageSourceLLM {
+		t.Errorf("Expected a date and an amount to be allowed, got %+v", n)
+	}
+}
+
+func TestComposer_FallsBackToTemplate(t *testing.T) {
+	c := newTestComposer(t)
+	blocked := domain.RiskAssessment{IsBlocked: true}
+	tx := domain.Transaction{
+		ID:             "tx-1",
+		Amount:         domain.MustParseMoney("54.10", "USD"),
+		OriginalAmount: domain.MustParseMoney("50", "EUR"),
+		Merchant:       "AMZN MKTP",
+		MerchantInfo:   &domain.Merchant{Name: "Amazon"},
+	}
+
+	tests := []struct {
+		name     string
+		profile  string
+		message  string
+		llm      domain.RiskAssessment
+		rejected string
+	}{
+		{"empty", "", "", blocked, RejectEmpty},
+		{"decision changed by override", "", "Your payment went through.", domain.RiskAssessment{}, RejectStale},
+		{"too long", "", strings.Repeat("Your payment was declined. ", 4), blocked, RejectLength},
+		{"link", "", "Declined, verify at https://example.com", blocked, RejectContent},
+		{"card number", "", "Card 4111 1111 1111 1111 was declined.", blocked, RejectContent},
+		{"one-time code", "", "Enter code 482913 to confirm it was you.", blocked, RejectContent},
+		{"trailing code", "", "482913 is your code, declined.", blocked, RejectContent},
+		{"phone number", "", "Declined, call +1 800 555 0199.", blocked, RejectContent},
+		{"internal tag", "", "[Heuristic Block] payment declined.", blocked, RejectContent},
+		{"configured pattern", "", "Reply with your CVV to unblock.", blocked, RejectContent},
+		{"shouting", "", "YOUR PAYMENT WAS DECLINED", blocked, RejectTone},
+		{"other language", "Language: uk-UA", "Your payment was declined.", blocked, RejectLocale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := tx
+			tx.UserProfile = tt.profile
+			final := blocked
+			final.AIPushMessage = tt.message
+
+			n := c.Compose(tx, final, tt.llm)
+			if n.Source != domain.MessageSourceTemplate || n.Rejected != tt.rejected {
+				t.Fatalf("Expected a template after %s, got %+v", tt.rejected, n)
+			}
+			want := "Payment of 50.00 EUR at Amazon declined."
+			if tt.profile != "" {
+				want = "Платіж 50.00 EUR у Amazon відхилено."
+			}
+			if n.Text != want {
+				t.Errorf("Expected %q, got %q", want, n.Text)
+			}
+		})
+	}
+}
+
+func TestComposer_LocaleFallback(t *testing.T) {
+	c := newTestComposer(t)
+
+	for profile, want := range map[string]string{
+		"Lang: uk":        "uk",
+		"Locale: uk_UA":   "uk",
+		"Language: pt-BR": "en",
+		"MaxTx: 100":      "en",
+	} {
+		n := c.Compose(domain.Transaction{UserProfile: profile}, domain.RiskAssessment{IsBlocked: true}, domain.RiskAssessment{})
+		if n.Locale != want {
+			t.Errorf("%q: expected locale %s, got %s", profile, want, n.Locale)
+		}
+	}
+
+	tx := domain.Transaction{Amount: domain.MustParseMoney("10", "USD"), Merchant: "Shop", UserProfile: "Lang: uk"}
+	n := c.Compose(tx, domain.RiskAssessment{}, domain.RiskAssessment{})
+	if n.Locale != "en" || n.Text != "Payment of 10.00 USD at Shop approved." {
+		t.Errorf("Expected the default locale for a decision without a uk template, got %+v", n)
+	}
+}
+
+func TestNewComposer_RejectsIncompleteTemplates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.json")
+	if err := os.WriteFile(path, []byte(`{"default_locale":"en","max_length":80,"templates":{"block":{"en":"Declined."}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewComposer(path); err == nil || !strings.Contains(err.Error(), "no allow template") {
+		t.Errorf("Expected a missing allow template to be rejected, got %v", err)
+	}
+
+	c, err := NewComposer(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Expected built-in templates for a missing file, got %v", err)
+	}
+	if n := c.Compose(domain.Transaction{}, domain.RiskAssessment{}, domain.RiskAssessment{}); n.Source != domain.MessageSourceTemplate || n.Text == "" {
+		t.Errorf("Expected a built-in template, got %+v", n)
+	}
+}
+
+func TestComposer_WatchLoadsFileCreatedAfterStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.json")
+	c, err := NewComposer(path)
+	if err != nil {
+		t.Fatalf("NewComposer: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Watch(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	// Give the watcher time to register the directory.
+	time.Sleep(50 * time.Millisecond)
+
+	if err := os.WriteFile(path, []byte(templates), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tx := domain.Transaction{Amount: domain.MustParseMoney("10", "USD"), Merchant: "Shop"}
+	for range 100 {
+		if n := c.Compose(tx, domain.RiskAssessment{}, domain.RiskAssessment{}); n.Text == "Payment of 10.00 USD at Shop approved." {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("Expected a templates file created after startup to be loaded")
+}
@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+)
+
+const (
+	maxAttempts  = 3
+	retryBackoff = time.Second
+)
+
+var ErrQueueFull = errors.New("notification queue full")
+
+// Sender delivers a notification to the user.
+type Sender interface {
+	Notify(ctx context.Context, n domain.Notification) error
+}
+
+// Queue sends notifications in the background so that a slow push gateway
+// does not delay decisions. When the queue is full, notifications are
+// dropped. Pending notifications are lost on shutdown.
+type Queue struct {
+	sender  Sender
+	pending chan domain.Notification
+}
+
+func NewQueue(sender Sender, size int) *Queue {
+	return &Queue{sender: sender, pending: make(chan domain.Notification, size)}
+}
+
+// Notify queues n and never blocks.
+func (q *Queue) Notify(_ context.Context, n domain.Notification) error {
+	select {
+	case q.pending <- n:
+		return nil
+	default:
+		metrics.Notifications.WithLabelValues("dropped").Inc()
+		return ErrQueueFull
+	}
+}
+
+// Run sends queued notifications until ctx is cancelled, trying each up to
+// three times.
+func (q *Queue) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-q.pending:
+			q.send(ctx, n)
+		}
+	}
+}
+
+func (q *Queue) send(ctx context.Context, n domain.Notification) {
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := q.sender.Notify(ctx, n)
+		if err == nil {
+			metrics.Notifications.WithLabelValues("sent").Inc()
+			return
+		}
+		if attempt == maxAttempts {
+			metrics.Notifications.WithLabelValues("failed").Inc()
+			slog.Warn("failed to send notification", "tenant", n.TenantID, "transaction_id", n.TransactionID, "attempts", attempt, "err", err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
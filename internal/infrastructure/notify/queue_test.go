@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/events"
+)
+
+func TestQueue_DropsWhenFull(t *testing.T) {
+	q := NewQueue(nil, 1)
+	if err := q.Notify(context.Background(), domain.Notification{TransactionID: "tx-1"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if err := q.Notify(context.Background(), domain.Notification{TransactionID: "tx-2"}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Expected ErrQueueFull, got %v", err)
+	}
+}
+
+func TestQueue_SendsThroughWebhook(t *testing.T) {
+	got := make(chan *http.Request, 1)
+	bodies := make(chan domain.Notification, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var n domain.Notification
+		_ = json.Unmarshal(data, &n)
+		got <- r
+		bodies <- n
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := NewQueue(NewWebhookNotifier(srv.URL, []byte("k"), time.Second), 10)
+	go q.Run(ctx)
+
+	sent := domain.Notification{TenantID: "acme", TransactionID: "tx-1", Decision: domain.DecisionBlock, Locale: "uk", Text: "Платіж відхилено."}
+	if err := q.Notify(ctx, sent); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	select {
+	case r := <-got:
+		if r.Header.Get("Idempotency-Key") != "acme:tx-1:block" || r.Header.Get(events.SignatureHeader) == "" {
+			t.Errorf("Expected idempotency and signature headers, got %v", r.Header)
+		}
+		if n := <-bodies; n != sent {
+			t.Errorf("Expected %+v, got %+v", sent, n)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the notification to be sent")
+	}
+}
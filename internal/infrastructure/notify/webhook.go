@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/infrastructure/events"
+)
+
+// WebhookNotifier posts each notification as JSON to a push gateway, signed
+// like decision events. Any 2xx response counts as delivered.
+type WebhookNotifier struct {
+	url    string
+	secret []byte
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewWebhookNotifier(url string, secret []byte, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{url: url, secret: secret, client: &http.Client{Timeout: timeout}, now: time.Now}
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, n domain.Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", n.TenantID+":"+n.TransactionID+":"+n.Decision)
+	req.Header.Set(events.SignatureHeader, events.Sign(w.secret, w.now(), body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("push gateway returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package review
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+// Outcome is the body posted to a tenant's review callback.
+type Outcome struct {
+	CaseID        string    `json:"case_id"`
+	TenantID      string    `json:"tenant_id"`
+	TransactionID string    `json:"transaction_id"`
+	UserID        string    `json:"user_id"`
+	Outcome       string    `json:"outcome"`
+	Reviewer      string    `json:"reviewer"`
+	Notes         string    `json:"notes,omitempty"`
+	Reason        string    `json:"reason"`
+	CreatedAt     time.Time `json:"created_at"`
+	ResolvedAt    time.Time `json:"resolved_at"`
+	Escalations   int       `json:"escalations"`
+}
+
+// HTTPNotifier posts the outcome as JSON to the case's callback URL. Any 2xx
+// response counts as delivered.
+type HTTPNotifier struct {
+	client *http.Client
+}
+
+func NewHTTPNotifier(timeout time.Duration) *HTTPNotifier {
+	return &HTTPNotifier{client: &http.Client{Timeout: timeout}}
+}
+
+func (n *HTTPNotifier) Notify(ctx context.Context, c domain.ReviewCase) error {
+	body, err := json.Marshal(Outcome{
+		CaseID:        c.ID,
+		TenantID:      c.TenantID,
+		TransactionID: c.Transaction.ID,
+		UserID:        c.Transaction.UserID,
+		Outcome:       c.Outcome,
+		Reviewer:      c.Assignee,
+		Notes:         c.Notes,
+		Reason:        c.Reason,
+		CreatedAt:     c.CreatedAt,
+		ResolvedAt:    c.ResolvedAt,
+		Escalations:   c.Escalations,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", c.ID)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("callback returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,583 @@
+// Package review keeps the queue of decisions marked for manual review and
+// delivers the analysts' outcomes to the tenants' callbacks.
+package review
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+	"github.com/tokyosplif/ai-risk-engine/pkg/atomicfile"
+	"github.com/tokyosplif/ai-risk-engine/pkg/jsonl"
+)
+
+// DefaultSweepPeriod is how often claims, SLAs and callbacks are checked.
+const DefaultSweepPeriod = 30 * time.Second
+
+// maxCallbackAttempts bounds the retries of an outcome callback, one per
+// sweep.
+const maxCallbackAttempts = 10
+
+// compactAfter is how many journal entries the sweeper lets accumulate
+// before it folds them into the snapshot.
+const compactAfter = 1000
+
+// journalSuffix names the journal next to the snapshot.
+const journalSuffix = ".log"
+
+// Notifier delivers the outcome of a resolved case.
+type Notifier interface {
+	Notify(ctx context.Context, c domain.ReviewCase) error
+}
+
+// Store is the review queue. Cases are kept in the JSON snapshot at path and
+// the JSON Lines journal next to it, which the engine owns and does not
+// watch. A change appends the case to the journal, so a request never
+// rewrites the whole queue; the sweeper folds the journal into the snapshot
+// once it grows or cases expire.
+type Store struct {
+	path string
+	// claimTTL is how long a claim holds before the case returns to the
+	// queue, and retention how long resolved cases are kept. Zero disables
+	// either.
+	claimTTL  time.Duration
+	retention time.Duration
+	notifier  Notifier
+	now       func() time.Time
+	wake      chan struct{}
+
+	mu      sync.Mutex
+	cases   []domain.ReviewCase
+	journal *os.File
+	// size is the journal's length and entries the cases appended to it
+	// since the last compaction.
+	size    int64
+	entries int
+}
+
+// NewStore loads the queue from path, creating the file if it does not exist.
+// notifier may be nil when no tenant has a callback.
+func NewStore(path string, claimTTL, retention time.Duration, notifier Notifier) (*Store, error) {
+	s := &Store{
+		path:      path,
+		claimTTL:  claimTTL,
+		retention: retention,
+		notifier:  notifier,
+		now:       time.Now,
+		wake:      make(chan struct{}, 1),
+	}
+
+	cases, err := readSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	s.cases = cases
+
+	f, err := os.OpenFile(path+journalSuffix, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open review journal: %w", err)
+	}
+	s.journal = f
+	if err := s.replay(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if err := s.compact(); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to write review queue: %w", err)
+	}
+
+	slog.Info("review queue loaded", "path", path, "cases", len(s.cases))
+	return s, nil
+}
+
+func readSnapshot(path string) ([]domain.ReviewCase, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read review queue: %w", err)
+	}
+	var cases []domain.ReviewCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("failed to parse review queue json: %w", err)
+	}
+	return cases, nil
+}
+
+// replay applies the journal over the snapshot, dropping an entry left
+// incomplete by a crash. The last entry of a case wins. A crash between
+// writing the snapshot and truncating the journal replays entries that are
+// already in it, which at worst brings back expired cases until the next
+// sweep.
+func (s *Store) replay() error {
+	size, dropped, err := jsonl.TrimPartial(s.journal)
+	if err != nil {
+		return err
+	}
+	if dropped > 0 {
+		slog.Warn("dropped incomplete review journal entry", "path", s.journal.Name(), "bytes", dropped)
+	}
+	s.size = size
+
+	sc := bufio.NewScanner(s.journal)
+	sc.Buffer(make([]byte, 64<<10), 16<<20)
+	for n := 1; sc.Scan(); n++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var c domain.ReviewCase
+		if err := json.Unmarshal(sc.Bytes(), &c); err != nil {
+			return fmt.Errorf("review journal line %d: %w", n, err)
+		}
+		if i := slices.IndexFunc(s.cases, func(e domain.ReviewCase) bool { return e.ID == c.ID }); i >= 0 {
+			s.cases[i] = c
+		} else {
+			s.cases = append(s.cases, c)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("failed to read review journal: %w", err)
+	}
+	return nil
+}
+
+// record appends cases to the journal. A failed write is cut off so that the
+// next entry starts on a line of its own. Callers must hold s.mu.
+func (s *Store) record(cases ...domain.ReviewCase) error {
+	var buf bytes.Buffer
+	for _, c := range cases {
+		data, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	n, err := s.journal.Write(buf.Bytes())
+	if err != nil {
+		if n > 0 {
+			_ = s.journal.Truncate(s.size)
+		}
+		return fmt.Errorf("failed to write review journal: %w", err)
+	}
+	s.size += int64(n)
+	s.entries += len(cases)
+	s.observe(s.now())
+	return nil
+}
+
+// compact writes the queue to the snapshot through a temporary file, so a
+// crash mid-write never leaves it truncated, and empties the journal.
+// Callers must hold s.mu, or own s.
+func (s *Store) compact() error {
+	s.observe(s.now())
+
+	cases := s.cases
+	if cases == nil {
+		cases = []domain.ReviewCase{}
+	}
+	data, err := json.MarshalIndent(cases, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := atomicfile.Write(s.path, data, 0o644); err != nil {
+		return err
+	}
+	if err := s.journal.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate review journal: %w", err)
+	}
+	s.size, s.entries = 0, 0
+	return nil
+}
+
+// Enqueue opens c. A transaction that already has an open case in its tenant
+// gets that case back, so that retried requests do not queue it twice.
+func (s *Store) Enqueue(ctx context.Context, c domain.ReviewCase) (domain.ReviewCase, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c.Transaction.ID != "" {
+		if i := slices.IndexFunc(s.cases, func(e domain.ReviewCase) bool {
+			return e.Open() && e.TenantID == c.TenantID && e.Transaction.ID == c.Transaction.ID
+		}); i >= 0 {
+			return s.cases[i], nil
+		}
+	}
+
+	id, err := newID()
+	if err != nil {
+		return domain.ReviewCase{}, err
+	}
+	c.ID = id
+	s.cases = append(s.cases, c)
+	if err := s.record(c); err != nil {
+		s.cases = s.cases[:len(s.cases)-1]
+		return domain.ReviewCase{}, err
+	}
+
+	metrics.ReviewEvents.WithLabelValues(c.TenantID, "enqueued").Inc()
+	slog.InfoContext(ctx, "review case opened", "case_id", c.ID, "tenant", c.TenantID, "due_at", c.DueAt)
+	return c, nil
+}
+
+// List returns the cases matching f, highest priority first.
+func (s *Store) List(f domain.ReviewFilter) []domain.ReviewCase {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []domain.ReviewCase
+	for _, c := range s.cases {
+		if f.Tenant != "" && c.TenantID != f.Tenant {
+			continue
+		}
+		if f.Status == "" && !c.Open() || f.Status != "" && c.Status != f.Status {
+			continue
+		}
+		if f.OverdueOnly && !c.Overdue(now) {
+			continue
+		}
+		out = append(out, c)
+	}
+
+	slices.SortStableFunc(out, byPriority)
+	if f.Limit > 0 && len(out) > f.Limit {
+		out = out[:f.Limit]
+	}
+	return out
+}
+
+// Get returns case id. A non-empty tenant hides other tenants' cases.
+func (s *Store) Get(tenant, id string) (domain.ReviewCase, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := s.find(tenant, id)
+	if err != nil {
+		return domain.ReviewCase{}, err
+	}
+	return s.cases[i], nil
+}
+
+// Claim assigns case id to reviewer, or the pending case with the highest
+// priority when id is empty. A reviewer may claim its own case again to
+// renew the claim.
+func (s *Store) Claim(tenant, id, reviewer string) (domain.ReviewCase, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var i int
+	if id == "" {
+		i = s.next(tenant)
+		if i < 0 {
+			return domain.ReviewCase{}, domain.ErrNoReviewCase
+		}
+	} else {
+		var err error
+		if i, err = s.find(tenant, id); err != nil {
+			return domain.ReviewCase{}, err
+		}
+	}
+
+	c := &s.cases[i]
+	switch {
+	case !c.Open():
+		return domain.ReviewCase{}, domain.ErrReviewCaseResolved
+	case c.Status == domain.ReviewClaimed && c.Assignee != reviewer:
+		return domain.ReviewCase{}, fmt.Errorf("%w: %s", domain.ErrReviewCaseClaimed, c.Assignee)
+	}
+
+	prev := *c
+	c.Status = domain.ReviewClaimed
+	c.Assignee = reviewer
+	c.ClaimedAt = now
+	if err := s.record(*c); err != nil {
+		*c = prev
+		return domain.ReviewCase{}, err
+	}
+
+	metrics.ReviewEvents.WithLabelValues(c.TenantID, "claimed").Inc()
+	return *c, nil
+}
+
+// Resolve records the outcome of case id, which reviewer must have claimed,
+// and schedules its callback.
+func (s *Store) Resolve(tenant, id, reviewer, outcome, notes string) (domain.ReviewCase, error) {
+	if !domain.ValidOutcome(outcome) {
+		return domain.ReviewCase{}, fmt.Errorf("%w: %q", domain.ErrInvalidReviewOutcome, outcome)
+	}
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := s.find(tenant, id)
+	if err != nil {
+		return domain.ReviewCase{}, err
+	}
+
+	c := &s.cases[i]
+	switch {
+	case !c.Open():
+		return domain.ReviewCase{}, domain.ErrReviewCaseResolved
+	case c.Status != domain.ReviewClaimed:
+		return domain.ReviewCase{}, domain.ErrReviewCaseNotClaimed
+	case c.Assignee != reviewer:
+		return domain.ReviewCase{}, fmt.Errorf("%w: %s", domain.ErrReviewCaseClaimed, c.Assignee)
+	}
+
+	prev := *c
+	c.Status = domain.ReviewResolved
+	c.Outcome = outcome
+	c.Notes = notes
+	c.ResolvedAt = now
+	if err := s.record(*c); err != nil {
+		*c = prev
+		return domain.ReviewCase{}, err
+	}
+
+	metrics.ReviewEvents.WithLabelValues(c.TenantID, outcome).Inc()
+	metrics.ReviewDuration.WithLabelValues(c.TenantID, outcome).Observe(now.Sub(c.CreatedAt).Seconds())
+	slog.Info("review case resolved", "case_id", c.ID, "tenant", c.TenantID, "outcome", outcome, "reviewer", reviewer)
+
+	resolved := *c
+	if resolved.CallbackURL != "" {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return resolved, nil
+}
+
+// Sweep returns expired claims to the queue, escalates overdue cases and
+// drops resolved cases past the retention period.
+func (s *Store) Sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired := false
+	s.cases = slices.DeleteFunc(s.cases, func(c domain.ReviewCase) bool {
+		drop := !c.Open() && s.retention > 0 && now.Sub(c.ResolvedAt) > s.retention && !s.callbackDue(c)
+		expired = expired || drop
+		return drop
+	})
+
+	var changed []domain.ReviewCase
+	for i := range s.cases {
+		c := &s.cases[i]
+		if !c.Open() {
+			continue
+		}
+		touched := false
+
+		if c.Status == domain.ReviewClaimed && s.claimTTL > 0 && now.Sub(c.ClaimedAt) >= s.claimTTL {
+			slog.Info("review claim expired", "case_id", c.ID, "tenant", c.TenantID, "reviewer", c.Assignee)
+			metrics.ReviewEvents.WithLabelValues(c.TenantID, "released").Inc()
+			c.Status, c.Assignee, c.ClaimedAt = domain.ReviewPending, "", time.Time{}
+			touched = true
+		}
+
+		if level := escalationLevel(*c, now); level > c.Escalations {
+			c.Escalations = level
+			slog.Warn("review case escalated", "case_id", c.ID, "tenant", c.TenantID, "escalations", level, "due_at", c.DueAt)
+			metrics.ReviewEvents.WithLabelValues(c.TenantID, "escalated").Inc()
+			touched = true
+		}
+
+		if touched {
+			changed = append(changed, *c)
+		}
+	}
+
+	var err error
+	switch {
+	case expired || s.entries+len(changed) >= compactAfter:
+		err = s.compact()
+	case len(changed) > 0:
+		err = s.record(changed...)
+	default:
+		s.observe(now)
+	}
+	if err != nil {
+		slog.Error("failed to write review queue", "path", s.path, "err", err)
+	}
+}
+
+// Deliver posts the outcome of resolved cases to their callbacks. Failed
+// deliveries are retried on later calls, up to maxCallbackAttempts.
+func (s *Store) Deliver(ctx context.Context) {
+	if s.notifier == nil {
+		return
+	}
+
+	s.mu.Lock()
+	var due []domain.ReviewCase
+	for _, c := range s.cases {
+		if s.callbackDue(c) {
+			due = append(due, c)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, c := range due {
+		err := s.notifier.Notify(ctx, c)
+		if ctx.Err() != nil {
+			return
+		}
+		result := "success"
+		if err != nil {
+			result = "failure"
+			slog.Warn("review callback failed", "case_id", c.ID, "tenant", c.TenantID, "attempt", c.CallbackAttempts+1, "err", err)
+		}
+		metrics.ReviewCallbacks.WithLabelValues(c.TenantID, result).Inc()
+		s.markDelivered(c.ID, err == nil)
+	}
+}
+
+func (s *Store) markDelivered(id string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := s.find("", id)
+	if err != nil {
+		return
+	}
+	s.cases[i].CallbackAttempts++
+	if ok {
+		s.cases[i].NotifiedAt = s.now()
+	} else if s.cases[i].CallbackAttempts >= maxCallbackAttempts {
+		slog.Error("review callback abandoned", "case_id", id, "tenant", s.cases[i].TenantID, "attempts", s.cases[i].CallbackAttempts)
+	}
+	if err := s.record(s.cases[i]); err != nil {
+		slog.Error("failed to write review queue", "path", s.path, "err", err)
+	}
+}
+
+// Run sweeps the queue and delivers callbacks every period, and right away
+// when a case with a callback is resolved, until ctx is done.
+func (s *Store) Run(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.Sweep(now)
+		case <-s.wake:
+		case <-ctx.Done():
+			return
+		}
+		s.Deliver(ctx)
+	}
+}
+
+// Close writes the queue to the snapshot and closes the journal.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.compact(), s.journal.Close())
+}
+
+func (s *Store) callbackDue(c domain.ReviewCase) bool {
+	return !c.Open() && c.CallbackURL != "" && c.NotifiedAt.IsZero() && c.CallbackAttempts < maxCallbackAttempts
+}
+
+// find returns the index of case id. Callers must hold s.mu.
+func (s *Store) find(tenant, id string) (int, error) {
+	i := slices.IndexFunc(s.cases, func(c domain.ReviewCase) bool {
+		return c.ID == id && (tenant == "" || c.TenantID == tenant)
+	})
+	if i < 0 {
+		return -1, fmt.Errorf("%w: %q", domain.ErrReviewCaseNotFound, id)
+	}
+	return i, nil
+}
+
+// next returns the index of the pending case with the highest priority, or
+// -1. Callers must hold s.mu.
+func (s *Store) next(tenant string) int {
+	best := -1
+	for i, c := range s.cases {
+		if c.Status != domain.ReviewPending || tenant != "" && c.TenantID != tenant {
+			continue
+		}
+		if best < 0 || byPriority(c, s.cases[best]) < 0 {
+			best = i
+		}
+	}
+	return best
+}
+
+// observe publishes the open and overdue case counts. Callers must hold s.mu.
+func (s *Store) observe(now time.Time) {
+	open := make(map[string]int)
+	overdue := make(map[string]int)
+	for _, c := range s.cases {
+		if !c.Open() {
+			continue
+		}
+		open[c.TenantID]++
+		if c.Overdue(now) {
+			overdue[c.TenantID]++
+		}
+	}
+
+	metrics.ReviewOpen.Reset()
+	for tenant, n := range open {
+		metrics.ReviewOpen.WithLabelValues(tenant, "open").Set(float64(n))
+		metrics.ReviewOpen.WithLabelValues(tenant, "overdue").Set(float64(overdue[tenant]))
+	}
+}
+
+// escalationLevel is 0 before the case is due, 1 when it is, and one more
+// for every further SLA period it stays open.
+func escalationLevel(c domain.ReviewCase, now time.Time) int {
+	if !c.Overdue(now) {
+		return 0
+	}
+	sla := c.SLA()
+	if sla <= 0 {
+		return 1
+	}
+	return 1 + int(now.Sub(c.DueAt)/sla)
+}
+
+// byPriority orders the most escalated cases first, then the ones due
+// soonest.
+func byPriority(a, b domain.ReviewCase) int {
+	if c := cmp.Compare(b.Escalations, a.Escalations); c != 0 {
+		return c
+	}
+	if c := a.DueAt.Compare(b.DueAt); c != 0 {
+		return c
+	}
+	return a.CreatedAt.Compare(b.CreatedAt)
+}
+
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "rc_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,228 @@
+package review
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+func newTestStore(t *testing.T, n Notifier) (*Store, *time.Time) {
+	t.Helper()
+
+	s, err := NewStore(filepath.Join(t.TempDir(), "reviews.json"), 30*time.Minute, 24*time.Hour, n)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func openCase(t *testing.T, s *Store, tenant, txID string, sla time.Duration, callback string) domain.ReviewCase {
+	t.Helper()
+
+	tx := domain.Transaction{ID: txID, TenantID: tenant, UserID: "u1"}
+	c, err := s.Enqueue(context.Background(), domain.NewReviewCase(tx, domain.RiskAssessment{Reason: domain.TagPendingReview + " high value"}, domain.ReviewPolicy{SLA: sla, CallbackURL: callback}, s.now()))
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	return c
+}
+
+func TestStore_ClaimAndResolve(t *testing.T) {
+	s, _ := newTestStore(t, nil)
+
+	first := openCase(t, s, "acme", "tx-1", 4*time.Hour, "")
+	if again := openCase(t, s, "acme", "tx-1", 4*time.Hour, ""); again.ID != first.ID {
+		t.Errorf("Expected a retried transaction to reuse its case, got %s and %s", first.ID, again.ID)
+	}
+	urgent := openCase(t, s, "acme", "tx-2", time.Hour, "")
+	openCase(t, s, "other", "tx-3", time.Minute, "")
+
+	if _, err := s.Get("other", first.ID); !errors.Is(err, domain.ErrReviewCaseNotFound) {
+		t.Errorf("Expected another tenant's case to be hidden, got %v", err)
+	}
+
+	c, err := s.Claim("acme", "", "jane")
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if c.ID != urgent.ID || c.Status != domain.ReviewClaimed || c.Assignee != "jane" {
+		t.Errorf("Expected jane to claim the case due first, got %+v", c)
+	}
+	if _, err := s.Claim("acme", urgent.ID, "bob"); !errors.Is(err, domain.ErrReviewCaseClaimed) {
+		t.Errorf("Expected a claimed case to be refused, got %v", err)
+	}
+	if _, err := s.Resolve("acme", first.ID, "jane", domain.OutcomeFraud, ""); !errors.Is(err, domain.ErrReviewCaseNotClaimed) {
+		t.Errorf("Expected an unclaimed case not to resolve, got %v", err)
+	}
+	if _, err := s.Resolve("acme", urgent.ID, "bob", domain.OutcomeFraud, ""); !errors.Is(err, domain.ErrReviewCaseClaimed) {
+		t.Errorf("Expected only the assignee to resolve, got %v", err)
+	}
+	if _, err := s.Resolve("acme", urgent.ID, "jane", "maybe", ""); !errors.Is(err, domain.ErrInvalidReviewOutcome) {
+		t.Errorf("Expected an invalid outcome to be refused, got %v", err)
+	}
+
+	c, err = s.Resolve("acme", urgent.ID, "jane", domain.OutcomeFraud, "card reported stolen")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if c.Status != domain.ReviewResolved || c.Outcome != domain.OutcomeFraud || c.Notes != "card reported stolen" {
+		t.Errorf("Unexpected resolved case %+v", c)
+	}
+	if _, err := s.Resolve("acme", urgent.ID, "jane", domain.OutcomeApproved, ""); !errors.Is(err, domain.ErrReviewCaseResolved) {
+		t.Errorf("Expected a resolved case to stay resolved, got %v", err)
+	}
+
+	if open := s.List(domain.ReviewFilter{Tenant: "acme"}); len(open) != 1 || open[0].ID != first.ID {
+		t.Errorf("Expected one open acme case, got %v", open)
+	}
+
+	reloaded, err := NewStore(s.path, time.Minute, 0, nil)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if got := reloaded.List(domain.ReviewFilter{Status: domain.ReviewResolved}); len(got) != 1 || got[0].Outcome != domain.OutcomeFraud {
+		t.Errorf("Expected the queue to survive a restart, got %v", got)
+	}
+}
+
+func TestStore_SweepEscalatesAndReleases(t *testing.T) {
+	s, now := newTestStore(t, nil)
+
+	c := openCase(t, s, "acme", "tx-1", time.Hour, "")
+	if _, err := s.Claim("acme", c.ID, "jane"); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	*now = now.Add(45 * time.Minute)
+	s.Sweep(*now)
+	if got, _ := s.Get("", c.ID); got.Status != domain.ReviewPending || got.Assignee != "" || got.Escalations != 0 {
+		t.Errorf("Expected the expired claim to be released without escalation, got %+v", got)
+	}
+
+	*now = now.Add(15 * time.Minute)
+	s.Sweep(*now)
+	if got, _ := s.Get("", c.ID); got.Escalations != 1 {
+		t.Errorf("Expected an escalation at the due time, got %d", got.Escalations)
+	}
+	if overdue := s.List(domain.ReviewFilter{OverdueOnly: true}); len(overdue) != 1 {
+		t.Errorf("Expected the case to be listed as overdue, got %v", overdue)
+	}
+
+	*now = now.Add(2 * time.Hour)
+	s.Sweep(*now)
+	if got, _ := s.Get("", c.ID); got.Escalations != 3 {
+		t.Errorf("Expected one escalation per further SLA period, got %d", got.Escalations)
+	}
+}
+
+func TestStore_DeliversOutcomeCallback(t *testing.T) {
+	var calls []Outcome
+	fail := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var o Outcome
+		_ = json.NewDecoder(r.Body).Decode(&o)
+		calls = append(calls, o)
+		if fail {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	s, now := newTestStore(t, NewHTTPNotifier(time.Second))
+	c := openCase(t, s, "acme", "tx-1", time.Hour, srv.URL)
+	if _, err := s.Claim("acme", c.ID, "jane"); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if _, err := s.Resolve("acme", c.ID, "jane", domain.OutcomeApproved, "customer confirmed"); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	s.Deliver(context.Background())
+	fail = false
+	s.Deliver(context.Background())
+	s.Deliver(context.Background())
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected one failed and one successful delivery, got %d", len(calls))
+	}
+	if o := calls[1]; o.CaseID != c.ID || o.TransactionID != "tx-1" || o.Outcome != domain.OutcomeApproved || o.Reviewer != "jane" {
+		t.Errorf("Unexpected callback body %+v", o)
+	}
+
+	*now = now.Add(48 * time.Hour)
+	s.Sweep(*now)
+	if _, err := s.Get("", c.ID); !errors.Is(err, domain.ErrReviewCaseNotFound) {
+		t.Errorf("Expected the delivered case to be dropped after retention, got %v", err)
+	}
+}
+
+func TestStore_JournalsChangesAndCompacts(t *testing.T) {
+	s, now := newTestStore(t, nil)
+	snapshot, err := os.ReadFile(s.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := openCase(t, s, "acme", "tx-1", time.Hour, "")
+	if _, err := s.Claim("acme", c.ID, "jane"); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if data, _ := os.ReadFile(s.path); string(data) != string(snapshot) {
+		t.Error("Expected changes to be appended to the journal, not written to the snapshot")
+	}
+
+	// A crash mid-append leaves a torn last line.
+	if _, err := s.journal.WriteString(`{"id":"rc_torn","tenant`); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := NewStore(s.path, time.Minute, 0, nil)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if got, err := reloaded.Get("", c.ID); err != nil || got.Assignee != "jane" {
+		t.Errorf("Expected the journal to be replayed, got %+v, %v", got, err)
+	}
+	if got := reloaded.List(domain.ReviewFilter{}); len(got) != 1 {
+		t.Errorf("Expected the torn entry to be dropped, got %v", got)
+	}
+	if info, err := os.Stat(s.path + journalSuffix); err != nil || info.Size() != 0 {
+		t.Errorf("Expected the journal to be compacted on load, got %v, %v", info, err)
+	}
+
+	*now = now.Add(2 * time.Hour)
+	reloaded.now = s.now
+	if _, err := reloaded.Resolve("acme", c.ID, "jane", domain.OutcomeApproved, ""); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	reloaded.retention = time.Hour
+	*now = now.Add(2 * time.Hour)
+	reloaded.Sweep(*now)
+	if data, _ := os.ReadFile(s.path); string(data) != string(snapshot) {
+		t.Errorf("Expected the sweep to compact the expired case out of the snapshot, got %s", data)
+	}
+}
+
+func TestStore_EnqueueRollsBackWhenTheJournalFails(t *testing.T) {
+	s, _ := newTestStore(t, nil)
+	if err := s.journal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := domain.Transaction{ID: "tx-1", TenantID: "acme"}
+	if _, err := s.Enqueue(context.Background(), domain.NewReviewCase(tx, domain.RiskAssessment{}, domain.ReviewPolicy{SLA: time.Hour}, s.now())); err == nil {
+		t.Fatal("Expected Enqueue to fail when the journal cannot be written")
+	}
+	if got := s.List(domain.ReviewFilter{}); len(got) != 0 {
+		t.Errorf("Expected the failed case not to stay queued, got %v", got)
+	}
+}
@@ -0,0 +1,203 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "risk_engine"
+
+const (
+	ErrClassTimeout       = "timeout"
+	ErrClassRateLimited   = "rate_limited"
+	ErrClassParseFailure  = "parse_failure"
+	ErrClassEmptyChoices  = "empty_choices"
+	ErrClassEmptyContent  = "empty_content"
+	ErrClassProviderOther = "provider_error"
+	ErrClassCircuitOpen   = "circuit_open"
+)
+
+var (
+	Decisions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "decisions_total",
+		Help:      "Final decisions returned by the analyzer.",
+	}, []string{"tenant", "decision"})
+
+	Routes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "routes_total",
+		Help:      "Analyses by the stage that produced the verdict (prescreen or LLM).",
+	}, []string{"tenant", "route"})
+
+	RulesFired = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rules_fired_total",
+		Help:      "Heuristic rule tags applied to verdicts.",
+	}, []string{"tenant", "rule"})
+
+	AnalysisDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "analysis_duration_seconds",
+		Help:      "End-to-end duration of a transaction analysis.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"tenant"})
+
+	LLMDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "llm_request_duration_seconds",
+		Help:      "Duration of outbound LLM chat completion calls.",
+		Buckets:   []float64{.1, .25, .5, 1, 2, 3, 5, 8, 13, 20},
+	}, []string{"tenant", "model"})
+
+	LLMErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "llm_errors_total",
+		Help:      "LLM provider errors by class.",
+	}, []string{"tenant", "model", "class"})
+
+	LLMTokens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "llm_tokens_total",
+		Help:      "Tokens consumed by LLM calls.",
+	}, []string{"tenant", "model", "type"})
+
+	PromptReloads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "prompt_reloads_total",
+		Help:      "Prompt file load attempts by result.",
+	}, []string{"result"})
+
+	ConfigReloads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "config_reloads_total",
+		Help:      "Runtime configuration reload attempts by result.",
+	}, []string{"result"})
+
+	PromptVersion = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "prompt_version_info",
+		Help:      "Prompt version currently used for analysis (value is always 1).",
+	}, []string{"tenant", "version"})
+
+	RateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Requests rejected by a rate limit, by the scope (tenant or client) that was exhausted.",
+	}, []string{"tenant", "scope"})
+
+	LLMInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "llm_in_flight",
+		Help:      "LLM calls currently holding a concurrency slot.",
+	})
+
+	LLMQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "llm_queue_depth",
+		Help:      "LLM calls waiting for a concurrency slot.",
+	})
+
+	LLMQueueWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "llm_queue_wait_seconds",
+		Help:      "Time LLM calls spent waiting for a concurrency slot.",
+		Buckets:   []float64{.001, .005, .01, .05, .1, .25, .5, 1, 2, 5},
+	})
+
+	LLMCircuitState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "llm_circuit_state",
+		Help:      "LLM provider circuit breaker state (value is 1 for the current state).",
+	}, []string{"provider", "state"})
+
+	KeyEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "key_events_total",
+		Help:      "Credential key events by credential and event (rate_limited, rejected, revoked or reloaded).",
+	}, []string{"credential", "event"})
+
+	ReviewEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "review_events_total",
+		Help:      "Review case events by event (enqueued, claimed, released, escalated, approved or fraud).",
+	}, []string{"tenant", "event"})
+
+	ReviewOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "review_open_cases",
+		Help:      "Open review cases, and how many of them are overdue.",
+	}, []string{"tenant", "state"})
+
+	ReviewDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "review_resolution_seconds",
+		Help:      "Time from opening a review case to its outcome.",
+		Buckets:   []float64{60, 300, 900, 1800, 3600, 7200, 14400, 28800, 86400, 259200},
+	}, []string{"tenant", "outcome"})
+
+	ReviewCallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "review_callbacks_total",
+		Help:      "Review outcome callback attempts by result.",
+	}, []string{"tenant", "result"})
+
+	OutcomeLabels = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outcome_labels_total",
+		Help:      "Outcome labels reported, by label, source and the engine's decision (unknown when it was not found).",
+	}, []string{"tenant", "label", "source", "decision"})
+
+	EventsPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_published_total",
+		Help:      "Decision event deliveries by sink and result.",
+	}, []string{"sink", "result"})
+
+	EventsBacklog = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "events_backlog_bytes",
+		Help:      "Bytes of decision events in the outbox not yet delivered to the sink.",
+	}, []string{"sink"})
+
+	EventsDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_dropped_total",
+		Help:      "Decision events dropped because the outbox was full.",
+	})
+
+	PushMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "push_messages_total",
+		Help:      "Composed push messages by decision, source (llm or template) and the policy the model's message failed (none when it was used).",
+	}, []string{"tenant", "decision", "source", "rejected"})
+
+	Notifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_total",
+		Help:      "Push notifications by result (sent, failed or dropped).",
+	}, []string{"result"})
+
+	LLMRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "llm_rejected_total",
+		Help:      "LLM calls rejected without reaching the provider, by reason (queue_full or queue_timeout).",
+	}, []string{"tenant", "reason"})
+)
+
+func SetPromptVersion(tenant, version string) {
+	PromptVersion.DeletePartialMatch(prometheus.Labels{"tenant": tenant})
+	PromptVersion.WithLabelValues(tenant, version).Set(1)
+}
+
+func SetCircuitState(provider, state string) {
+	LLMCircuitState.DeletePartialMatch(prometheus.Labels{"provider": provider})
+	LLMCircuitState.WithLabelValues(provider, state).Set(1)
+}
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
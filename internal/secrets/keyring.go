@@ -0,0 +1,285 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+	"github.com/tokyosplif/ai-risk-engine/pkg/watch"
+)
+
+// Key selection strategies.
+const (
+	// RoundRobin spreads calls over every usable key.
+	RoundRobin = "round_robin"
+	// Failover uses the first usable key and moves on only when it is
+	// rate limited or revoked.
+	Failover = "failover"
+)
+
+var ErrNoUsableKey = errors.New("no usable key")
+
+// rejectedCooldown is how long a rejected key that no reload can replace sits
+// out before it is tried again, in case the rejection was transient.
+const rejectedCooldown = 10 * time.Minute
+
+// Key is one secret of a KeyRing as handed out by Pick.
+type Key struct {
+	Value Value
+	// ID is the key's fingerprint, safe to log.
+	ID string
+}
+
+type keyState struct {
+	Key
+	// until is when a rate limited key may be used again.
+	until time.Time
+	// revoked keys stay unused until a reload brings a different value.
+	revoked bool
+	// fromFile keys come from the key file and can be rotated by editing it.
+	fromFile bool
+}
+
+// KeyRing holds the keys of one credential: static values, typically from
+// the environment, and the keys of an optional file with one key per line.
+// The file is re-read when it changes, so a rotated key is used from the next
+// call on. Keys that are rate limited sit out a cooldown. Rejected keys from
+// the file are dropped until the file changes; rejected static keys, which
+// only a restart could replace, sit out a longer cooldown instead.
+type KeyRing struct {
+	name     string
+	static   []Value
+	path     string
+	strategy string
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu   sync.Mutex
+	keys []*keyState
+	next int
+}
+
+// NewKeyRing loads the static keys and the file at path, if any. An unreadable
+// file is an error; an empty ring is not, so that the engine can start and
+// report not ready.
+func NewKeyRing(name string, static []Value, path, strategy string, cooldown time.Duration) (*KeyRing, error) {
+	r := &KeyRing{
+		name:     name,
+		static:   static,
+		path:     path,
+		strategy: strategy,
+		cooldown: cooldown,
+		now:      time.Now,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Static returns a ring of fixed keys.
+func Static(keys ...Value) *KeyRing {
+	r := &KeyRing{static: keys, strategy: RoundRobin, now: time.Now}
+	r.set(keys, nil)
+	return r
+}
+
+// Name is the credential the ring holds, for logs.
+func (r *KeyRing) Name() string {
+	return r.name
+}
+
+// Reload re-reads the key file. The state of keys that are still present is
+// kept; a failed read keeps the current keys.
+func (r *KeyRing) Reload() error {
+	var file []Value
+	if r.path != "" {
+		data, err := os.ReadFile(r.path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s key file: %w", r.name, err)
+		}
+		file = parseFile(data)
+	}
+	r.set(r.static, file)
+	return nil
+}
+
+// set replaces the keys with the static ones followed by the file's. A value
+// listed in both counts as static.
+func (r *KeyRing) set(static, file []Value) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := make([]*keyState, 0, len(static)+len(file))
+	for i, v := range append(slices.Clone(static), file...) {
+		if slices.ContainsFunc(keys, func(k *keyState) bool { return k.Value == v }) {
+			continue
+		}
+		fromFile := i >= len(static)
+		j := slices.IndexFunc(r.keys, func(k *keyState) bool { return k.Value == v })
+		if j >= 0 && r.keys[j].fromFile == fromFile {
+			keys = append(keys, r.keys[j])
+			continue
+		}
+		keys = append(keys, &keyState{Key: Key{Value: v, ID: v.Fingerprint()}, fromFile: fromFile})
+	}
+	r.keys = keys
+	r.next = 0
+}
+
+// Len returns the number of keys loaded.
+func (r *KeyRing) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.keys)
+}
+
+// Values returns every key that is not revoked, for verifiers that accept
+// any of them.
+func (r *KeyRing) Values() []Value {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Value, 0, len(r.keys))
+	for _, k := range r.keys {
+		if !k.revoked {
+			out = append(out, k.Value)
+		}
+	}
+	return out
+}
+
+// Pick returns the key to use for the next call.
+func (r *KeyRing) Pick() (Key, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for i := range r.keys {
+		idx := i
+		if r.strategy != Failover {
+			idx = (r.next + i) % len(r.keys)
+		}
+		k := r.keys[idx]
+		if k.revoked || now.Before(k.until) {
+			continue
+		}
+		r.next = idx + 1
+		return k.Key, nil
+	}
+
+	if len(r.keys) == 0 {
+		return Key{}, fmt.Errorf("%w: no %s configured", ErrNoUsableKey, r.name)
+	}
+	return Key{}, fmt.Errorf("%w: every %s is rate limited or revoked", ErrNoUsableKey, r.name)
+}
+
+// RateLimited benches k for the cooldown.
+func (r *KeyRing) RateLimited(k Key) {
+	r.update(k, func(s *keyState) { s.until = r.now().Add(r.cooldown) })
+	metrics.KeyEvents.WithLabelValues(r.name, "rate_limited").Inc()
+	slog.Warn("key rate limited, benched", "credential", r.name, "key", k.ID, "cooldown", r.cooldown)
+}
+
+// Rejected stops using k, which the provider refused. A key from the file is
+// revoked until the file replaces it. A static key cannot be rotated without
+// a restart, so it is benched for rejectedCooldown, or the rate limit
+// cooldown if longer, rather than taking the credential down for good.
+func (r *KeyRing) Rejected(k Key) {
+	revoked := false
+	r.update(k, func(s *keyState) {
+		if s.fromFile {
+			s.revoked, revoked = true, true
+			return
+		}
+		s.until = r.now().Add(max(rejectedCooldown, r.cooldown))
+	})
+	if revoked {
+		metrics.KeyEvents.WithLabelValues(r.name, "revoked").Inc()
+		slog.Error("key rejected, disabled until rotated", "credential", r.name, "key", k.ID)
+		return
+	}
+	metrics.KeyEvents.WithLabelValues(r.name, "rejected").Inc()
+	slog.Error("static key rejected, benched", "credential", r.name, "key", k.ID, "cooldown", max(rejectedCooldown, r.cooldown))
+}
+
+func (r *KeyRing) update(k Key, fn func(*keyState)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.keys {
+		if s.Value == k.Value {
+			fn(s)
+		}
+	}
+}
+
+// Ready fails when no key is loaded or every key is revoked. Benched keys
+// count as usable since they come back on their own.
+func (r *KeyRing) Ready() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.keys) == 0 {
+		return fmt.Errorf("no %s configured", r.name)
+	}
+	for _, k := range r.keys {
+		if !k.revoked {
+			return nil
+		}
+	}
+	return fmt.Errorf("every %s was rejected by the provider", r.name)
+}
+
+// Scrub returns err with any key of the ring replaced by Redacted, keeping
+// err in the chain for errors.Is and errors.As.
+func (r *KeyRing) Scrub(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	scrubbed := msg
+	r.mu.Lock()
+	for _, k := range r.keys {
+		if k.Value != "" {
+			scrubbed = strings.ReplaceAll(scrubbed, k.Value.Reveal(), Redacted)
+		}
+	}
+	r.mu.Unlock()
+	if scrubbed == msg {
+		return err
+	}
+	return &scrubbedError{msg: scrubbed, err: err}
+}
+
+type scrubbedError struct {
+	msg string
+	err error
+}
+
+func (e *scrubbedError) Error() string { return e.msg }
+func (e *scrubbedError) Unwrap() error { return e.err }
+
+// Watch reloads the key file when it is written, replaced or created, until
+// ctx is done. Kubernetes secret updates, which swap a symlink rather than
+// write the file, are seen too.
+func (r *KeyRing) Watch(ctx context.Context) {
+	if r.path == "" {
+		return
+	}
+	watch.Files(ctx, r.name+" keys", func(string) {
+		before := r.Len()
+		if err := r.Reload(); err != nil {
+			slog.Error("failed to reload key file, keeping current keys", "credential", r.name, "err", err)
+			return
+		}
+		metrics.KeyEvents.WithLabelValues(r.name, "reloaded").Inc()
+		slog.Info("key file reloaded", "credential", r.name, "keys", r.Len(), "previous", before)
+	}, r.path)
+}
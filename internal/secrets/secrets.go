@@ -0,0 +1,72 @@
+// Package secrets loads credentials from the environment and from mounted
+// files, picks up rotated values without a restart and keeps them out of
+// logs and configuration dumps.
+package secrets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+)
+
+// Redacted is how a Value renders everywhere except Reveal.
+const Redacted = "[REDACTED]"
+
+// Value is a secret string. fmt, slog, JSON and YAML all render a non-empty
+// Value as Redacted; only Reveal returns the secret itself.
+type Value string
+
+func (v Value) Reveal() string {
+	return string(v)
+}
+
+func (v Value) String() string {
+	if v == "" {
+		return ""
+	}
+	return Redacted
+}
+
+func (v Value) GoString() string {
+	return `"` + v.String() + `"`
+}
+
+func (v Value) LogValue() slog.Value {
+	return slog.StringValue(v.String())
+}
+
+// MarshalText covers the JSON and YAML encoders.
+func (v Value) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// Fingerprint identifies v in logs without revealing it.
+func (v Value) Fingerprint() string {
+	sum := sha256.Sum256([]byte(v))
+	return "sha256:" + hex.EncodeToString(sum[:4])
+}
+
+// Split parses a comma-separated list of secrets, as given in an
+// environment variable.
+func Split(s string) []Value {
+	return parse(s, func(r rune) bool { return r == ',' || r == '\n' })
+}
+
+// parseFile parses a key file: one secret per line, blank lines and lines
+// starting with # skipped.
+func parseFile(data []byte) []Value {
+	return parse(string(data), func(r rune) bool { return r == '\n' })
+}
+
+func parse(s string, sep func(rune) bool) []Value {
+	var out []Value
+	for _, line := range strings.FieldsFunc(s, sep) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		out = append(out, Value(line))
+	}
+	return out
+}
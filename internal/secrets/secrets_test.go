@@ -0,0 +1,165 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValue_NeverPrints(t *testing.T) {
+	v := Value("gsk_live_123")
+
+	var logs bytes.Buffer
+	slog.New(slog.NewJSONHandler(&logs, nil)).Info("loaded", "key", v)
+	jsonOut, _ := json.Marshal(struct{ Key Value }{v})
+
+	for name, out := range map[string]string{
+		"fmt %v":  fmt.Sprintf("%v", v),
+		"fmt %s":  fmt.Sprintf("%s", v),
+		"fmt %#v": fmt.Sprintf("%#v", v),
+		"slog":    logs.String(),
+		"json":    string(jsonOut),
+	} {
+		if strings.Contains(out, "gsk_live_123") {
+			t.Errorf("%s leaked the secret: %s", name, out)
+		}
+	}
+	if v.Reveal() != "gsk_live_123" {
+		t.Error("Expected Reveal to return the secret")
+	}
+}
+
+func TestKeyRing_Strategies(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	newRing := func(strategy string) *KeyRing {
+		r := Static("a", "b", "c")
+		r.strategy, r.cooldown, r.name = strategy, time.Minute, "test key"
+		r.now = func() time.Time { return now }
+		return r
+	}
+	pick := func(r *KeyRing) string {
+		t.Helper()
+		k, err := r.Pick()
+		if err != nil {
+			t.Fatalf("Pick: %v", err)
+		}
+		return k.Value.Reveal()
+	}
+
+	rr := newRing(RoundRobin)
+	if got := []string{pick(rr), pick(rr), pick(rr), pick(rr)}; fmt.Sprint(got) != "[a b c a]" {
+		t.Errorf("Expected round robin order, got %v", got)
+	}
+
+	fo := newRing(Failover)
+	if pick(fo) != "a" || pick(fo) != "a" {
+		t.Error("Expected failover to stick to the first key")
+	}
+	fo.RateLimited(Key{Value: "a"})
+	if got := pick(fo); got != "b" {
+		t.Errorf("Expected failover to the second key, got %s", got)
+	}
+	fo.Rejected(Key{Value: "b"})
+	if got := pick(fo); got != "c" {
+		t.Errorf("Expected the rejected key to be skipped, got %s", got)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if got := pick(fo); got != "a" {
+		t.Errorf("Expected the first key back after its cooldown, got %s", got)
+	}
+
+	fo.Rejected(Key{Value: "a"})
+	fo.Rejected(Key{Value: "c"})
+	if _, err := fo.Pick(); !errors.Is(err, ErrNoUsableKey) {
+		t.Errorf("Expected ErrNoUsableKey, got %v", err)
+	}
+	if err := fo.Ready(); err != nil {
+		t.Errorf("Expected rejected static keys to be benched rather than revoked, got %v", err)
+	}
+
+	now = now.Add(rejectedCooldown)
+	if got := pick(fo); got != "a" {
+		t.Errorf("Expected a rejected static key back after its cooldown, got %s", got)
+	}
+}
+
+func TestKeyRing_FileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groq_api_key")
+	if err := os.WriteFile(path, []byte("# primary\nkey-1\n\nkey-2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewKeyRing("llm api key", []Value{"env-key"}, path, Failover, time.Minute)
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	if r.Len() != 3 {
+		t.Fatalf("Expected 3 keys, got %d", r.Len())
+	}
+	r.Rejected(Key{Value: "env-key"})
+	r.Rejected(Key{Value: "key-1"})
+
+	if err := os.WriteFile(path, []byte("key-2\nkey-3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	var got []string
+	for _, v := range r.Values() {
+		got = append(got, v.Reveal())
+	}
+	if strings.Join(got, ",") != "env-key,key-2,key-3" {
+		t.Errorf("Expected the rotated keys and the benched env key, got %v", got)
+	}
+
+	r.Rejected(Key{Value: "key-2"})
+	r.Rejected(Key{Value: "key-3"})
+	if k, err := r.Pick(); err == nil {
+		t.Errorf("Expected every key to be out of rotation, got %s", k.ID)
+	}
+	if err := r.Ready(); err != nil {
+		t.Errorf("Expected the benched env key to keep the ring ready, got %v", err)
+	}
+	fileOnly, err := NewKeyRing("llm api key", nil, path, Failover, time.Minute)
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	fileOnly.Rejected(Key{Value: "key-2"})
+	fileOnly.Rejected(Key{Value: "key-3"})
+	if fileOnly.Ready() == nil {
+		t.Error("Expected a ring with every file key revoked not to be ready")
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reload(); err == nil {
+		t.Error("Expected a missing file to fail the reload")
+	}
+	if r.Len() != 3 {
+		t.Errorf("Expected a failed reload to keep the keys, got %d", r.Len())
+	}
+}
+
+func TestKeyRing_Scrub(t *testing.T) {
+	r := Static("gsk_live_123")
+	cause := errors.New("provider said: invalid key gsk_live_123")
+
+	err := r.Scrub(fmt.Errorf("call failed: %w", cause))
+	if strings.Contains(err.Error(), "gsk_live_123") {
+		t.Errorf("Expected the key to be scrubbed, got %q", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("Expected the scrubbed error to wrap the cause")
+	}
+}
@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+const serviceName = "ai-risk-engine"
+
+const (
+	AttrTransactionID    = attribute.Key("risk.transaction_id")
+	AttrTenant           = attribute.Key("risk.tenant")
+	AttrDecision         = attribute.Key("risk.decision")
+	AttrRoute            = attribute.Key("risk.route")
+	AttrRuleName         = attribute.Key("risk.rule.name")
+	AttrRuleFired        = attribute.Key("risk.rule.fired")
+	AttrModel            = attribute.Key("llm.model")
+	AttrPromptVersion    = attribute.Key("llm.prompt_version")
+	AttrPromptTokens     = attribute.Key("llm.usage.prompt_tokens")
+	AttrCompletionTokens = attribute.Key("llm.usage.completion_tokens")
+)
+
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup installs the global tracer provider and W3C propagators. With the
+// "none" exporter nothing is installed and the OTel no-op provider stays in place.
+func Setup(ctx context.Context, exporter string) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+
+	switch strings.ToLower(exporter) {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		spanExporter, err = otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
@@ -2,21 +2,19 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math/big"
 	"regexp"
-	"strconv"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/tokyosplif/ai-risk-engine/internal/domain"
-)
-
-const (
-	lowValueThreshold        = 500.0
-	heuristicBlockMinAmount  = 500.0
-	heuristicBlockMultiplier = 2.0
-	highValueThreshold       = 10000.0
-	highConfidenceThreshold  = 75
-	lowConfidenceThreshold   = 30
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+	"github.com/tokyosplif/ai-risk-engine/internal/tracing"
+	"go.opentelemetry.io/otel"
 )
 
 var (
@@ -24,80 +22,635 @@ var (
 	maxTxRegex  = regexp.MustCompile(`MaxTx:?\s*([0-9]+(\.[0-9]+)?)`)
 )
 
+var tracer = otel.Tracer("github.com/tokyosplif/ai-risk-engine/internal/usecase")
+
 type LLMClient interface {
 	Analyze(ctx context.Context, txData string, userProfile string) (domain.RiskAssessment, error)
 }
 
+type AuditRecorder interface {
+	Record(ctx context.Context, rec domain.AuditRecord) error
+}
+
+// FeatureStore records a user's transactions and aggregates their history.
+// ObserveAndFeatures returns the features before tx and records tx in one
+// step, so that concurrent transactions see each other.
+type FeatureStore interface {
+	ObserveAndFeatures(ctx context.Context, tx domain.Transaction, at time.Time) (domain.UserFeatures, error)
+}
+
+// ListChecker looks a transaction up on the managed allow/deny lists.
+type ListChecker interface {
+	Lookup(tx domain.Transaction, list string) (domain.ListEntry, bool)
+}
+
+// FXProvider converts amounts into the base currency used by rules and prompts.
+type FXProvider interface {
+	Base() string
+	Rate(currency string) (*big.Rat, error)
+	Thresholds(currency string) (domain.Thresholds, bool)
+}
+
+// GeoResolver maps free-text locations and IP addresses to coordinates.
+type GeoResolver interface {
+	Resolve(location string) (domain.GeoPoint, bool)
+	ResolveIP(ip string) (domain.GeoPoint, bool)
+}
+
+// MerchantCatalog resolves merchant descriptors to catalog entries.
+type MerchantCatalog interface {
+	Match(descriptor string) (domain.Merchant, bool)
+}
+
+// ReviewQueue takes the decisions marked for manual review.
+type ReviewQueue interface {
+	Enqueue(ctx context.Context, c domain.ReviewCase) (domain.ReviewCase, error)
+}
+
+// EventPublisher publishes decisions to downstream systems.
+type EventPublisher interface {
+	Publish(ctx context.Context, e domain.DecisionEvent) error
+}
+
+// MessageComposer writes the push message shown to the user for the final
+// verdict. llm is the model's verdict before overrides.
+type MessageComposer interface {
+	Compose(tx domain.Transaction, final, llm domain.RiskAssessment) domain.Notification
+}
+
+// Notifier sends push messages to users.
+type Notifier interface {
+	Notify(ctx context.Context, n domain.Notification) error
+}
+
+// DecisionLog remembers recent decisions so that outcome labels reported
+// later can be joined to them.
+type DecisionLog interface {
+	RecordDecision(tenant string, d domain.LabeledDecision)
+}
+
+// Degradation policies decide the verdict when the LLM is unavailable.
+const (
+	DegradeAllow  = "allow"
+	DegradeBlock  = "block"
+	DegradeReview = "review"
+)
+
 type Analyzer struct {
-	llm LLMClient
+	tenant      string
+	rules       []rule
+	screens     []screen
+	degradation string
+
+	llm        LLMClient
+	audit      AuditRecorder
+	features   FeatureStore
+	lists      ListChecker
+	fx         FXProvider
+	geo        GeoResolver
+	merchants  MerchantCatalog
+	reviews    ReviewQueue
+	review     domain.ReviewPolicy
+	decisions  DecisionLog
+	events     EventPublisher
+	messages   MessageComposer
+	notifier   Notifier
+	notify     []string
+	thresholds domain.Thresholds
+	now        func() time.Time
 }
 
-func NewAnalyzer(llm LLMClient) *Analyzer {
-	return &Analyzer{llm: llm}
+// DefaultThresholds returns the built-in limits expressed in currency, which
+// is the base currency of the analyzer.
+func DefaultThresholds(currency string) domain.Thresholds {
+	return domain.Thresholds{
+		LowValue:          domain.MustParseMoney("500", currency),
+		HeuristicBlockMin: domain.MustParseMoney("500", currency),
+		HighValue:         domain.MustParseMoney("10000", currency),
+		PrescreenAllowMax: domain.MustParseMoney("100", currency),
+	}
 }
 
+// normalized is a transaction converted to the base currency together with
+// the thresholds that apply to it.
+type normalized struct {
+	tx         domain.Transaction
+	thresholds domain.Thresholds
+	currency   string
+	rate       *big.Rat
+}
+
+type Option func(*Analyzer)
+
+func WithTenant(id string) Option {
+	return func(a *Analyzer) {
+		a.tenant = id
+	}
+}
+
+// WithRules restricts the heuristic rules to names, keeping their canonical
+// order. Validate names with ValidateRules first; unknown names are ignored.
+func WithRules(names []string) Option {
+	return func(a *Analyzer) {
+		a.rules = selectByName(rules, names, func(r rule) string { return r.name })
+	}
+}
+
+// WithScreens restricts the prescreen checks to names, like WithRules.
+// Required checks are kept even when names leaves them out.
+func WithScreens(names []string) Option {
+	return func(a *Analyzer) {
+		a.screens = slices.DeleteFunc(slices.Clone(screens), func(s screen) bool {
+			return !s.required && !slices.Contains(names, s.name)
+		})
+	}
+}
+
+// WithDegradation sets the policy applied when the LLM call fails.
+func WithDegradation(policy string) Option {
+	return func(a *Analyzer) {
+		a.degradation = policy
+	}
+}
+
+func WithAuditRecorder(r AuditRecorder) Option {
+	return func(a *Analyzer) {
+		a.audit = r
+	}
+}
+
+func WithFeatureStore(s FeatureStore) Option {
+	return func(a *Analyzer) {
+		a.features = s
+	}
+}
+
+func WithLists(l ListChecker) Option {
+	return func(a *Analyzer) {
+		a.lists = l
+	}
+}
+
+func WithFX(fx FXProvider) Option {
+	return func(a *Analyzer) {
+		a.fx = fx
+	}
+}
+
+func WithGeo(g GeoResolver) Option {
+	return func(a *Analyzer) {
+		a.geo = g
+	}
+}
+
+func WithMerchants(c MerchantCatalog) Option {
+	return func(a *Analyzer) {
+		a.merchants = c
+	}
+}
+
+// WithReviewQueue opens a case in q for every review decision.
+func WithReviewQueue(q ReviewQueue) Option {
+	return func(a *Analyzer) {
+		a.reviews = q
+	}
+}
+
+// WithReviewPolicy sets the SLA and callback of the tenant's review cases.
+func WithReviewPolicy(p domain.ReviewPolicy) Option {
+	return func(a *Analyzer) {
+		a.review = p
+	}
+}
+
+// WithDecisionLog records every decision in l.
+func WithDecisionLog(l DecisionLog) Option {
+	return func(a *Analyzer) {
+		a.decisions = l
+	}
+}
+
+// WithEventPublisher publishes every decision to p.
+func WithEventPublisher(p EventPublisher) Option {
+	return func(a *Analyzer) {
+		a.events = p
+	}
+}
+
+// WithMessageComposer replaces the model's push message with the one c
+// composes.
+func WithMessageComposer(c MessageComposer) Option {
+	return func(a *Analyzer) {
+		a.messages = c
+	}
+}
+
+// WithNotifier sends the push message through n for the given decisions.
+// Messages are only sent when a composer is set.
+func WithNotifier(n Notifier, decisions []string) Option {
+	return func(a *Analyzer) {
+		a.notifier = n
+		a.notify = decisions
+	}
+}
+
+// WithThresholds overrides the default limits. They must be expressed in the
+// base currency.
+func WithThresholds(t domain.Thresholds) Option {
+	return func(a *Analyzer) {
+		a.thresholds = t
+	}
+}
+
+func NewAnalyzer(llm LLMClient, opts ...Option) *Analyzer {
+	a := &Analyzer{
+		tenant:      domain.DefaultTenant,
+		rules:       rules,
+		screens:     screens,
+		degradation: DegradeAllow,
+		llm:         llm,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.thresholds == (domain.Thresholds{}) {
+		a.thresholds = DefaultThresholds(a.base())
+	}
+	return a
+}
+
+func (a *Analyzer) base() string {
+	if a.fx != nil {
+		return a.fx.Base()
+	}
+	return domain.DefaultBaseCurrency
+}
+
+// ProcessAnalysis analyzes a transaction given in its LLM text form.
 func (a *Analyzer) ProcessAnalysis(ctx context.Context, txData, userProfile string) (domain.RiskAssessment, error) {
-	assessment, err := a.llm.Analyze(ctx, txData, userProfile)
+	amount, err := extractAmount(txData)
 	if err != nil {
-		return domain.RiskAssessment{IsBlocked: false, Reason: "fail-safe: ai service unavailable"}, nil
+		return domain.RiskAssessment{}, err
 	}
+	tx := domain.Transaction{Amount: amount, UserProfile: userProfile}
+	return a.process(ctx, tx, txData)
+}
+
+// ProcessTransaction normalizes tx to the base currency and analyzes it. It
+// returns domain.ErrUnsupportedCurrency for currencies without an FX rate.
+func (a *Analyzer) ProcessTransaction(ctx context.Context, tx domain.Transaction) (domain.RiskAssessment, error) {
+	return a.process(ctx, tx, "")
+}
 
-	amount := extractAmount(txData)
-	maxTx := extractMaxTx(userProfile)
+// process analyzes tx. An empty txData means the LLM text is rendered from
+// the normalized transaction.
+func (a *Analyzer) process(ctx context.Context, tx domain.Transaction, txData string) (domain.RiskAssessment, error) {
+	ctx, span := tracer.Start(ctx, "Analyzer.ProcessAnalysis")
+	defer span.End()
 
-	if amount < lowValueThreshold && assessment.IsBlocked && strings.Contains(strings.ToLower(assessment.Reason), "amount") {
-		assessment.IsBlocked = false
-		assessment.Reason = addTag(assessment.Reason, "[Low Value Pass]")
-		return assessment, nil
+	span.SetAttributes(tracing.AttrTenant.String(a.tenant))
+	if tx.TenantID == "" {
+		tx.TenantID = a.tenant
 	}
 
-	if maxTx > 0 && amount > maxTx*heuristicBlockMultiplier && amount > heuristicBlockMinAmount {
-		assessment.IsBlocked = true
-		assessment.Reason = addTag(assessment.Reason, fmt.Sprintf("[Heuristic Block] Amount (%.2f) exceeds historical max (%.2f).", amount, maxTx))
-		return assessment, nil
+	n, err := a.normalize(tx)
+	if err != nil {
+		return domain.RiskAssessment{}, err
+	}
+	if txData == "" {
+		txData = n.tx.Summary()
 	}
 
-	if amount > highValueThreshold && assessment.ConfidenceScore <= highConfidenceThreshold {
-		assessment.IsBlocked = false
-		assessment.Reason = addTag(assessment.Reason, "[PENDING REVIEW]")
-		return assessment, nil
+	geo := a.enrichGeo(&n.tx)
+	a.enrichMerchant(&n.tx)
+
+	start := a.now()
+	features := a.observeFeatures(ctx, n.tx, start)
+	addTravel(&geo, features, start)
+
+	rec := domain.AuditRecord{Transaction: n.tx, TxData: txData, Features: features}
+	if a.geo != nil {
+		rec.Geo = &geo
+	}
+	assessment, err := a.evaluate(ctx, n, txData, features, geo, &rec)
+	metrics.AnalysisDuration.WithLabelValues(a.tenant).Observe(time.Since(start).Seconds())
+
+	decision := assessment.Decision()
+	if err == nil {
+		// A failed evaluation has no verdict and must not count as an allow.
+		metrics.Decisions.WithLabelValues(a.tenant, decision).Inc()
+		metrics.Routes.WithLabelValues(a.tenant, assessment.Route).Inc()
+		span.SetAttributes(
+			tracing.AttrDecision.String(decision),
+			tracing.AttrRoute.String(assessment.Route),
+		)
 	}
 
-	switch {
-	case assessment.ConfidenceScore <= lowConfidenceThreshold:
-		assessment.IsBlocked = false
-		assessment.Reason = addTag(assessment.Reason, "[Low Confidence Ignore]")
-	case assessment.ConfidenceScore <= highConfidenceThreshold:
-		if assessment.IsBlocked {
-			assessment.IsBlocked = false
-			assessment.Reason = addTag(assessment.Reason, "[PENDING REVIEW]")
+	var note *domain.Notification
+	if err == nil && a.messages != nil {
+		msg := a.composeMessage(ctx, n.tx, &assessment, rec.LLMVerdict)
+		note = &msg
+	}
+
+	rec.Final = assessment
+	if auditErr := a.recordAudit(ctx, rec); auditErr != nil {
+		// A decision without an audit entry must not reach the caller, and
+		// the caller retries it, so nothing has been acted on yet.
+		return domain.RiskAssessment{}, auditErr
+	}
+	if err != nil {
+		return assessment, err
+	}
+
+	if decision == domain.DecisionReview {
+		assessment.ReviewCaseID = a.enqueueReview(ctx, n.tx, assessment, start)
+		rec.Final.ReviewCaseID = assessment.ReviewCaseID
+	}
+	if note != nil {
+		a.sendNotification(ctx, *note)
+	}
+	if a.decisions != nil {
+		a.decisions.RecordDecision(a.tenant, domain.NewLabeledDecision(n.tx, assessment, start))
+	}
+	a.publishEvent(ctx, rec, start)
+
+	return assessment, err
+}
+
+func (a *Analyzer) evaluate(ctx context.Context, n normalized, txData string, features *domain.UserFeatures, geo domain.GeoFeatures, rec *domain.AuditRecord) (domain.RiskAssessment, error) {
+	tx := n.tx
+	maxTx, err := extractMaxTx(tx.UserProfile, n)
+	if err != nil {
+		return domain.RiskAssessment{}, err
+	}
+	in := ruleInput{
+		tenant:       a.tenant,
+		amount:       tx.Amount,
+		maxTx:        maxTx,
+		thresholds:   n.thresholds,
+		merchant:     tx.Merchant,
+		merchantInfo: tx.MerchantInfo,
+		location:     tx.Location,
+		homeLocation: extractHomeLocation(tx.UserProfile),
+		geo:          geo,
+	}
+	if err := in.checkCurrencies(); err != nil {
+		return domain.RiskAssessment{}, err
+	}
+
+	if a.lists != nil {
+		if e, ok := a.lists.Lookup(tx, domain.ListDeny); ok {
+			in.denied = &e
+		}
+		if e, ok := a.lists.Lookup(tx, domain.ListAllow); ok {
+			in.allowed = &e
+		}
+	}
+
+	userProfile := tx.UserProfile
+	if features != nil {
+		in.features = *features
+		in.hasFeatures = true
+		userProfile += "\n" + features.Summary()
+	}
+	if a.geo != nil {
+		userProfile += "\n" + geo.Summary()
+	}
+	if tx.MerchantInfo != nil {
+		userProfile += "\n" + tx.MerchantInfo.Summary()
+	}
+
+	if verdict, ok := a.prescreen(ctx, in); ok {
+		verdict.Explanation.Factors = explain(in)
+		return verdict, nil
+	}
+
+	assessment, err := a.llm.Analyze(ctx, txData, userProfile)
+	rec.LLM = assessment.LLM
+	if err != nil {
+		rec.LLMError = err.Error()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// The caller gave up; no verdict will be read.
+			return domain.RiskAssessment{}, ctxErr
+		}
+		verdict := a.degrade(in, err)
+		verdict.LLM = assessment.LLM
+		verdict.Explanation.Factors = explain(in)
+		return verdict, nil
+	}
+	rec.LLMVerdict = assessment
+	assessment.Route = domain.RouteLLM
+	assessment.RouteReason = "no prescreen rule matched"
+	assessment.Explanation.Factors = explain(in)
+
+	for _, r := range a.rules {
+		if a.applyRule(ctx, r, in, &assessment) && r.terminal {
+			break
 		}
 	}
 
 	return assessment, nil
 }
 
-func addTag(reason, tag string) string {
+// degrade is the verdict returned when the LLM call failed with cause.
+func (a *Analyzer) degrade(in ruleInput, cause error) domain.RiskAssessment {
+	state := "unavailable"
+	if errors.Is(cause, domain.ErrLLMOverloaded) {
+		state = "overloaded"
+	}
+
+	verdict := domain.RiskAssessment{
+		Route:       domain.RouteDegraded,
+		RouteReason: "ai service " + state + ", " + a.degradation + " policy applied",
+	}
+
+	switch a.degradation {
+	case DegradeBlock:
+		verdict.IsBlocked = true
+		verdict.Reason = "fail-closed: ai service " + state
+	case DegradeReview:
+		verdict.Reason = addTag(in.tenant, "fail-safe: ai service "+state, domain.TagPendingReview)
+	default:
+		verdict.Reason = "fail-safe: ai service " + state
+	}
+	return verdict
+}
+
+// normalize converts the amount to the base currency. Profile amounts such as
+// MaxTx are assumed to be in the transaction currency and are converted at the
+// same rate.
+func (a *Analyzer) normalize(tx domain.Transaction) (normalized, error) {
+	base := a.base()
+
+	currency := domain.NormalizeCurrency(tx.Amount.Currency)
+	if currency == "" {
+		currency = base
+	}
+
+	// Re-express the amount at the precision of the resolved currency; this
+	// is a no-op unless the caller left the currency empty.
+	amount, err := tx.Amount.Convert(big.NewRat(1, 1), currency)
+	if err != nil {
+		return normalized{}, err
+	}
+
+	n := normalized{tx: tx, thresholds: a.thresholds, currency: currency, rate: big.NewRat(1, 1)}
+	n.tx.Amount = amount
+
+	if currency == base {
+		return n, nil
+	}
+	if a.fx == nil {
+		return normalized{}, fmt.Errorf("%w: %q", domain.ErrUnsupportedCurrency, currency)
+	}
+
+	rate, err := a.fx.Rate(currency)
+	if err != nil {
+		return normalized{}, err
+	}
+
+	n.rate = rate
+	n.tx.OriginalAmount = amount
+	if n.tx.Amount, err = amount.Convert(rate, base); err != nil {
+		return normalized{}, err
+	}
+	if t, ok := a.fx.Thresholds(currency); ok {
+		if n.thresholds, err = t.Convert(rate, base); err != nil {
+			return normalized{}, err
+		}
+	}
+	return n, nil
+}
+
+func (a *Analyzer) enrichMerchant(tx *domain.Transaction) {
+	if a.merchants == nil || tx.Merchant == "" {
+		return
+	}
+	if m, ok := a.merchants.Match(tx.Merchant); ok {
+		tx.MerchantInfo = &m
+	}
+}
+
+// observeFeatures records tx in the user's history and returns the features
+// from before it. It returns nil when no store is configured or the
+// transaction has no user, so that callers can tell "unknown" apart from "no
+// history".
+func (a *Analyzer) observeFeatures(ctx context.Context, tx domain.Transaction, at time.Time) *domain.UserFeatures {
+	if a.features == nil || tx.UserID == "" {
+		return nil
+	}
+	f, err := a.features.ObserveAndFeatures(ctx, tx, at)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load user features", "err", err)
+		return nil
+	}
+	return &f
+}
+
+// enqueueReview opens a review case and returns its ID, or "" when no queue
+// is configured or the case could not be opened. A failure does not change
+// the verdict.
+func (a *Analyzer) enqueueReview(ctx context.Context, tx domain.Transaction, r domain.RiskAssessment, at time.Time) string {
+	if a.reviews == nil {
+		return ""
+	}
+	c, err := a.reviews.Enqueue(ctx, domain.NewReviewCase(tx, r, a.review, at))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to open review case", "err", err)
+		return ""
+	}
+	return c.ID
+}
+
+// composeMessage sets the verdict's push message to the composed one and
+// returns the notification for it.
+func (a *Analyzer) composeMessage(ctx context.Context, tx domain.Transaction, assessment *domain.RiskAssessment, llm domain.RiskAssessment) domain.Notification {
+	n := a.messages.Compose(tx, *assessment, llm)
+	assessment.AIPushMessage = n.Text
+	assessment.AIPushLocale = n.Locale
+
+	rejected := n.Rejected
+	if rejected == "" {
+		rejected = "none"
+	}
+	metrics.PushMessages.WithLabelValues(a.tenant, n.Decision, n.Source, rejected).Inc()
+	if n.Rejected != "" {
+		slog.DebugContext(ctx, "push message replaced by template", "decision", n.Decision, "policy", n.Rejected)
+	}
+	return n
+}
+
+// sendNotification sends n to the user when its decision calls for it.
+func (a *Analyzer) sendNotification(ctx context.Context, n domain.Notification) {
+	if a.notifier == nil || !slices.Contains(a.notify, n.Decision) {
+		return
+	}
+	if err := a.notifier.Notify(ctx, n); err != nil {
+		slog.ErrorContext(ctx, "failed to queue notification", "err", err)
+	}
+}
+
+// publishEvent hands the decision to the event publisher. A failure does not
+// change the verdict.
+func (a *Analyzer) publishEvent(ctx context.Context, rec domain.AuditRecord, at time.Time) {
+	if a.events == nil {
+		return
+	}
+	if err := a.events.Publish(ctx, domain.NewDecisionEvent(rec, at)); err != nil {
+		slog.ErrorContext(ctx, "failed to publish decision event", "err", err)
+	}
+}
+
+// recordAudit writes rec to the audit trail. The cause of a failure is logged
+// and ErrAuditUnavailable returned, so file paths do not reach callers.
+func (a *Analyzer) recordAudit(ctx context.Context, rec domain.AuditRecord) error {
+	if a.audit == nil {
+		return nil
+	}
+	if err := a.audit.Record(ctx, rec); err != nil {
+		slog.ErrorContext(ctx, "failed to write audit record", "err", err)
+		return domain.ErrAuditUnavailable
+	}
+	return nil
+}
+
+func addTag(tenant, reason, tag string) string {
 	if strings.Contains(reason, tag) {
 		return reason
 	}
+	metrics.RulesFired.WithLabelValues(tenant, ruleName(tag)).Inc()
 	return tag + " " + reason
 }
 
-func extractAmount(data string) float64 {
+func ruleName(tag string) string {
+	if i := strings.Index(tag, "]"); i >= 0 {
+		return tag[:i+1]
+	}
+	return tag
+}
+
+// extractAmount parses the amount from the LLM text form. The text carries no
+// currency, so the amount is taken to be in the base currency.
+func extractAmount(data string) (domain.Money, error) {
 	matches := amountRegex.FindStringSubmatch(data)
 	if len(matches) > 1 {
-		val, _ := strconv.ParseFloat(matches[1], 64)
-		return val
+		return domain.ParseMoney(matches[1], "")
 	}
-	return 0
+	return domain.Money{}, nil
 }
 
-func extractMaxTx(profile string) float64 {
+// extractMaxTx returns the profile's MaxTx converted to the base currency, or
+// a zero amount if the profile has none.
+func extractMaxTx(profile string, n normalized) (domain.Money, error) {
+	base := n.tx.Amount.Currency
 	matches := maxTxRegex.FindStringSubmatch(profile)
-	if len(matches) > 1 {
-		val, _ := strconv.ParseFloat(matches[1], 64)
-		return val
+	if len(matches) < 2 {
+		return domain.Money{Currency: base}, nil
+	}
+	maxTx, err := domain.ParseMoney(matches[1], n.currency)
+	if err != nil {
+		return domain.Money{}, err
 	}
-	return 0
+	return maxTx.Convert(n.rate, base)
 }
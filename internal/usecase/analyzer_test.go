@@ -2,10 +2,17 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/big"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
 )
 
 type MockLLMClient struct {
@@ -70,3 +77,592 @@ func TestProcessAnalysis_HeuristicBlock(t *testing.T) {
 		t.Errorf("Expected reason to contain '[Heuristic Block]', got: %s", result.Reason)
 	}
 }
+
+func TestProcessTransaction_Explanation(t *testing.T) {
+	mockAI := &MockLLMClient{
+		Response: domain.RiskAssessment{
+			IsBlocked:       false,
+			Reason:          "Normal transaction",
+			ConfidenceScore: 95,
+			Explanation:     domain.Explanation{LLMRationale: "Normal transaction"},
+		},
+	}
+
+	analyzer := NewAnalyzer(mockAI)
+
+	result, _ := analyzer.ProcessTransaction(context.Background(), domain.Transaction{
+		Amount:      domain.MustParseMoney("2500.0", "USD"),
+		Merchant:    "Binance",
+		Location:    "Lagos",
+		UserProfile: "MaxTx: 500.0, Location: Kyiv",
+	})
+
+	if result.Explanation.LLMRationale != "Normal transaction" {
+		t.Errorf("Expected LLM rationale to be preserved, got: %s", result.Explanation.LLMRationale)
+	}
+
+	directions := map[string]string{}
+	for _, f := range result.Explanation.Factors {
+		directions[f.Name] = f.Direction
+	}
+	for _, name := range []string{"amount_vs_profile_max", "geo_mismatch", "merchant_category"} {
+		if directions[name] != domain.DirectionIncreasesRisk {
+			t.Errorf("Expected factor %s to increase risk, got %q", name, directions[name])
+		}
+	}
+
+	if len(result.Overrides) != 1 || result.Overrides[0].Rule != "heuristic_block" || !result.Overrides[0].ChangedOutcome() {
+		t.Errorf("Expected a single outcome-changing heuristic_block override, got %+v", result.Overrides)
+	}
+}
+
+func TestMerchantFactor_OmitsMissingMCC(t *testing.T) {
+	f := merchantFactor(ruleInput{merchantInfo: &domain.Merchant{Name: "Paxful", Category: "p2p_transfer", RiskTier: domain.RiskTierHigh}})
+	if want := `merchant "Paxful" is p2p_transfer, risk tier high`; f.Detail != want {
+		t.Errorf("Expected %q, got %q", want, f.Detail)
+	}
+}
+
+func TestProcessAnalysis_PrescreenBlockSkipsLLM(t *testing.T) {
+	mockAI := &MockLLMClient{Err: errors.New("llm must not be called")}
+
+	analyzer := NewAnalyzer(mockAI)
+
+	result, _ := analyzer.ProcessAnalysis(context.Background(), "Amount: 99999.0, Merchant: Unknown", "MaxTx: 500.0")
+
+	if !result.IsBlocked || result.Route != domain.RoutePrescreenBlock {
+		t.Errorf("Expected prescreen block, got blocked=%v route=%q", result.IsBlocked, result.Route)
+	}
+
+	if !strings.Contains(result.RouteReason, "extreme_amount") {
+		t.Errorf("Expected route reason to name the screen, got: %s", result.RouteReason)
+	}
+}
+
+type stubLists map[string]domain.ListEntry
+
+func (s stubLists) Lookup(_ domain.Transaction, list string) (domain.ListEntry, bool) {
+	e, ok := s[list]
+	return e, ok
+}
+
+func TestProcessTransaction_DenylistBlocksWithoutPrescreen(t *testing.T) {
+	lists := stubLists{
+		domain.ListDeny:  {List: domain.ListDeny, Entity: domain.EntityDevice, Value: "dev-1", Reason: "chargeback"},
+		domain.ListAllow: {List: domain.ListAllow, Entity: domain.EntityUser, Value: "u1", Reason: "vip"},
+	}
+	// A tenant with "prescreen": [] selects no checks.
+	analyzer := NewAnalyzer(&MockLLMClient{Response: domain.RiskAssessment{Reason: "Normal transaction", ConfidenceScore: 95}},
+		WithLists(lists),
+		WithScreens([]string{}),
+	)
+
+	tx := domain.Transaction{UserID: "u1", DeviceID: "dev-1", Amount: domain.MustParseMoney("700", "USD"), Merchant: "Apple Store", UserProfile: "MaxTx: 1000.0"}
+	result, err := analyzer.ProcessTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.IsBlocked || result.Route != domain.RoutePrescreenBlock || !strings.Contains(result.RouteReason, "denylisted") {
+		t.Errorf("Expected the denylisted device to be blocked despite the allowlisted user, got blocked=%v route=%q reason=%q", result.IsBlocked, result.Route, result.RouteReason)
+	}
+}
+
+type stubFX struct {
+	rates      map[string]*big.Rat
+	thresholds map[string]domain.Thresholds
+}
+
+func (s stubFX) Base() string { return "USD" }
+
+func (s stubFX) Rate(currency string) (*big.Rat, error) {
+	rate, ok := s.rates[currency]
+	if !ok {
+		return nil, domain.ErrUnsupportedCurrency
+	}
+	return rate, nil
+}
+
+func (s stubFX) Thresholds(currency string) (domain.Thresholds, bool) {
+	t, ok := s.thresholds[currency]
+	return t, ok
+}
+
+func TestProcessTransaction_CurrencyNormalization(t *testing.T) {
+	mockAI := &MockLLMClient{
+		Response: domain.RiskAssessment{
+			IsBlocked:       true,
+			Reason:          "Amount is unusual",
+			ConfidenceScore: 90,
+		},
+	}
+
+	fx := stubFX{
+		rates: map[string]*big.Rat{"UAH": big.NewRat(1, 40)},
+		thresholds: map[string]domain.Thresholds{
+			"UAH": {
+				LowValue:          domain.MustParseMoney("20000", "UAH"),
+				HeuristicBlockMin: domain.MustParseMoney("20000", "UAH"),
+				HighValue:         domain.MustParseMoney("400000", "UAH"),
+				PrescreenAllowMax: domain.MustParseMoney("4000", "UAH"),
+			},
+		},
+	}
+	analyzer := NewAnalyzer(mockAI, WithFX(fx))
+
+	// 15,000 UAH is 375 USD: under the UAH low-value override.
+	result, err := analyzer.ProcessTransaction(context.Background(), domain.Transaction{
+		Amount: domain.Money{MinorUnits: 1500000, Currency: "UAH"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsBlocked || !strings.Contains(result.Reason, "[Low Value Pass]") {
+		t.Errorf("Expected Low Value Pass on normalized amount, got blocked=%v reason=%s", result.IsBlocked, result.Reason)
+	}
+
+	_, err = analyzer.ProcessTransaction(context.Background(), domain.Transaction{Amount: domain.MustParseMoney("10", "XYZ")})
+	if !errors.Is(err, domain.ErrUnsupportedCurrency) {
+		t.Errorf("Expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+type stubGeo map[string]domain.GeoPoint
+
+func (s stubGeo) Resolve(location string) (domain.GeoPoint, bool) {
+	p, ok := s[location]
+	return p, ok
+}
+
+func (s stubGeo) ResolveIP(string) (domain.GeoPoint, bool) {
+	return domain.GeoPoint{}, false
+}
+
+type stubFeatures struct {
+	features domain.UserFeatures
+}
+
+func (s stubFeatures) ObserveAndFeatures(context.Context, domain.Transaction, time.Time) (domain.UserFeatures, error) {
+	return s.features, nil
+}
+
+// countingFeatures reports every transaction recorded so far as one in the
+// last minute.
+type countingFeatures struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (s *countingFeatures) ObserveAndFeatures(context.Context, domain.Transaction, time.Time) (domain.UserFeatures, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := domain.UserFeatures{HasHistory: s.n > 0, TxCount1m: s.n, TxCount24h: s.n}
+	s.n++
+	return f, nil
+}
+
+// barrierLLM holds every call until all expected calls have arrived, so that
+// the transactions are analyzed concurrently.
+type barrierLLM struct {
+	arrived sync.WaitGroup
+}
+
+func (b *barrierLLM) Analyze(context.Context, string, string) (domain.RiskAssessment, error) {
+	b.arrived.Done()
+	b.arrived.Wait()
+	return domain.RiskAssessment{Reason: "Normal transaction", ConfidenceScore: 95}, nil
+}
+
+func TestProcessTransaction_ConcurrentBurstTripsVelocity(t *testing.T) {
+	const burst = 8
+	llm := &barrierLLM{}
+	llm.arrived.Add(burst)
+	analyzer := NewAnalyzer(llm, WithFeatureStore(&countingFeatures{}))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		blocked int
+	)
+	for i := range burst {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := analyzer.ProcessTransaction(context.Background(), domain.Transaction{
+				ID:          fmt.Sprintf("tx-%d", i),
+				UserID:      "u1",
+				Amount:      domain.MustParseMoney("40", "USD"),
+				Merchant:    "Steam",
+				UserProfile: "MaxTx: 500.0",
+			})
+			if err != nil {
+				t.Errorf("ProcessTransaction: %v", err)
+				return
+			}
+			if strings.Contains(result.Reason, "[Velocity Block]") {
+				mu.Lock()
+				blocked++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if want := burst - velocityBurstCount; blocked != want {
+		t.Errorf("Expected %d transactions of the burst to be blocked for velocity, got %d", want, blocked)
+	}
+}
+
+func TestProcessTransaction_ImpossibleTravel(t *testing.T) {
+	mockAI := &MockLLMClient{
+		Response: domain.RiskAssessment{
+			IsBlocked:       false,
+			Reason:          "Normal transaction",
+			ConfidenceScore: 95,
+		},
+	}
+
+	kyiv := domain.GeoPoint{City: "Kyiv", Country: "UA", Lat: 50.45, Lon: 30.52}
+	lagos := domain.GeoPoint{City: "Lagos", Country: "NG", Lat: 6.52, Lon: 3.38}
+	store := stubFeatures{features: domain.UserFeatures{
+		HasHistory: true,
+		LastGeo:    &lagos,
+		LastGeoAt:  time.Now().Add(-time.Hour),
+	}}
+
+	analyzer := NewAnalyzer(mockAI, WithGeo(stubGeo{"Kyiv": kyiv}), WithFeatureStore(store))
+
+	result, err := analyzer.ProcessTransaction(context.Background(), domain.Transaction{
+		UserID:      "u1",
+		Amount:      domain.MustParseMoney("40", "USD"),
+		Merchant:    "Silpo",
+		Location:    "Kyiv",
+		UserProfile: "Location: Kyiv",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !result.IsBlocked || !strings.Contains(result.Reason, "[Impossible Travel]") {
+		t.Errorf("Expected impossible travel block, got blocked=%v reason=%s", result.IsBlocked, result.Reason)
+	}
+}
+
+func TestEnrichGeo_CountryOnlyPointsHaveNoDistances(t *testing.T) {
+	ukraine := domain.GeoPoint{Country: "UA", Lat: 48.38, Lon: 31.17}
+	lviv := domain.GeoPoint{City: "Lviv", Country: "UA", Lat: 49.84, Lon: 24.03}
+	analyzer := NewAnalyzer(&MockLLMClient{}, WithGeo(stubGeo{"Lviv": lviv, "Ukraine": ukraine}))
+
+	tx := domain.Transaction{Location: "Lviv", UserProfile: "Location: Ukraine"}
+	g := analyzer.enrichGeo(&tx)
+	if g.Home == nil || g.HasHomeDistance {
+		t.Errorf("Expected no home distance to a country centroid, got %+v", g)
+	}
+
+	addTravel(&g, &domain.UserFeatures{LastGeo: &ukraine, LastGeoAt: time.Now().Add(-time.Minute)}, time.Now())
+	if g.HasTravel {
+		t.Errorf("Expected no travel from a country centroid, got %+v", g)
+	}
+	addTravel(&g, &domain.UserFeatures{LastGeo: &lviv, LastGeoAt: time.Now().Add(-time.Minute)}, time.Now())
+	if !g.HasTravel || g.TravelDistanceKm != 0 {
+		t.Errorf("Expected travel between two cities, got %+v", g)
+	}
+}
+
+func TestRouter_TenantDegradation(t *testing.T) {
+	mockAI := &MockLLMClient{Err: errors.New("provider unavailable")}
+
+	router := NewRouter(domain.DefaultTenant, map[string]*Analyzer{
+		domain.DefaultTenant: NewAnalyzer(mockAI),
+		"strict":             NewAnalyzer(mockAI, WithTenant("strict"), WithDegradation(DegradeBlock)),
+	})
+
+	tx := domain.Transaction{
+		UserID:      "u1",
+		Amount:      domain.MustParseMoney("700", "USD"),
+		Merchant:    "Apple Store",
+		UserProfile: "MaxTx: 1000.0",
+	}
+
+	result, err := router.ProcessTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IsBlocked {
+		t.Errorf("Expected the default tenant to fail open, got reason: %s", result.Reason)
+	}
+
+	tx.TenantID = "strict"
+	result, err = router.ProcessTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.IsBlocked {
+		t.Errorf("Expected the strict tenant to fail closed, got reason: %s", result.Reason)
+	}
+
+	tx.TenantID = "unknown"
+	if _, err := router.ProcessTransaction(context.Background(), tx); !errors.Is(err, domain.ErrUnknownTenant) {
+		t.Errorf("Expected ErrUnknownTenant, got %v", err)
+	}
+}
+
+func TestRouter_TenantThresholds(t *testing.T) {
+	mockAI := &MockLLMClient{
+		Response: domain.RiskAssessment{
+			IsBlocked:       true,
+			Reason:          "Amount is unusual for this user",
+			ConfidenceScore: 90,
+		},
+	}
+
+	strict := DefaultThresholds("USD")
+	strict.LowValue = domain.MustParseMoney("50", "USD")
+
+	router := NewRouter(domain.DefaultTenant, map[string]*Analyzer{
+		domain.DefaultTenant: NewAnalyzer(mockAI),
+		"strict":             NewAnalyzer(mockAI, WithTenant("strict"), WithThresholds(strict), WithRules([]string{"low_value_pass"})),
+	})
+
+	tx := domain.Transaction{
+		UserID:      "u1",
+		Amount:      domain.MustParseMoney("150", "USD"),
+		Merchant:    "Apple Store",
+		UserProfile: "MaxTx: 1000.0",
+	}
+
+	result, _ := router.ProcessTransaction(context.Background(), tx)
+	if result.IsBlocked {
+		t.Errorf("Expected the default tenant to pass a low value transaction, got reason: %s", result.Reason)
+	}
+
+	tx.TenantID = "strict"
+	result, _ = router.ProcessTransaction(context.Background(), tx)
+	if !result.IsBlocked {
+		t.Errorf("Expected the strict tenant to keep the LLM block, got reason: %s", result.Reason)
+	}
+}
+
+func TestProcessTransaction_ThresholdsInAnotherCurrency(t *testing.T) {
+	thresholds := DefaultThresholds("USD")
+	thresholds.HighValue = domain.MustParseMoney("1000", "EUR")
+	analyzer := NewAnalyzer(&MockLLMClient{}, WithThresholds(thresholds))
+
+	_, err := analyzer.ProcessTransaction(context.Background(), domain.Transaction{UserID: "u1", Amount: domain.MustParseMoney("40", "USD"), Merchant: "Shop"})
+	if !errors.Is(err, domain.ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch instead of a panic, got %v", err)
+	}
+}
+
+func TestProcessTransaction_OverloadedLLMDegrades(t *testing.T) {
+	mockAI := &MockLLMClient{Err: fmt.Errorf("%w: queue full", domain.ErrLLMOverloaded)}
+
+	analyzer := NewAnalyzer(mockAI, WithDegradation(DegradeReview))
+
+	result, err := analyzer.ProcessTransaction(context.Background(), domain.Transaction{
+		UserID:      "u1",
+		Amount:      domain.MustParseMoney("700", "USD"),
+		Merchant:    "Apple Store",
+		UserProfile: "MaxTx: 1000.0",
+	})
+	if err != nil {
+		t.Fatalf("Expected overload to degrade instead of failing, got %v", err)
+	}
+	if result.IsBlocked || !strings.Contains(result.Reason, "overloaded") || !strings.Contains(result.Reason, domain.TagPendingReview) {
+		t.Errorf("Expected an overloaded pending-review verdict, got blocked=%v reason=%s", result.IsBlocked, result.Reason)
+	}
+	if result.Route != domain.RouteDegraded {
+		t.Errorf("Expected the degraded route, got %s", result.Route)
+	}
+}
+
+func TestProcessTransaction_FailedEvaluationIsNotCounted(t *testing.T) {
+	analyzer := NewAnalyzer(&MockLLMClient{Err: context.Canceled}, WithTenant("metrics-error"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tx := domain.Transaction{UserID: "u1", Amount: domain.MustParseMoney("700", "USD"), Merchant: "Apple Store", UserProfile: "MaxTx: 1000.0"}
+	if _, err := analyzer.ProcessTransaction(ctx, tx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the caller's cancellation, got %v", err)
+	}
+	if n := testutil.ToFloat64(metrics.Decisions.WithLabelValues("metrics-error", domain.DecisionAllow)); n != 0 {
+		t.Errorf("Expected a failed evaluation not to count as an allow, got %v", n)
+	}
+	if n := testutil.ToFloat64(metrics.Routes.WithLabelValues("metrics-error", "")); n != 0 {
+		t.Errorf("Expected a failed evaluation not to count as a route, got %v", n)
+	}
+}
+
+type stubReviews struct {
+	cases []domain.ReviewCase
+}
+
+func (s *stubReviews) Enqueue(_ context.Context, c domain.ReviewCase) (domain.ReviewCase, error) {
+	c.ID = fmt.Sprintf("rc_%d", len(s.cases)+1)
+	s.cases = append(s.cases, c)
+	return c, nil
+}
+
+func TestProcessTransaction_ReviewDecisionOpensCase(t *testing.T) {
+	reviews := &stubReviews{}
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	analyzer := NewAnalyzer(&MockLLMClient{Err: errors.New("provider unavailable")},
+		WithTenant("acme"),
+		WithDegradation(DegradeReview),
+		WithReviewQueue(reviews),
+		WithReviewPolicy(domain.ReviewPolicy{SLA: 2 * time.Hour, CallbackURL: "https://acme.test/reviews"}),
+	)
+	analyzer.now = func() time.Time { return now }
+
+	tx := domain.Transaction{
+		ID:          "tx-1",
+		UserID:      "u1",
+		Amount:      domain.MustParseMoney("700", "USD"),
+		Merchant:    "Apple Store",
+		UserProfile: "MaxTx: 1000.0",
+	}
+	result, err := analyzer.ProcessTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Decision() != domain.DecisionReview || result.ReviewCaseID != "rc_1" {
+		t.Fatalf("Expected a review decision with a case, got %s %q", result.Decision(), result.ReviewCaseID)
+	}
+	c := reviews.cases[0]
+	if c.TenantID != "acme" || c.Transaction.ID != "tx-1" || c.Reason != result.Reason {
+		t.Errorf("Expected the case to carry the transaction and verdict, got %+v", c)
+	}
+	if !c.DueAt.Equal(now.Add(2*time.Hour)) || c.CallbackURL != "https://acme.test/reviews" {
+		t.Errorf("Expected the tenant review policy, got due %v callback %q", c.DueAt, c.CallbackURL)
+	}
+
+	analyzer.degradation = DegradeAllow
+	if result, _ := analyzer.ProcessTransaction(context.Background(), tx); result.ReviewCaseID != "" || len(reviews.cases) != 1 {
+		t.Errorf("Expected no case for an allow decision, got %q", result.ReviewCaseID)
+	}
+}
+
+type stubPublisher struct {
+	events []domain.DecisionEvent
+}
+
+func (p *stubPublisher) Publish(_ context.Context, e domain.DecisionEvent) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+func TestProcessTransaction_PublishesDecisionEvent(t *testing.T) {
+	events := &stubPublisher{}
+	analyzer := NewAnalyzer(&MockLLMClient{Response: domain.RiskAssessment{Reason: "Normal transaction", ConfidenceScore: 95}},
+		WithTenant("acme"),
+		WithEventPublisher(events),
+	)
+
+	tx := domain.Transaction{
+		ID:          "tx-1",
+		UserID:      "u1",
+		Amount:      domain.MustParseMoney("2500", "USD"),
+		Merchant:    "Apple Store",
+		UserProfile: "MaxTx: 500.0",
+	}
+	result, err := analyzer.ProcessTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("Expected one event, got %d", len(events.events))
+	}
+	e := events.events[0]
+	if e.Type != domain.EventTypeDecision || e.SchemaVersion != domain.DecisionEventVersion || e.TenantID != "acme" || e.Transaction.ID != "tx-1" {
+		t.Errorf("Unexpected event envelope %+v", e)
+	}
+	if e.Assessment.Decision != result.Decision() || !e.Assessment.IsBlocked || len(e.Assessment.Overrides) == 0 {
+		t.Errorf("Expected the final blocked verdict with its overrides, got %+v", e.Assessment)
+	}
+	if e.LLMVerdict == nil || e.LLMVerdict.IsBlocked {
+		t.Errorf("Expected the LLM's allow verdict before overrides, got %+v", e.LLMVerdict)
+	}
+}
+
+type failingAudit struct{}
+
+func (failingAudit) Record(context.Context, domain.AuditRecord) error {
+	return errors.New("disk full")
+}
+
+type stubComposer struct{}
+
+func (stubComposer) Compose(tx domain.Transaction, final, llm domain.RiskAssessment) domain.Notification {
+	return domain.Notification{TransactionID: tx.ID, Decision: final.Decision(), Locale: "uk", Text: "composed: " + final.AIPushMessage, Source: domain.MessageSourceTemplate}
+}
+
+type stubNotifier struct {
+	sent []domain.Notification
+}
+
+func (n *stubNotifier) Notify(_ context.Context, note domain.Notification) error {
+	n.sent = append(n.sent, note)
+	return nil
+}
+
+type stubDecisions struct {
+	recorded []domain.LabeledDecision
+}
+
+func (s *stubDecisions) RecordDecision(_ string, d domain.LabeledDecision) {
+	s.recorded = append(s.recorded, d)
+}
+
+func TestProcessTransaction_FailsWhenAuditWriteFails(t *testing.T) {
+	events := &stubPublisher{}
+	reviews := &stubReviews{}
+	notifier := &stubNotifier{}
+	decisions := &stubDecisions{}
+	analyzer := NewAnalyzer(&MockLLMClient{Err: errors.New("provider unavailable")},
+		WithDegradation(DegradeReview),
+		WithAuditRecorder(failingAudit{}),
+		WithEventPublisher(events),
+		WithReviewQueue(reviews),
+		WithMessageComposer(stubComposer{}),
+		WithNotifier(notifier, []string{domain.DecisionReview}),
+		WithDecisionLog(decisions),
+	)
+
+	tx := domain.Transaction{ID: "tx-1", UserID: "u1", Amount: domain.MustParseMoney("700", "USD"), Merchant: "Apple Store", UserProfile: "MaxTx: 1000.0"}
+	if _, err := analyzer.ProcessTransaction(context.Background(), tx); !errors.Is(err, domain.ErrAuditUnavailable) {
+		t.Fatalf("Expected ErrAuditUnavailable, got %v", err)
+	}
+	if len(events.events) != 0 || len(reviews.cases) != 0 || len(notifier.sent) != 0 || len(decisions.recorded) != 0 {
+		t.Errorf("Expected an unaudited decision not to be acted on, got %d events, %d cases, %d notifications and %d decisions",
+			len(events.events), len(reviews.cases), len(notifier.sent), len(decisions.recorded))
+	}
+}
+
+func TestProcessTransaction_ComposesAndSendsPushMessage(t *testing.T) {
+	notifier := &stubNotifier{}
+	analyzer := NewAnalyzer(&MockLLMClient{Response: domain.RiskAssessment{Reason: "Normal transaction", AIPushMessage: "raw"}},
+		WithMessageComposer(stubComposer{}),
+		WithNotifier(notifier, []string{domain.DecisionBlock}),
+	)
+
+	blocked := domain.Transaction{ID: "tx-1", UserID: "u1", Amount: domain.MustParseMoney("2500", "USD"), Merchant: "Apple Store", UserProfile: "MaxTx: 500.0"}
+	result, err := analyzer.ProcessTransaction(context.Background(), blocked)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.AIPushMessage != "composed: raw" || result.AIPushLocale != "uk" {
+		t.Errorf("Expected the composed message, got %q in %q", result.AIPushMessage, result.AIPushLocale)
+	}
+	if len(notifier.sent) != 1 || notifier.sent[0].TransactionID != "tx-1" {
+		t.Errorf("Expected the block to be sent, got %+v", notifier.sent)
+	}
+
+	allowed := domain.Transaction{ID: "tx-2", UserID: "u1", Amount: domain.MustParseMoney("150", "USD"), Merchant: "Starbucks", UserProfile: "MaxTx: 1000.0"}
+	if _, err := analyzer.ProcessTransaction(context.Background(), allowed); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(notifier.sent) != 1 {
+		t.Errorf("Expected allow decisions not to be sent, got %+v", notifier.sent)
+	}
+}
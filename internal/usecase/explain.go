@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+const (
+	amountFactorMaxRatio = 5.0
+	geoMismatchWeight    = 0.6
+	geoMatchWeight       = 0.3
+	riskyMerchantWeight  = 0.5
+	lowMerchantWeight    = 0.2
+	confirmedFraudWeight = 0.5
+)
+
+var (
+	homeLocationRegex = regexp.MustCompile(`(?i)\b(?:home(?:_location)?|location)\s*[:=]?\s*([^,;\n]+)`)
+
+	// riskyMerchantKeywords is the fallback for merchants not in the catalog.
+	riskyMerchantKeywords = []string{"binance", "coinbase", "crypto", "p2p", "unknown"}
+)
+
+// explain derives the deterministic risk factors shown alongside a verdict.
+func explain(in ruleInput) []domain.RiskFactor {
+	return []domain.RiskFactor{
+		amountFactor(in),
+		geoFactor(in),
+		merchantFactor(in),
+		velocityFactor(in),
+		travelFactor(in),
+		outcomeFactor(in),
+	}
+}
+
+func amountFactor(in ruleInput) domain.RiskFactor {
+	f := domain.RiskFactor{Name: "amount_vs_profile_max"}
+	if in.maxTx.MinorUnits <= 0 {
+		f.Direction = domain.DirectionNeutral
+		f.Detail = fmt.Sprintf("amount %s, no historical max available", in.amount)
+		return f
+	}
+
+	ratio := in.amount.Ratio(in.maxTx)
+	f.Detail = fmt.Sprintf("amount %s is %.2fx historical max %s", in.amount, ratio, in.maxTx)
+	if ratio > 1 {
+		f.Direction = domain.DirectionIncreasesRisk
+		f.Weight = min(1, (ratio-1)/(amountFactorMaxRatio-1))
+	} else {
+		f.Direction = domain.DirectionDecreasesRisk
+		f.Weight = 1 - ratio
+	}
+	return f
+}
+
+func geoFactor(in ruleInput) domain.RiskFactor {
+	f := domain.RiskFactor{Name: "geo_mismatch"}
+	known, match := atHome(in)
+	if !known {
+		f.Direction = domain.DirectionNeutral
+		f.Detail = "transaction or home location unknown"
+		return f
+	}
+
+	if match {
+		f.Direction = domain.DirectionDecreasesRisk
+		f.Weight = geoMatchWeight
+		f.Detail = fmt.Sprintf("location %q matches home %q", in.location, in.homeLocation)
+		return f
+	}
+
+	f.Direction = domain.DirectionIncreasesRisk
+	f.Weight = geoMismatchWeight
+	f.Detail = fmt.Sprintf("location %q differs from home %q", in.location, in.homeLocation)
+	if in.geo.HasHomeDistance {
+		f.Detail += fmt.Sprintf(" (%.0f km away)", in.geo.HomeDistanceKm)
+	}
+	return f
+}
+
+func travelFactor(in ruleInput) domain.RiskFactor {
+	f := domain.RiskFactor{Name: "travel_speed", Direction: domain.DirectionNeutral}
+	if !in.geo.HasTravel {
+		f.Detail = "no previous geolocated transaction"
+		return f
+	}
+
+	f.Detail = fmt.Sprintf("%.0f km from the previous transaction at %.0f km/h", in.geo.TravelDistanceKm, in.geo.TravelSpeedKmh)
+	if impossibleTravel(in.geo) {
+		f.Direction = domain.DirectionIncreasesRisk
+		f.Weight = 1
+	}
+	return f
+}
+
+func merchantFactor(in ruleInput) domain.RiskFactor {
+	f := domain.RiskFactor{Name: "merchant_category", Direction: domain.DirectionNeutral}
+	if m := in.merchantInfo; m != nil {
+		f.Detail = fmt.Sprintf("merchant %q is %s, risk tier %s", m.Name, m.Category, m.RiskTier)
+		if m.MCC != "" {
+			f.Detail = fmt.Sprintf("merchant %q is %s (MCC %s), risk tier %s", m.Name, m.Category, m.MCC, m.RiskTier)
+		}
+		switch m.RiskTier {
+		case domain.RiskTierHigh:
+			f.Direction = domain.DirectionIncreasesRisk
+			f.Weight = riskyMerchantWeight
+		case domain.RiskTierLow:
+			f.Direction = domain.DirectionDecreasesRisk
+			f.Weight = lowMerchantWeight
+		}
+		return f
+	}
+
+	merchant := strings.ToLower(in.merchant)
+	for _, kw := range riskyMerchantKeywords {
+		if merchant != "" && strings.Contains(merchant, kw) {
+			f.Direction = domain.DirectionIncreasesRisk
+			f.Weight = riskyMerchantWeight
+			f.Detail = fmt.Sprintf("merchant %q matches high-risk category %q", in.merchant, kw)
+			return f
+		}
+	}
+	f.Detail = "merchant not in a high-risk category"
+	return f
+}
+
+func velocityFactor(in ruleInput) domain.RiskFactor {
+	f := domain.RiskFactor{Name: "velocity", Direction: domain.DirectionNeutral}
+	if !in.features.HasHistory {
+		f.Detail = "no velocity data available"
+		return f
+	}
+
+	f.Detail = fmt.Sprintf("%d transactions in the last minute, %d in the last hour", in.features.TxCount1m, in.features.TxCount1h)
+	if in.features.TxCount1m > 1 {
+		f.Direction = domain.DirectionIncreasesRisk
+		f.Weight = min(1, float64(in.features.TxCount1m)/velocityBurstCount)
+	}
+	return f
+}
+
+func outcomeFactor(in ruleInput) domain.RiskFactor {
+	f := domain.RiskFactor{Name: "confirmed_outcomes", Direction: domain.DirectionNeutral}
+	fraud, legit := in.features.ConfirmedFraud, in.features.ConfirmedLegit
+	if fraud == 0 && legit == 0 {
+		f.Detail = "no labelled outcomes for this user"
+		return f
+	}
+
+	f.Detail = fmt.Sprintf("%d transactions confirmed as fraud, %d as legitimate", fraud, legit)
+	if fraud > 0 {
+		f.Direction = domain.DirectionIncreasesRisk
+		f.Weight = min(1, confirmedFraudWeight*float64(fraud))
+	}
+	return f
+}
+
+// sameLocation reports whether both locations are known and, if so, whether
+// one names the other ("Lviv" vs "Lviv, Ukraine").
+func sameLocation(location, home string) (known, match bool) {
+	if location == "" || home == "" {
+		return false, false
+	}
+	loc := strings.ToLower(location)
+	h := strings.ToLower(home)
+	return true, strings.Contains(loc, h) || strings.Contains(h, loc)
+}
+
+func extractHomeLocation(profile string) string {
+	matches := homeLocationRegex.FindStringSubmatch(profile)
+	if len(matches) > 1 {
+		return strings.TrimSpace(matches[1])
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+const (
+	// impossibleTravelSpeedKmh is faster than a commercial flight.
+	impossibleTravelSpeedKmh = 900.0
+	// impossibleTravelMinKm ignores hops between nearby places, where IP
+	// geolocation noise alone can produce absurd speeds.
+	impossibleTravelMinKm = 500.0
+	// homeRadiusKm is how far from home a transaction still counts as home.
+	homeRadiusKm = 50.0
+	// minTravelElapsed bounds the speed of near-simultaneous transactions.
+	minTravelElapsed = time.Minute
+)
+
+// enrichGeo resolves the transaction's location, IP address and the profile's
+// home location, and records where the transaction happened on tx.Geo. A
+// missing country code is filled in from the resolved point.
+func (a *Analyzer) enrichGeo(tx *domain.Transaction) domain.GeoFeatures {
+	var g domain.GeoFeatures
+	if a.geo == nil {
+		return g
+	}
+
+	if tx.Location != "" {
+		if p, ok := a.geo.Resolve(tx.Location); ok {
+			g.Location = &p
+		}
+	}
+	if tx.IPAddress != "" {
+		if p, ok := a.geo.ResolveIP(tx.IPAddress); ok {
+			g.IP = &p
+		}
+	}
+	if home := extractHomeLocation(tx.UserProfile); home != "" {
+		if p, ok := a.geo.Resolve(home); ok {
+			g.Home = &p
+		}
+	}
+
+	point := g.Point()
+	if measurable(point, g.Home) {
+		g.HasHomeDistance = true
+		g.HomeDistanceKm = domain.DistanceKm(*point, *g.Home)
+	}
+	if measurable(g.Location, g.IP) {
+		g.HasIPDistance = true
+		g.IPDistanceKm = domain.DistanceKm(*g.Location, *g.IP)
+	}
+
+	tx.Geo = point
+	if tx.CountryCode == "" && point != nil {
+		tx.CountryCode = point.Country
+	}
+	return g
+}
+
+// addTravel compares the transaction with the user's previous geolocated one.
+func addTravel(g *domain.GeoFeatures, f *domain.UserFeatures, at time.Time) {
+	point := g.Point()
+	if f == nil || !measurable(point, f.LastGeo) {
+		return
+	}
+
+	elapsed := max(at.Sub(f.LastGeoAt), minTravelElapsed)
+	distance := domain.DistanceKm(*f.LastGeo, *point)
+
+	g.HasTravel = true
+	g.TravelDistanceKm = distance
+	g.TravelElapsed = max(at.Sub(f.LastGeoAt), 0)
+	g.TravelSpeedKmh = distance / elapsed.Hours()
+}
+
+// measurable reports whether the distance between a and b means anything:
+// both are resolved to a city. Between country centroids, a purchase in
+// Lviv would be hundreds of kilometres from "Ukraine".
+func measurable(a, b *domain.GeoPoint) bool {
+	return a != nil && b != nil && a.Precise() && b.Precise()
+}
+
+func impossibleTravel(g domain.GeoFeatures) bool {
+	return g.HasTravel && g.TravelDistanceKm >= impossibleTravelMinKm && g.TravelSpeedKmh > impossibleTravelSpeedKmh
+}
+
+// atHome reports whether the home location is known and, if so, whether the
+// transaction happened there. Resolved coordinates win over text matching.
+func atHome(in ruleInput) (known, match bool) {
+	if in.geo.HasHomeDistance {
+		return true, in.geo.HomeDistanceKm <= homeRadiusKm
+	}
+	return sameLocation(in.location, in.homeLocation)
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/metrics"
+)
+
+// LabelStore keeps outcome labels and the recent decisions they are joined to.
+type LabelStore interface {
+	Label(tenant, txID string) (domain.Label, bool)
+	Labels() []domain.Label
+	Save(l domain.Label) error
+	Decision(tenant, txID string) (domain.LabeledDecision, bool)
+}
+
+// LabelObserver folds outcome labels into user aggregates.
+type LabelObserver interface {
+	ObserveLabel(ctx context.Context, l domain.Label) error
+}
+
+// Denylister adds entries to the deny list.
+type Denylister interface {
+	ListChecker
+	Add(e domain.ListEntry) (domain.ListEntry, error)
+}
+
+// TenantResolver maps a requested tenant to a configured one.
+type TenantResolver interface {
+	Tenant(id string) (string, error)
+}
+
+// Outcomes ingests outcome labels: it stores each label with the decision it
+// judges, counts it towards the user's aggregates and denylists the entities
+// of confirmed fraud.
+type Outcomes struct {
+	mu      sync.Mutex
+	labels  LabelStore
+	tenants TenantResolver
+
+	features     LabelObserver
+	lists        Denylister
+	denyEntities []string
+	denyTTL      time.Duration
+	now          func() time.Time
+}
+
+type OutcomeOption func(*Outcomes)
+
+// WithLabelFeatures feeds labels into the user aggregates of f.
+func WithLabelFeatures(f LabelObserver) OutcomeOption {
+	return func(o *Outcomes) {
+		o.features = f
+	}
+}
+
+// WithLabelDenylist denylists the given entities (user, device or ip) of a
+// transaction confirmed as fraud for ttl; zero keeps the entries until
+// removed. The entries apply only to the label's tenant, since a label
+// reporter is trusted with its own tenant only.
+func WithLabelDenylist(l Denylister, entities []string, ttl time.Duration) OutcomeOption {
+	return func(o *Outcomes) {
+		o.lists = l
+		o.denyEntities = entities
+		o.denyTTL = ttl
+	}
+}
+
+func NewOutcomes(labels LabelStore, tenants TenantResolver, opts ...OutcomeOption) *Outcomes {
+	o := &Outcomes{labels: labels, tenants: tenants, now: time.Now}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Restore replays the stored labels into the user aggregates, which live in
+// memory only.
+func (o *Outcomes) Restore(ctx context.Context) {
+	if o.features == nil {
+		return
+	}
+	for _, l := range o.labels.Labels() {
+		if err := o.features.ObserveLabel(ctx, l); err != nil {
+			slog.ErrorContext(ctx, "failed to restore outcome label", "transaction_id", l.TransactionID, "err", err)
+		}
+	}
+}
+
+// Report records the outcome label l. An empty TenantID selects the default
+// tenant and a zero OccurredAt the time of the report.
+func (o *Outcomes) Report(ctx context.Context, l domain.Label) (domain.OutcomeResult, error) {
+	tenant, err := o.tenants.Tenant(l.TenantID)
+	if err != nil {
+		return domain.OutcomeResult{}, err
+	}
+	l.TenantID = tenant
+	if err := l.Validate(); err != nil {
+		return domain.OutcomeResult{}, err
+	}
+	l.ReportedAt = o.now()
+	if l.OccurredAt.IsZero() {
+		l.OccurredAt = l.ReportedAt
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	prev, relabel := o.labels.Label(tenant, l.TransactionID)
+	if relabel && prev.Label == l.Label && prev.Source == l.Source {
+		return domain.OutcomeResult{Label: prev, Duplicate: true}, nil
+	}
+	if d, ok := o.labels.Decision(tenant, l.TransactionID); ok {
+		l.Decision = &d
+	} else if relabel {
+		l.Decision = prev.Decision
+	}
+
+	if err := o.labels.Save(l); err != nil {
+		return domain.OutcomeResult{}, err
+	}
+
+	decision := "unknown"
+	if l.Decision != nil {
+		decision = l.Decision.Decision
+	}
+	metrics.OutcomeLabels.WithLabelValues(tenant, l.Label, l.Source, decision).Inc()
+
+	if o.features != nil {
+		if err := o.features.ObserveLabel(ctx, l); err != nil {
+			slog.ErrorContext(ctx, "failed to update user features", "transaction_id", l.TransactionID, "err", err)
+		}
+	}
+
+	res := domain.OutcomeResult{Label: l}
+	if l.Label == domain.LabelFraud && !(relabel && prev.Label == domain.LabelFraud) {
+		res.Denylisted = o.denylist(ctx, l)
+	}
+	return res, nil
+}
+
+// denylist adds the configured entities of a confirmed fraud to the deny
+// list of the label's tenant. Only a label joined to its decision knows them.
+func (o *Outcomes) denylist(ctx context.Context, l domain.Label) []domain.ListEntry {
+	if o.lists == nil || l.Decision == nil {
+		return nil
+	}
+
+	var added []domain.ListEntry
+	for _, entity := range o.denyEntities {
+		only := domain.Transaction{TenantID: l.TenantID}
+		v := denyValue(&only, entity)
+		if v == nil {
+			continue
+		}
+		*v = *denyValue(&l.Decision.Transaction, entity)
+		if *v == "" {
+			continue
+		}
+		// An entity already denied, for the tenant or for every tenant, keeps
+		// its entry, which may be permanent.
+		if _, ok := o.lists.Lookup(only, domain.ListDeny); ok {
+			continue
+		}
+		e := domain.ListEntry{
+			TenantID: l.TenantID,
+			List:     domain.ListDeny,
+			Entity:   entity,
+			Value:    *v,
+			Reason:   fmt.Sprintf("%s confirmed fraud on transaction %s", l.Source, l.TransactionID),
+			Author:   "outcome:" + l.Source,
+		}
+		if o.denyTTL > 0 {
+			e.ExpiresAt = l.ReportedAt.Add(o.denyTTL)
+		}
+		e, err := o.lists.Add(e)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to denylist confirmed fraud", "transaction_id", l.TransactionID, "entity", entity, "err", err)
+			continue
+		}
+		added = append(added, e)
+	}
+	return added
+}
+
+// denyValue points at the field of tx that identifies entity. BINs are left
+// out: one fraud must not block a whole card range.
+func denyValue(tx *domain.Transaction, entity string) *string {
+	switch entity {
+	case domain.EntityUser:
+		return &tx.UserID
+	case domain.EntityDevice:
+		return &tx.DeviceID
+	case domain.EntityIP:
+		return &tx.IPAddress
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+type stubLabels struct {
+	labels    map[string]domain.Label
+	decisions map[string]domain.LabeledDecision
+}
+
+func (s *stubLabels) Label(_, txID string) (domain.Label, bool) {
+	l, ok := s.labels[txID]
+	return l, ok
+}
+
+func (s *stubLabels) Labels() []domain.Label { return nil }
+
+func (s *stubLabels) Save(l domain.Label) error {
+	s.labels[l.TransactionID] = l
+	return nil
+}
+
+func (s *stubLabels) Decision(_, txID string) (domain.LabeledDecision, bool) {
+	d, ok := s.decisions[txID]
+	return d, ok
+}
+
+type stubTenants struct{}
+
+func (stubTenants) Tenant(id string) (string, error) {
+	switch id {
+	case "":
+		return "default", nil
+	case "default", "acme":
+		return id, nil
+	default:
+		return "", domain.ErrUnknownTenant
+	}
+}
+
+type stubDenylist struct {
+	entries []domain.ListEntry
+}
+
+func (s *stubDenylist) Lookup(tx domain.Transaction, _ string) (domain.ListEntry, bool) {
+	for _, e := range s.entries {
+		if e.Entity == domain.EntityDevice && e.Value == tx.DeviceID {
+			return e, true
+		}
+	}
+	return domain.ListEntry{}, false
+}
+
+func (s *stubDenylist) Add(e domain.ListEntry) (domain.ListEntry, error) {
+	s.entries = append(s.entries, e)
+	return e, nil
+}
+
+type stubObserver struct {
+	labels []domain.Label
+}
+
+func (s *stubObserver) ObserveLabel(_ context.Context, l domain.Label) error {
+	s.labels = append(s.labels, l)
+	return nil
+}
+
+func TestOutcomes_ReportJoinsDecisionAndDenylists(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	store := &stubLabels{
+		labels: map[string]domain.Label{},
+		decisions: map[string]domain.LabeledDecision{
+			"tx-1": {Transaction: domain.Transaction{ID: "tx-1", UserID: "u1", DeviceID: "dev-1", IPAddress: "203.0.113.7"}, Decision: domain.DecisionAllow},
+		},
+	}
+	deny := &stubDenylist{}
+	observed := &stubObserver{}
+	o := NewOutcomes(store, stubTenants{},
+		WithLabelFeatures(observed),
+		WithLabelDenylist(deny, []string{domain.EntityDevice, domain.EntityBIN}, 24*time.Hour),
+	)
+	o.now = func() time.Time { return now }
+
+	res, err := o.Report(context.Background(), domain.Label{TransactionID: "tx-1", TenantID: "acme", Label: domain.LabelFraud, Source: domain.SourceChargeback})
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if res.Duplicate || res.Label.Decision == nil || res.Label.Decision.Decision != domain.DecisionAllow {
+		t.Errorf("Expected the label to be joined to the allow decision, got %+v", res)
+	}
+	if !res.Label.OccurredAt.Equal(now) || !res.Label.ReportedAt.Equal(now) {
+		t.Errorf("Expected the outcome time to default to the report time, got %+v", res.Label)
+	}
+	if len(res.Denylisted) != 1 || res.Denylisted[0].Value != "dev-1" || !res.Denylisted[0].ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("Expected only the device to be denylisted for a day, got %+v", res.Denylisted)
+	}
+	if len(res.Denylisted) == 1 && res.Denylisted[0].TenantID != "acme" {
+		t.Errorf("Expected the deny entry to be scoped to the label's tenant, got %q", res.Denylisted[0].TenantID)
+	}
+	if len(observed.labels) != 1 {
+		t.Errorf("Expected the label to reach the user aggregates, got %d", len(observed.labels))
+	}
+
+	res, err = o.Report(context.Background(), domain.Label{TransactionID: "tx-1", TenantID: "acme", Label: domain.LabelFraud, Source: domain.SourceChargeback})
+	if err != nil || !res.Duplicate {
+		t.Errorf("Expected a repeated label to be a duplicate, got %+v, %v", res, err)
+	}
+	res, err = o.Report(context.Background(), domain.Label{TransactionID: "tx-1", TenantID: "acme", Label: domain.LabelFraud, Source: domain.SourceDispute})
+	if err != nil || res.Duplicate || len(res.Denylisted) != 0 {
+		t.Errorf("Expected a new source to relabel without denylisting again, got %+v, %v", res, err)
+	}
+	if len(deny.entries) != 1 || len(observed.labels) != 2 {
+		t.Errorf("Expected one deny entry and two observed labels, got %d and %d", len(deny.entries), len(observed.labels))
+	}
+
+	res, err = o.Report(context.Background(), domain.Label{TransactionID: "tx-9", Label: domain.LabelFraud, Source: domain.SourceOther})
+	if err != nil || res.Label.TenantID != "default" || res.Label.Decision != nil || len(res.Denylisted) != 0 {
+		t.Errorf("Expected an unmatched label on the default tenant, got %+v, %v", res, err)
+	}
+
+	if _, err := o.Report(context.Background(), domain.Label{TransactionID: "tx-1", Label: "maybe", Source: domain.SourceOther}); !errors.Is(err, domain.ErrInvalidLabel) {
+		t.Errorf("Expected an invalid label to be refused, got %v", err)
+	}
+	if _, err := o.Report(context.Background(), domain.Label{TransactionID: "tx-1", TenantID: "nope", Label: domain.LabelFraud, Source: domain.SourceOther}); !errors.Is(err, domain.ErrUnknownTenant) {
+		t.Errorf("Expected an unknown tenant to be refused, got %v", err)
+	}
+}
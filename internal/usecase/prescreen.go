@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/tracing"
+)
+
+const (
+	prescreenBlockMultiplier = 10
+	prescreenAllowConfidence = 95
+	prescreenBlockConfidence = 99
+)
+
+// screen decides clear-cut cases without the LLM. It returns ok=false when the
+// transaction is ambiguous and must go to the model. A required screen runs
+// whatever checks a tenant selects.
+type screen struct {
+	name     string
+	route    string
+	required bool
+	match    func(in ruleInput) (reason string, ok bool)
+}
+
+// Block screens run before allow screens so that a transaction on the deny
+// list (see denylisted) or with an extreme amount can never be waved through.
+// The allow list does not override them: it is applied with the heuristics,
+// after the LLM. The deny list check is required, so a tenant that turns the
+// prescreen off still blocks denylisted entities.
+var screens = []screen{
+	{name: "denylisted", route: domain.RoutePrescreenBlock, required: true, match: denylisted},
+	{name: "extreme_amount", route: domain.RoutePrescreenBlock, match: extremeAmount},
+	{name: "routine_purchase", route: domain.RoutePrescreenAllow, match: routinePurchase},
+}
+
+// ValidateScreens reports names that are not prescreen checks.
+func ValidateScreens(names []string) error {
+	return validateNames(screens, names, func(s screen) string { return s.name }, "prescreen check")
+}
+
+func (a *Analyzer) prescreen(ctx context.Context, in ruleInput) (domain.RiskAssessment, bool) {
+	_, span := tracer.Start(ctx, "Analyzer.prescreen")
+	defer span.End()
+
+	for _, s := range a.screens {
+		reason, ok := s.match(in)
+		if !ok {
+			continue
+		}
+
+		span.SetAttributes(
+			tracing.AttrRuleName.String(s.name),
+			tracing.AttrRoute.String(s.route),
+		)
+
+		verdict := domain.RiskAssessment{
+			Route:       s.route,
+			RouteReason: s.name + ": " + reason,
+		}
+		if s.route == domain.RoutePrescreenBlock {
+			verdict.IsBlocked = true
+			verdict.ConfidenceScore = prescreenBlockConfidence
+			verdict.Reason = addTag(in.tenant, reason, "[Prescreen Block]")
+		} else {
+			verdict.ConfidenceScore = prescreenAllowConfidence
+			verdict.Reason = addTag(in.tenant, reason, "[Prescreen Allow]")
+		}
+		return verdict, true
+	}
+
+	return domain.RiskAssessment{}, false
+}
+
+func denylisted(in ruleInput) (string, bool) {
+	if in.denied == nil {
+		return "", false
+	}
+	return fmt.Sprintf("%s %q is denylisted: %s.", in.denied.Entity, in.denied.Value, in.denied.Reason), true
+}
+
+func extremeAmount(in ruleInput) (string, bool) {
+	if in.maxTx.MinorUnits > 0 && above(in.amount, in.maxTx.MulInt(prescreenBlockMultiplier)) && above(in.amount, in.thresholds.HeuristicBlockMin) {
+		return fmt.Sprintf("Amount (%s) is more than %dx historical max (%s).", in.amount.Decimal(), prescreenBlockMultiplier, in.maxTx.Decimal()), true
+	}
+	return "", false
+}
+
+func routinePurchase(in ruleInput) (string, bool) {
+	if !in.hasFeatures || above(in.amount, in.thresholds.PrescreenAllowMax) {
+		return "", false
+	}
+	if !in.features.KnownMerchant || in.features.TxCount1m > 0 || impossibleTravel(in.geo) {
+		return "", false
+	}
+	if in.merchantInfo != nil && in.merchantInfo.HighRisk() {
+		return "", false
+	}
+	if known, match := atHome(in); !known || !match {
+		return "", false
+	}
+	return fmt.Sprintf("Small amount (%s) at a known merchant in the home location.", in.amount.Decimal()), true
+}
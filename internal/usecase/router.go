@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+)
+
+// Router dispatches each transaction to the analyzer of its tenant, so that
+// tenants with different risk appetites share one engine.
+type Router struct {
+	mu            sync.RWMutex
+	analyzers     map[string]*Analyzer
+	defaultTenant string
+}
+
+func NewRouter(defaultTenant string, analyzers map[string]*Analyzer) *Router {
+	return &Router{analyzers: analyzers, defaultTenant: defaultTenant}
+}
+
+// ProcessTransaction analyzes tx with its tenant's configuration. An empty
+// TenantID selects the default tenant; an unconfigured one returns
+// domain.ErrUnknownTenant.
+func (r *Router) ProcessTransaction(ctx context.Context, tx domain.Transaction) (domain.RiskAssessment, error) {
+	if tx.TenantID == "" {
+		tx.TenantID = r.defaultTenant
+	}
+
+	r.mu.RLock()
+	a, ok := r.analyzers[tx.TenantID]
+	r.mu.RUnlock()
+	if !ok {
+		return domain.RiskAssessment{}, fmt.Errorf("%w: %q", domain.ErrUnknownTenant, tx.TenantID)
+	}
+	return a.ProcessTransaction(ctx, tx)
+}
+
+// Tenant resolves id the way ProcessTransaction does: empty selects the
+// default tenant and an unconfigured one returns domain.ErrUnknownTenant.
+func (r *Router) Tenant(id string) (string, error) {
+	if id == "" {
+		id = r.defaultTenant
+	}
+
+	r.mu.RLock()
+	_, ok := r.analyzers[id]
+	r.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %q", domain.ErrUnknownTenant, id)
+	}
+	return id, nil
+}
+
+// Replace swaps in reconfigured analyzers. Calls in flight finish with the
+// analyzer they started with.
+func (r *Router) Replace(analyzers map[string]*Analyzer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.analyzers = analyzers
+}
+
+// ValidateDegradation reports an unknown degradation policy. Empty selects
+// the default, DegradeAllow.
+func ValidateDegradation(policy string) error {
+	switch policy {
+	case "", DegradeAllow, DegradeBlock, DegradeReview:
+		return nil
+	default:
+		return fmt.Errorf("unknown degradation policy %q", policy)
+	}
+}
+
+// selectByName keeps the items named in names, in their original order.
+func selectByName[T any](items []T, names []string, name func(T) string) []T {
+	out := make([]T, 0, len(names))
+	for _, item := range items {
+		if slices.Contains(names, name(item)) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func validateNames[T any](items []T, names []string, name func(T) string, kind string) error {
+	for _, n := range names {
+		if !slices.ContainsFunc(items, func(item T) bool { return name(item) == n }) {
+			return fmt.Errorf("unknown %s %q", kind, n)
+		}
+	}
+	return nil
+}
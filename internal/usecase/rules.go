@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tokyosplif/ai-risk-engine/internal/domain"
+	"github.com/tokyosplif/ai-risk-engine/internal/tracing"
+)
+
+const (
+	heuristicBlockMultiplier = 2
+	highConfidenceThreshold  = 75
+	lowConfidenceThreshold   = 30
+	velocityBurstCount       = 5
+)
+
+// ruleInput holds the facts the heuristic rules are evaluated against.
+type ruleInput struct {
+	tenant       string
+	amount       domain.Money
+	maxTx        domain.Money
+	thresholds   domain.Thresholds
+	merchant     string
+	merchantInfo *domain.Merchant
+	location     string
+	homeLocation string
+	geo          domain.GeoFeatures
+	features     domain.UserFeatures
+	hasFeatures  bool
+	denied       *domain.ListEntry
+	allowed      *domain.ListEntry
+}
+
+// checkCurrencies returns domain.ErrCurrencyMismatch unless the amount, the
+// profile's maximum and the thresholds share a currency. The rules compare
+// them only after it passed.
+func (in ruleInput) checkCurrencies() error {
+	for _, m := range []domain.Money{in.maxTx, in.thresholds.LowValue, in.thresholds.HeuristicBlockMin, in.thresholds.HighValue, in.thresholds.PrescreenAllowMax} {
+		if _, err := in.amount.Cmp(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// above and below compare amounts checked by checkCurrencies.
+func above(a, b domain.Money) bool {
+	c, err := a.Cmp(b)
+	return err == nil && c > 0
+}
+
+func below(a, b domain.Money) bool {
+	c, err := a.Cmp(b)
+	return err == nil && c < 0
+}
+
+// rule mutates the assessment and reports whether it fired. A fired terminal
+// rule stops evaluation of the remaining rules.
+type rule struct {
+	name     string
+	terminal bool
+	apply    func(in ruleInput, assessment *domain.RiskAssessment) bool
+}
+
+var rules = []rule{
+	{name: "allowlist_pass", terminal: true, apply: allowlistPass},
+	{name: "velocity_burst", terminal: true, apply: velocityBurst},
+	{name: "impossible_travel", terminal: true, apply: impossibleTravelBlock},
+	{name: "low_value_pass", terminal: true, apply: lowValuePass},
+	{name: "heuristic_block", terminal: true, apply: heuristicBlock},
+	{name: "high_value_review", terminal: true, apply: highValueReview},
+	{name: "confidence_mapping", apply: confidenceMapping},
+}
+
+// ValidateRules reports names that are not heuristic rules.
+func ValidateRules(names []string) error {
+	return validateNames(rules, names, func(r rule) string { return r.name }, "rule")
+}
+
+func (a *Analyzer) applyRule(ctx context.Context, r rule, in ruleInput, assessment *domain.RiskAssessment) bool {
+	_, span := tracer.Start(ctx, "rule."+r.name)
+	defer span.End()
+
+	blockedBefore := assessment.IsBlocked
+	fired := r.apply(in, assessment)
+	span.SetAttributes(
+		tracing.AttrRuleName.String(r.name),
+		tracing.AttrRuleFired.Bool(fired),
+	)
+	if fired {
+		// The reason names list entries and user details, so only the
+		// resulting decision is exported.
+		span.SetAttributes(tracing.AttrDecision.String(assessment.Decision()))
+		assessment.Overrides = append(assessment.Overrides, domain.Override{
+			Stage:         domain.StageAnalyzer,
+			Rule:          r.name,
+			BlockedBefore: blockedBefore,
+			BlockedAfter:  assessment.IsBlocked,
+			Reason:        assessment.Reason,
+		})
+	}
+
+	return fired
+}
+
+func allowlistPass(in ruleInput, assessment *domain.RiskAssessment) bool {
+	if in.allowed == nil {
+		return false
+	}
+	assessment.IsBlocked = false
+	assessment.Reason = addTag(in.tenant, assessment.Reason, fmt.Sprintf("[Allowlist] %s %q allowlisted: %s.", in.allowed.Entity, in.allowed.Value, in.allowed.Reason))
+	return true
+}
+
+func velocityBurst(in ruleInput, assessment *domain.RiskAssessment) bool {
+	if in.features.TxCount1m >= velocityBurstCount {
+		assessment.IsBlocked = true
+		assessment.Reason = addTag(in.tenant, assessment.Reason, fmt.Sprintf("[Velocity Block] %d transactions in the last minute.", in.features.TxCount1m))
+		return true
+	}
+	return false
+}
+
+func impossibleTravelBlock(in ruleInput, assessment *domain.RiskAssessment) bool {
+	if !impossibleTravel(in.geo) {
+		return false
+	}
+	assessment.IsBlocked = true
+	assessment.Reason = addTag(in.tenant, assessment.Reason, fmt.Sprintf("[Impossible Travel] %.0f km from the previous transaction in %s (%.0f km/h).",
+		in.geo.TravelDistanceKm, in.geo.TravelElapsed.Round(time.Second), in.geo.TravelSpeedKmh))
+	return true
+}
+
+func lowValuePass(in ruleInput, assessment *domain.RiskAssessment) bool {
+	if below(in.amount, in.thresholds.LowValue) && assessment.IsBlocked && strings.Contains(strings.ToLower(assessment.Reason), "amount") {
+		assessment.IsBlocked = false
+		assessment.Reason = addTag(in.tenant, assessment.Reason, "[Low Value Pass]")
+		return true
+	}
+	return false
+}
+
+func heuristicBlock(in ruleInput, assessment *domain.RiskAssessment) bool {
+	if in.maxTx.MinorUnits > 0 && above(in.amount, in.maxTx.MulInt(heuristicBlockMultiplier)) && above(in.amount, in.thresholds.HeuristicBlockMin) {
+		assessment.IsBlocked = true
+		assessment.Reason = addTag(in.tenant, assessment.Reason, fmt.Sprintf("[Heuristic Block] Amount (%s) exceeds historical max (%s).", in.amount.Decimal(), in.maxTx.Decimal()))
+		return true
+	}
+	return false
+}
+
+func highValueReview(in ruleInput, assessment *domain.RiskAssessment) bool {
+	if above(in.amount, in.thresholds.HighValue) && assessment.ConfidenceScore <= highConfidenceThreshold {
+		assessment.IsBlocked = false
+		assessment.Reason = addTag(in.tenant, assessment.Reason, domain.TagPendingReview)
+		return true
+	}
+	return false
+}
+
+func confidenceMapping(in ruleInput, assessment *domain.RiskAssessment) bool {
+	switch {
+	case assessment.ConfidenceScore <= lowConfidenceThreshold:
+		assessment.IsBlocked = false
+		assessment.Reason = addTag(in.tenant, assessment.Reason, "[Low Confidence Ignore]")
+		return true
+	case assessment.ConfidenceScore <= highConfidenceThreshold:
+		if assessment.IsBlocked {
+			assessment.IsBlocked = false
+			assessment.Reason = addTag(in.tenant, assessment.Reason, domain.TagPendingReview)
+			return true
+		}
+	}
+	return false
+}
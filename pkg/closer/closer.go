@@ -1,8 +1,12 @@
 package closer
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"sync"
 )
 
 func Close(c io.Closer, name string) {
@@ -16,3 +20,76 @@ func Close(c io.Closer, name string) {
 		)
 	}
 }
+
+// Closer shuts components down in the reverse of their registration order,
+// so that each one is closed before the ones it was built on.
+type Closer struct {
+	mu    sync.Mutex
+	items []item
+}
+
+type item struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func New() *Closer {
+	return &Closer{}
+}
+
+// Add registers fn to run on CloseAll.
+func (c *Closer) Add(name string, fn func(ctx context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = append(c.items, item{name: name, fn: fn})
+}
+
+// AddCloser registers an io.Closer.
+func (c *Closer) AddCloser(name string, cl io.Closer) {
+	c.Add(name, func(context.Context) error { return cl.Close() })
+}
+
+// Go runs fn in a goroutine until CloseAll cancels its context, and waits for
+// it to return when closing.
+func (c *Closer) Go(name string, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(ctx)
+	}()
+
+	c.Add(name, func(closeCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-closeCtx.Done():
+			return fmt.Errorf("did not stop in time: %w", closeCtx.Err())
+		}
+	})
+}
+
+// CloseAll runs every registered function, newest first, even after ctx
+// expires, and returns the errors joined. It may be called once.
+func (c *Closer) CloseAll(ctx context.Context) error {
+	c.mu.Lock()
+	items := c.items
+	c.items = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(items) - 1; i >= 0; i-- {
+		it := items[i]
+		if err := it.fn(ctx); err != nil {
+			slog.Error("failed to close resource",
+				"resource", it.name,
+				"error", err,
+			)
+			errs = append(errs, fmt.Errorf("%s: %w", it.name, err))
+			continue
+		}
+		slog.Debug("resource closed", "resource", it.name)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,66 @@
+package closer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCloseAll_ReverseOrderAndErrors(t *testing.T) {
+	c := New()
+
+	var order []string
+	for _, name := range []string{"tracing", "store", "watcher"} {
+		c.Add(name, func(context.Context) error {
+			order = append(order, name)
+			if name == "store" {
+				return errors.New("flush failed")
+			}
+			return nil
+		})
+	}
+
+	err := c.CloseAll(context.Background())
+
+	if want := []string{"watcher", "store", "tracing"}; !slices.Equal(order, want) {
+		t.Errorf("Expected close order %v, got %v", want, order)
+	}
+	if err == nil || err.Error() != "store: flush failed" {
+		t.Errorf("Expected the store error to be reported, got %v", err)
+	}
+}
+
+func TestGo_CancelsAndWaits(t *testing.T) {
+	c := New()
+
+	stopped := false
+	c.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		stopped = true
+	})
+
+	if err := c.CloseAll(context.Background()); err != nil {
+		t.Fatalf("Expected clean shutdown, got %v", err)
+	}
+	if !stopped {
+		t.Error("Expected CloseAll to wait for the goroutine to return")
+	}
+}
+
+func TestGo_ReportsStuckGoroutine(t *testing.T) {
+	c := New()
+
+	release := make(chan struct{})
+	defer close(release)
+	c.Go("stuck", func(context.Context) { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := c.CloseAll(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error for the stuck goroutine, got %v", err)
+	}
+}
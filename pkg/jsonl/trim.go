@@ -0,0 +1,53 @@
+// Package jsonl holds helpers for append-only JSON Lines files.
+package jsonl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+const chunkSize = 64 << 10
+
+// TrimPartial truncates f after its last newline, dropping a line left
+// incomplete by a crash, and returns the new size and how many bytes were
+// dropped. It reads backwards from the end, so only the tail of the file is
+// read.
+func TrimPartial(f *os.File) (size, dropped int64, err error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	end := info.Size()
+
+	size, err = lastNewline(f, end)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read %s: %w", f.Name(), err)
+	}
+	if size == end {
+		return size, 0, nil
+	}
+	if err := f.Truncate(size); err != nil {
+		return 0, 0, fmt.Errorf("failed to trim %s: %w", f.Name(), err)
+	}
+	return size, end - size, nil
+}
+
+// lastNewline returns the offset just past the last newline before end, or
+// 0 if there is none.
+func lastNewline(r io.ReaderAt, end int64) (int64, error) {
+	buf := make([]byte, chunkSize)
+	for end > 0 {
+		start := max(end-chunkSize, 0)
+		chunk := buf[:end-start]
+		if _, err := r.ReadAt(chunk, start); err != nil && err != io.EOF {
+			return 0, err
+		}
+		if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 {
+			return start + int64(i) + 1, nil
+		}
+		end = start
+	}
+	return 0, nil
+}
@@ -0,0 +1,44 @@
+package jsonl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTrimPartial(t *testing.T) {
+	long := strings.Repeat("x", chunkSize+10)
+	tests := []struct {
+		name, data, want string
+	}{
+		{"empty", "", ""},
+		{"complete", "{}\n{}\n", "{}\n{}\n"},
+		{"torn tail", "{}\n{\"id\":", "{}\n"},
+		{"no newline", "{\"id\":", ""},
+		{"tail longer than a chunk", "{}\n" + long, "{}\n"},
+		{"line longer than a chunk", long + "\n" + "{", long + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "f.jsonl")
+			if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.OpenFile(path, os.O_RDWR, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			size, dropped, err := TrimPartial(f)
+			if err != nil {
+				t.Fatalf("TrimPartial: %v", err)
+			}
+			got, _ := os.ReadFile(path)
+			if string(got) != tt.want || size != int64(len(tt.want)) || dropped != int64(len(tt.data)-len(tt.want)) {
+				t.Errorf("Expected %d bytes kept, got %d (size %d, dropped %d)", len(tt.want), len(got), size, dropped)
+			}
+		})
+	}
+}
@@ -1,31 +1,71 @@
 package logger
 
 import (
+	"io"
 	"log/slog"
 	"os"
 	"strings"
 )
 
-func Setup(level string) {
-	var slogLevel slog.Level
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type Options struct {
+	Level  string
+	Format string
+	// DebugSampleRate keeps one of every N debug records. Values <= 1 keep all.
+	DebugSampleRate int
+}
+
+// level is the level of the default logger installed by Setup.
+var level = new(slog.LevelVar)
+
+func Setup(opts Options) {
+	level.Set(ParseLevel(opts.Level))
+	slog.SetDefault(newLogger(os.Stdout, opts, level))
+}
+
+// SetLevel changes the level of the default logger at runtime.
+func SetLevel(l string) {
+	level.Set(ParseLevel(l))
+}
+
+func New(w io.Writer, opts Options) *slog.Logger {
+	return newLogger(w, opts, ParseLevel(opts.Level))
+}
+
+func newLogger(w io.Writer, opts Options, l slog.Leveler) *slog.Logger {
+	handlerOpts := &slog.HandlerOptions{
+		Level:       l,
+		ReplaceAttr: Redact,
+	}
+
+	var handler slog.Handler
+	if strings.ToLower(opts.Format) == FormatText {
+		handler = slog.NewTextHandler(w, handlerOpts)
+	} else {
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	}
 
+	handler = &contextHandler{Handler: handler}
+	if opts.DebugSampleRate > 1 {
+		handler = newSamplingHandler(handler, opts.DebugSampleRate)
+	}
+
+	return slog.New(handler)
+}
+
+func ParseLevel(level string) slog.Level {
 	switch strings.ToLower(level) {
 	case "debug":
-		slogLevel = slog.LevelDebug
+		return slog.LevelDebug
 	case "warn":
-		slogLevel = slog.LevelWarn
+		return slog.LevelWarn
 	case "error":
-		slogLevel = slog.LevelError
+		return slog.LevelError
 	default:
-		slogLevel = slog.LevelInfo
-	}
-
-	opts := &slog.HandlerOptions{
-		Level: slogLevel,
+		return slog.LevelInfo
 	}
-
-	handler := slog.NewJSONHandler(os.Stdout, opts)
-	logger := slog.New(handler)
-
-	slog.SetDefault(logger)
 }
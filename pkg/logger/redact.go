@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const redactedValue = "[REDACTED]"
+
+type redactRule func(value string) string
+
+// redactRules maps lower-cased attribute keys to the masking applied to their
+// values. Keys not listed here are logged as-is.
+var redactRules = map[string]redactRule{
+	"name":            redactAll,
+	"full_name":       redactAll,
+	"email":           redactAll,
+	"phone":           redactAll,
+	"card":            keepLast(4),
+	"card_number":     keepLast(4),
+	"pan":             keepLast(4),
+	"location":        redactAll,
+	"ip":              redactAll,
+	"user_profile":    redactAll,
+	"content":         redactAll,
+	"reason":          redactAll,
+	"notes":           redactAll,
+	"ai_push_message": redactAll,
+	"merchant":        keepFirst(3),
+	"api_key":         redactAll,
+	"authorization":   redactAll,
+	"secret":          redactAll,
+	"token":           redactAll,
+	"password":        redactAll,
+}
+
+// Redact is a slog ReplaceAttr hook that masks PII-bearing attributes.
+func Redact(_ []string, a slog.Attr) slog.Attr {
+	rule, ok := redactRules[strings.ToLower(a.Key)]
+	if !ok {
+		return a
+	}
+	return slog.String(a.Key, rule(a.Value.String()))
+}
+
+func redactAll(string) string {
+	return redactedValue
+}
+
+func keepLast(n int) redactRule {
+	return func(v string) string {
+		if len(v) <= n {
+			return redactedValue
+		}
+		return strings.Repeat("*", len(v)-n) + v[len(v)-n:]
+	}
+}
+
+func keepFirst(n int) redactRule {
+	return func(v string) string {
+		if len(v) <= n {
+			return redactedValue
+		}
+		return v[:n] + "***"
+	}
+}
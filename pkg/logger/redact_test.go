@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		key, value, want string
+	}{
+		{"email", "jane@example.com", redactedValue},
+		{"Email", "jane@example.com", redactedValue},
+		{"reason", "Cardholder Jane Doe at 12 Main St", redactedValue},
+		{"notes", "called the customer", redactedValue},
+		{"user_profile", "MaxTx: 500, Location: Kyiv", redactedValue},
+		{"card_number", "4111111111111111", "************1111"},
+		{"card", "1234", redactedValue},
+		{"merchant", "Apple Store", "App***"},
+		{"merchant", "Abc", redactedValue},
+		{"tenant", "acme", "acme"},
+		{"blocked", "true", "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			got := Redact(nil, slog.String(tt.key, tt.value))
+			if got.Value.String() != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got.Value.String())
+			}
+		})
+	}
+}
+
+func TestNew_RedactsLoggedAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, Options{Level: "debug"}).Debug("risk analysis complete", "blocked", true, "reason", "Jane Doe, Lviv")
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if rec["reason"] != redactedValue || rec["blocked"] != true {
+		t.Errorf("Expected reason to be redacted and blocked kept, got %v", rec)
+	}
+}
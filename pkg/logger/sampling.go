@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// samplingHandler passes every record at Info and above, and one of every
+// rate debug records.
+type samplingHandler struct {
+	slog.Handler
+	rate    uint64
+	counter *atomic.Uint64
+}
+
+func newSamplingHandler(h slog.Handler, rate int) *samplingHandler {
+	return &samplingHandler{Handler: h, rate: uint64(rate), counter: &atomic.Uint64{}}
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelInfo && h.counter.Add(1)%h.rate != 1 {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithAttrs(attrs), rate: h.rate, counter: h.counter}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithGroup(name), rate: h.rate, counter: h.counter}
+}
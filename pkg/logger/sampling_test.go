@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSampling(t *testing.T) {
+	tests := []struct {
+		name      string
+		rate      int
+		debug     int
+		info      int
+		wantDebug int
+	}{
+		{"disabled", 0, 10, 2, 10},
+		{"every record", 1, 10, 2, 10},
+		{"one in three", 3, 10, 2, 4},
+		{"one in ten", 10, 25, 5, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := New(&buf, Options{Level: "debug", Format: FormatText, DebugSampleRate: tt.rate})
+			for range tt.debug {
+				l.Debug("sampled")
+			}
+			for range tt.info {
+				l.Info("kept")
+			}
+
+			out := buf.String()
+			if got := strings.Count(out, "msg=sampled"); got != tt.wantDebug {
+				t.Errorf("Expected %d debug records, got %d", tt.wantDebug, got)
+			}
+			if got := strings.Count(out, "msg=kept"); got != tt.info {
+				t.Errorf("Expected every info record, got %d of %d", got, tt.info)
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.11
-// 	protoc        v6.33.5
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
 // source: api/proto/risk_engine.proto
 
 package pb
@@ -9,6 +9,7 @@ package pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,16 +22,507 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type FactorDirection int32
+
+const (
+	FactorDirection_FACTOR_DIRECTION_UNSPECIFIED    FactorDirection = 0
+	FactorDirection_FACTOR_DIRECTION_INCREASES_RISK FactorDirection = 1
+	FactorDirection_FACTOR_DIRECTION_DECREASES_RISK FactorDirection = 2
+	FactorDirection_FACTOR_DIRECTION_NEUTRAL        FactorDirection = 3
+)
+
+// Enum value maps for FactorDirection.
+var (
+	FactorDirection_name = map[int32]string{
+		0: "FACTOR_DIRECTION_UNSPECIFIED",
+		1: "FACTOR_DIRECTION_INCREASES_RISK",
+		2: "FACTOR_DIRECTION_DECREASES_RISK",
+		3: "FACTOR_DIRECTION_NEUTRAL",
+	}
+	FactorDirection_value = map[string]int32{
+		"FACTOR_DIRECTION_UNSPECIFIED":    0,
+		"FACTOR_DIRECTION_INCREASES_RISK": 1,
+		"FACTOR_DIRECTION_DECREASES_RISK": 2,
+		"FACTOR_DIRECTION_NEUTRAL":        3,
+	}
+)
+
+func (x FactorDirection) Enum() *FactorDirection {
+	p := new(FactorDirection)
+	*p = x
+	return p
+}
+
+func (x FactorDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FactorDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_risk_engine_proto_enumTypes[0].Descriptor()
+}
+
+func (FactorDirection) Type() protoreflect.EnumType {
+	return &file_api_proto_risk_engine_proto_enumTypes[0]
+}
+
+func (x FactorDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FactorDirection.Descriptor instead.
+func (FactorDirection) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{0}
+}
+
+type ListKind int32
+
+const (
+	ListKind_LIST_KIND_UNSPECIFIED ListKind = 0
+	ListKind_LIST_KIND_ALLOW       ListKind = 1
+	ListKind_LIST_KIND_DENY        ListKind = 2
+)
+
+// Enum value maps for ListKind.
+var (
+	ListKind_name = map[int32]string{
+		0: "LIST_KIND_UNSPECIFIED",
+		1: "LIST_KIND_ALLOW",
+		2: "LIST_KIND_DENY",
+	}
+	ListKind_value = map[string]int32{
+		"LIST_KIND_UNSPECIFIED": 0,
+		"LIST_KIND_ALLOW":       1,
+		"LIST_KIND_DENY":        2,
+	}
+)
+
+func (x ListKind) Enum() *ListKind {
+	p := new(ListKind)
+	*p = x
+	return p
+}
+
+func (x ListKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_risk_engine_proto_enumTypes[1].Descriptor()
+}
+
+func (ListKind) Type() protoreflect.EnumType {
+	return &file_api_proto_risk_engine_proto_enumTypes[1]
+}
+
+func (x ListKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListKind.Descriptor instead.
+func (ListKind) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{1}
+}
+
+type EntityType int32
+
+const (
+	EntityType_ENTITY_TYPE_UNSPECIFIED EntityType = 0
+	EntityType_ENTITY_TYPE_MERCHANT    EntityType = 1
+	EntityType_ENTITY_TYPE_USER        EntityType = 2
+	EntityType_ENTITY_TYPE_DEVICE      EntityType = 3
+	// Single address or CIDR range.
+	EntityType_ENTITY_TYPE_IP EntityType = 4
+	// Card number prefix.
+	EntityType_ENTITY_TYPE_BIN     EntityType = 5
+	EntityType_ENTITY_TYPE_COUNTRY EntityType = 6
+)
+
+// Enum value maps for EntityType.
+var (
+	EntityType_name = map[int32]string{
+		0: "ENTITY_TYPE_UNSPECIFIED",
+		1: "ENTITY_TYPE_MERCHANT",
+		2: "ENTITY_TYPE_USER",
+		3: "ENTITY_TYPE_DEVICE",
+		4: "ENTITY_TYPE_IP",
+		5: "ENTITY_TYPE_BIN",
+		6: "ENTITY_TYPE_COUNTRY",
+	}
+	EntityType_value = map[string]int32{
+		"ENTITY_TYPE_UNSPECIFIED": 0,
+		"ENTITY_TYPE_MERCHANT":    1,
+		"ENTITY_TYPE_USER":        2,
+		"ENTITY_TYPE_DEVICE":      3,
+		"ENTITY_TYPE_IP":          4,
+		"ENTITY_TYPE_BIN":         5,
+		"ENTITY_TYPE_COUNTRY":     6,
+	}
+)
+
+func (x EntityType) Enum() *EntityType {
+	p := new(EntityType)
+	*p = x
+	return p
+}
+
+func (x EntityType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntityType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_risk_engine_proto_enumTypes[2].Descriptor()
+}
+
+func (EntityType) Type() protoreflect.EnumType {
+	return &file_api_proto_risk_engine_proto_enumTypes[2]
+}
+
+func (x EntityType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntityType.Descriptor instead.
+func (EntityType) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{2}
+}
+
+type MerchantRiskTier int32
+
+const (
+	MerchantRiskTier_MERCHANT_RISK_TIER_UNSPECIFIED MerchantRiskTier = 0
+	MerchantRiskTier_MERCHANT_RISK_TIER_LOW         MerchantRiskTier = 1
+	MerchantRiskTier_MERCHANT_RISK_TIER_MEDIUM      MerchantRiskTier = 2
+	MerchantRiskTier_MERCHANT_RISK_TIER_HIGH        MerchantRiskTier = 3
+)
+
+// Enum value maps for MerchantRiskTier.
+var (
+	MerchantRiskTier_name = map[int32]string{
+		0: "MERCHANT_RISK_TIER_UNSPECIFIED",
+		1: "MERCHANT_RISK_TIER_LOW",
+		2: "MERCHANT_RISK_TIER_MEDIUM",
+		3: "MERCHANT_RISK_TIER_HIGH",
+	}
+	MerchantRiskTier_value = map[string]int32{
+		"MERCHANT_RISK_TIER_UNSPECIFIED": 0,
+		"MERCHANT_RISK_TIER_LOW":         1,
+		"MERCHANT_RISK_TIER_MEDIUM":      2,
+		"MERCHANT_RISK_TIER_HIGH":        3,
+	}
+)
+
+func (x MerchantRiskTier) Enum() *MerchantRiskTier {
+	p := new(MerchantRiskTier)
+	*p = x
+	return p
+}
+
+func (x MerchantRiskTier) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MerchantRiskTier) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_risk_engine_proto_enumTypes[3].Descriptor()
+}
+
+func (MerchantRiskTier) Type() protoreflect.EnumType {
+	return &file_api_proto_risk_engine_proto_enumTypes[3]
+}
+
+func (x MerchantRiskTier) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MerchantRiskTier.Descriptor instead.
+func (MerchantRiskTier) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{3}
+}
+
+type ReviewStatus int32
+
+const (
+	ReviewStatus_REVIEW_STATUS_UNSPECIFIED ReviewStatus = 0
+	ReviewStatus_REVIEW_STATUS_PENDING     ReviewStatus = 1
+	ReviewStatus_REVIEW_STATUS_CLAIMED     ReviewStatus = 2
+	ReviewStatus_REVIEW_STATUS_RESOLVED    ReviewStatus = 3
+)
+
+// Enum value maps for ReviewStatus.
+var (
+	ReviewStatus_name = map[int32]string{
+		0: "REVIEW_STATUS_UNSPECIFIED",
+		1: "REVIEW_STATUS_PENDING",
+		2: "REVIEW_STATUS_CLAIMED",
+		3: "REVIEW_STATUS_RESOLVED",
+	}
+	ReviewStatus_value = map[string]int32{
+		"REVIEW_STATUS_UNSPECIFIED": 0,
+		"REVIEW_STATUS_PENDING":     1,
+		"REVIEW_STATUS_CLAIMED":     2,
+		"REVIEW_STATUS_RESOLVED":    3,
+	}
+)
+
+func (x ReviewStatus) Enum() *ReviewStatus {
+	p := new(ReviewStatus)
+	*p = x
+	return p
+}
+
+func (x ReviewStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_risk_engine_proto_enumTypes[4].Descriptor()
+}
+
+func (ReviewStatus) Type() protoreflect.EnumType {
+	return &file_api_proto_risk_engine_proto_enumTypes[4]
+}
+
+func (x ReviewStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewStatus.Descriptor instead.
+func (ReviewStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{4}
+}
+
+type ReviewOutcome int32
+
+const (
+	ReviewOutcome_REVIEW_OUTCOME_UNSPECIFIED ReviewOutcome = 0
+	// The transaction is legitimate.
+	ReviewOutcome_REVIEW_OUTCOME_APPROVED ReviewOutcome = 1
+	ReviewOutcome_REVIEW_OUTCOME_FRAUD    ReviewOutcome = 2
+)
+
+// Enum value maps for ReviewOutcome.
+var (
+	ReviewOutcome_name = map[int32]string{
+		0: "REVIEW_OUTCOME_UNSPECIFIED",
+		1: "REVIEW_OUTCOME_APPROVED",
+		2: "REVIEW_OUTCOME_FRAUD",
+	}
+	ReviewOutcome_value = map[string]int32{
+		"REVIEW_OUTCOME_UNSPECIFIED": 0,
+		"REVIEW_OUTCOME_APPROVED":    1,
+		"REVIEW_OUTCOME_FRAUD":       2,
+	}
+)
+
+func (x ReviewOutcome) Enum() *ReviewOutcome {
+	p := new(ReviewOutcome)
+	*p = x
+	return p
+}
+
+func (x ReviewOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_risk_engine_proto_enumTypes[5].Descriptor()
+}
+
+func (ReviewOutcome) Type() protoreflect.EnumType {
+	return &file_api_proto_risk_engine_proto_enumTypes[5]
+}
+
+func (x ReviewOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewOutcome.Descriptor instead.
+func (ReviewOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{5}
+}
+
+type OutcomeLabel int32
+
+const (
+	OutcomeLabel_OUTCOME_LABEL_UNSPECIFIED OutcomeLabel = 0
+	OutcomeLabel_OUTCOME_LABEL_FRAUD       OutcomeLabel = 1
+	OutcomeLabel_OUTCOME_LABEL_LEGITIMATE  OutcomeLabel = 2
+)
+
+// Enum value maps for OutcomeLabel.
+var (
+	OutcomeLabel_name = map[int32]string{
+		0: "OUTCOME_LABEL_UNSPECIFIED",
+		1: "OUTCOME_LABEL_FRAUD",
+		2: "OUTCOME_LABEL_LEGITIMATE",
+	}
+	OutcomeLabel_value = map[string]int32{
+		"OUTCOME_LABEL_UNSPECIFIED": 0,
+		"OUTCOME_LABEL_FRAUD":       1,
+		"OUTCOME_LABEL_LEGITIMATE":  2,
+	}
+)
+
+func (x OutcomeLabel) Enum() *OutcomeLabel {
+	p := new(OutcomeLabel)
+	*p = x
+	return p
+}
+
+func (x OutcomeLabel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutcomeLabel) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_risk_engine_proto_enumTypes[6].Descriptor()
+}
+
+func (OutcomeLabel) Type() protoreflect.EnumType {
+	return &file_api_proto_risk_engine_proto_enumTypes[6]
+}
+
+func (x OutcomeLabel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutcomeLabel.Descriptor instead.
+func (OutcomeLabel) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{6}
+}
+
+type OutcomeSource int32
+
+const (
+	OutcomeSource_OUTCOME_SOURCE_UNSPECIFIED OutcomeSource = 0
+	OutcomeSource_OUTCOME_SOURCE_CHARGEBACK  OutcomeSource = 1
+	OutcomeSource_OUTCOME_SOURCE_DISPUTE     OutcomeSource = 2
+	OutcomeSource_OUTCOME_SOURCE_REVIEW      OutcomeSource = 3
+	OutcomeSource_OUTCOME_SOURCE_OTHER       OutcomeSource = 4
+)
+
+// Enum value maps for OutcomeSource.
+var (
+	OutcomeSource_name = map[int32]string{
+		0: "OUTCOME_SOURCE_UNSPECIFIED",
+		1: "OUTCOME_SOURCE_CHARGEBACK",
+		2: "OUTCOME_SOURCE_DISPUTE",
+		3: "OUTCOME_SOURCE_REVIEW",
+		4: "OUTCOME_SOURCE_OTHER",
+	}
+	OutcomeSource_value = map[string]int32{
+		"OUTCOME_SOURCE_UNSPECIFIED": 0,
+		"OUTCOME_SOURCE_CHARGEBACK":  1,
+		"OUTCOME_SOURCE_DISPUTE":     2,
+		"OUTCOME_SOURCE_REVIEW":      3,
+		"OUTCOME_SOURCE_OTHER":       4,
+	}
+)
+
+func (x OutcomeSource) Enum() *OutcomeSource {
+	p := new(OutcomeSource)
+	*p = x
+	return p
+}
+
+func (x OutcomeSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutcomeSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_risk_engine_proto_enumTypes[7].Descriptor()
+}
+
+func (OutcomeSource) Type() protoreflect.EnumType {
+	return &file_api_proto_risk_engine_proto_enumTypes[7]
+}
+
+func (x OutcomeSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutcomeSource.Descriptor instead.
+func (OutcomeSource) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{7}
+}
+
+type OutcomeFileFormat int32
+
+const (
+	OutcomeFileFormat_OUTCOME_FILE_FORMAT_UNSPECIFIED OutcomeFileFormat = 0
+	// Header row with transaction_id, label, source and optionally timestamp
+	// (RFC 3339), in any order.
+	OutcomeFileFormat_OUTCOME_FILE_FORMAT_CSV OutcomeFileFormat = 1
+	// One {"transaction_id", "label", "source", "timestamp"} object per line.
+	OutcomeFileFormat_OUTCOME_FILE_FORMAT_JSONL OutcomeFileFormat = 2
+)
+
+// Enum value maps for OutcomeFileFormat.
+var (
+	OutcomeFileFormat_name = map[int32]string{
+		0: "OUTCOME_FILE_FORMAT_UNSPECIFIED",
+		1: "OUTCOME_FILE_FORMAT_CSV",
+		2: "OUTCOME_FILE_FORMAT_JSONL",
+	}
+	OutcomeFileFormat_value = map[string]int32{
+		"OUTCOME_FILE_FORMAT_UNSPECIFIED": 0,
+		"OUTCOME_FILE_FORMAT_CSV":         1,
+		"OUTCOME_FILE_FORMAT_JSONL":       2,
+	}
+)
+
+func (x OutcomeFileFormat) Enum() *OutcomeFileFormat {
+	p := new(OutcomeFileFormat)
+	*p = x
+	return p
+}
+
+func (x OutcomeFileFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutcomeFileFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_risk_engine_proto_enumTypes[8].Descriptor()
+}
+
+func (OutcomeFileFormat) Type() protoreflect.EnumType {
+	return &file_api_proto_risk_engine_proto_enumTypes[8]
+}
+
+func (x OutcomeFileFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutcomeFileFormat.Descriptor instead.
+func (OutcomeFileFormat) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{8}
+}
+
 type AnalyzeRequest struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId      string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	UserId             string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Amount             float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Merchant           string                 `protobuf:"bytes,4,opt,name=merchant,proto3" json:"merchant,omitempty"`
-	Location           string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
-	UserProfileContext string                 `protobuf:"bytes,6,opt,name=user_profile_context,json=userProfileContext,proto3" json:"user_profile_context,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Deprecated: use money. Only read when money is not set.
+	//
+	// Deprecated: Marked as deprecated in api/proto/risk_engine.proto.
+	Amount             float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Merchant           string  `protobuf:"bytes,4,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	Location           string  `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	UserProfileContext string  `protobuf:"bytes,6,opt,name=user_profile_context,json=userProfileContext,proto3" json:"user_profile_context,omitempty"`
+	DeviceId           string  `protobuf:"bytes,7,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	IpAddress          string  `protobuf:"bytes,8,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	CardBin            string  `protobuf:"bytes,9,opt,name=card_bin,json=cardBin,proto3" json:"card_bin,omitempty"`
+	// ISO 3166-1 alpha-2 country of the transaction.
+	CountryCode string `protobuf:"bytes,10,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	// Deprecated: use money. ISO 4217 currency of amount. Defaults to the
+	// engine's base currency.
+	//
+	// Deprecated: Marked as deprecated in api/proto/risk_engine.proto.
+	Currency string `protobuf:"bytes,11,opt,name=currency,proto3" json:"currency,omitempty"`
+	Money    *Money `protobuf:"bytes,12,opt,name=money,proto3" json:"money,omitempty"`
+	// Tenant whose configuration applies. Falls back to the x-tenant-id
+	// metadata header, then to the engine's default tenant.
+	TenantId      string `protobuf:"bytes,13,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AnalyzeRequest) Reset() {
@@ -77,6 +569,7 @@ func (x *AnalyzeRequest) GetUserId() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in api/proto/risk_engine.proto.
 func (x *AnalyzeRequest) GetAmount() float64 {
 	if x != nil {
 		return x.Amount
@@ -105,29 +598,81 @@ func (x *AnalyzeRequest) GetUserProfileContext() string {
 	return ""
 }
 
-type AnalyzeResponse struct {
+func (x *AnalyzeRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *AnalyzeRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *AnalyzeRequest) GetCardBin() string {
+	if x != nil {
+		return x.CardBin
+	}
+	return ""
+}
+
+func (x *AnalyzeRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in api/proto/risk_engine.proto.
+func (x *AnalyzeRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AnalyzeRequest) GetMoney() *Money {
+	if x != nil {
+		return x.Money
+	}
+	return nil
+}
+
+func (x *AnalyzeRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+// Money is an exact amount in the minor units of an ISO 4217 currency, e.g.
+// 49999 with USD is 499.99 USD and 500 with JPY is 500 JPY. An empty currency
+// means the engine's base currency.
+type Money struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	IsBlocked     bool                   `protobuf:"varint,1,opt,name=is_blocked,json=isBlocked,proto3" json:"is_blocked,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	AiPushMsg     string                 `protobuf:"bytes,3,opt,name=ai_push_msg,json=aiPushMsg,proto3" json:"ai_push_msg,omitempty"`
+	MinorUnits    int64                  `protobuf:"varint,1,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AnalyzeResponse) Reset() {
-	*x = AnalyzeResponse{}
+func (x *Money) Reset() {
+	*x = Money{}
 	mi := &file_api_proto_risk_engine_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AnalyzeResponse) String() string {
+func (x *Money) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AnalyzeResponse) ProtoMessage() {}
+func (*Money) ProtoMessage() {}
 
-func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
+func (x *Money) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_risk_engine_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -139,52 +684,2031 @@ func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AnalyzeResponse.ProtoReflect.Descriptor instead.
-func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
 	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *AnalyzeResponse) GetIsBlocked() bool {
+func (x *Money) GetMinorUnits() int64 {
 	if x != nil {
-		return x.IsBlocked
+		return x.MinorUnits
 	}
-	return false
+	return 0
 }
 
-func (x *AnalyzeResponse) GetReason() string {
+func (x *Money) GetCurrency() string {
 	if x != nil {
-		return x.Reason
+		return x.Currency
 	}
 	return ""
 }
 
-func (x *AnalyzeResponse) GetAiPushMsg() string {
-	if x != nil {
-		return x.AiPushMsg
-	}
-	return ""
+type AnalyzeResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	IsBlocked   bool                   `protobuf:"varint,1,opt,name=is_blocked,json=isBlocked,proto3" json:"is_blocked,omitempty"`
+	Reason      string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	AiPushMsg   string                 `protobuf:"bytes,3,opt,name=ai_push_msg,json=aiPushMsg,proto3" json:"ai_push_msg,omitempty"`
+	Explanation *Explanation           `protobuf:"bytes,4,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	// Set when the decision is review: the case whose outcome is posted to the
+	// tenant's review callback.
+	ReviewCaseId string `protobuf:"bytes,5,opt,name=review_case_id,json=reviewCaseId,proto3" json:"review_case_id,omitempty"`
+	// Locale ai_push_msg is written in, e.g. "en" or "pt-br".
+	AiPushLocale string `protobuf:"bytes,6,opt,name=ai_push_locale,json=aiPushLocale,proto3" json:"ai_push_locale,omitempty"`
+	// Set when the LLM failed or was overloaded and the tenant's degradation
+	// policy (allow, block or review) decided instead.
+	Degraded      bool `protobuf:"varint,7,opt,name=degraded,proto3" json:"degraded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-var File_api_proto_risk_engine_proto protoreflect.FileDescriptor
+func (x *AnalyzeResponse) Reset() {
+	*x = AnalyzeResponse{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeResponse) ProtoMessage() {}
+
+func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AnalyzeResponse) GetIsBlocked() bool {
+	if x != nil {
+		return x.IsBlocked
+	}
+	return false
+}
+
+func (x *AnalyzeResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AnalyzeResponse) GetAiPushMsg() string {
+	if x != nil {
+		return x.AiPushMsg
+	}
+	return ""
+}
+
+func (x *AnalyzeResponse) GetExplanation() *Explanation {
+	if x != nil {
+		return x.Explanation
+	}
+	return nil
+}
+
+func (x *AnalyzeResponse) GetReviewCaseId() string {
+	if x != nil {
+		return x.ReviewCaseId
+	}
+	return ""
+}
+
+func (x *AnalyzeResponse) GetAiPushLocale() string {
+	if x != nil {
+		return x.AiPushLocale
+	}
+	return ""
+}
+
+func (x *AnalyzeResponse) GetDegraded() bool {
+	if x != nil {
+		return x.Degraded
+	}
+	return false
+}
+
+type Explanation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Decision        string                 `protobuf:"bytes,1,opt,name=decision,proto3" json:"decision,omitempty"`
+	ConfidenceScore int32                  `protobuf:"varint,2,opt,name=confidence_score,json=confidenceScore,proto3" json:"confidence_score,omitempty"`
+	LlmRationale    string                 `protobuf:"bytes,3,opt,name=llm_rationale,json=llmRationale,proto3" json:"llm_rationale,omitempty"`
+	Factors         []*RiskFactor          `protobuf:"bytes,4,rep,name=factors,proto3" json:"factors,omitempty"`
+	Overrides       []*AppliedOverride     `protobuf:"bytes,5,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	// route is the stage that produced the verdict: prescreen_allow,
+	// prescreen_block, llm or degraded.
+	Route         string `protobuf:"bytes,6,opt,name=route,proto3" json:"route,omitempty"`
+	RouteReason   string `protobuf:"bytes,7,opt,name=route_reason,json=routeReason,proto3" json:"route_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Explanation) Reset() {
+	*x = Explanation{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Explanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Explanation) ProtoMessage() {}
+
+func (x *Explanation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Explanation.ProtoReflect.Descriptor instead.
+func (*Explanation) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Explanation) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *Explanation) GetConfidenceScore() int32 {
+	if x != nil {
+		return x.ConfidenceScore
+	}
+	return 0
+}
+
+func (x *Explanation) GetLlmRationale() string {
+	if x != nil {
+		return x.LlmRationale
+	}
+	return ""
+}
+
+func (x *Explanation) GetFactors() []*RiskFactor {
+	if x != nil {
+		return x.Factors
+	}
+	return nil
+}
+
+func (x *Explanation) GetOverrides() []*AppliedOverride {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+func (x *Explanation) GetRoute() string {
+	if x != nil {
+		return x.Route
+	}
+	return ""
+}
+
+func (x *Explanation) GetRouteReason() string {
+	if x != nil {
+		return x.RouteReason
+	}
+	return ""
+}
+
+type RiskFactor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Direction     FactorDirection        `protobuf:"varint,2,opt,name=direction,proto3,enum=riskengine.FactorDirection" json:"direction,omitempty"`
+	Weight        float64                `protobuf:"fixed64,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	Detail        string                 `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RiskFactor) Reset() {
+	*x = RiskFactor{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RiskFactor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RiskFactor) ProtoMessage() {}
+
+func (x *RiskFactor) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RiskFactor.ProtoReflect.Descriptor instead.
+func (*RiskFactor) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RiskFactor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RiskFactor) GetDirection() FactorDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return FactorDirection_FACTOR_DIRECTION_UNSPECIFIED
+}
+
+func (x *RiskFactor) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *RiskFactor) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type AppliedOverride struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Stage          string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	Rule           string                 `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	BlockedBefore  bool                   `protobuf:"varint,3,opt,name=blocked_before,json=blockedBefore,proto3" json:"blocked_before,omitempty"`
+	BlockedAfter   bool                   `protobuf:"varint,4,opt,name=blocked_after,json=blockedAfter,proto3" json:"blocked_after,omitempty"`
+	ChangedOutcome bool                   `protobuf:"varint,5,opt,name=changed_outcome,json=changedOutcome,proto3" json:"changed_outcome,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AppliedOverride) Reset() {
+	*x = AppliedOverride{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppliedOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppliedOverride) ProtoMessage() {}
+
+func (x *AppliedOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppliedOverride.ProtoReflect.Descriptor instead.
+func (*AppliedOverride) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AppliedOverride) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *AppliedOverride) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *AppliedOverride) GetBlockedBefore() bool {
+	if x != nil {
+		return x.BlockedBefore
+	}
+	return false
+}
+
+func (x *AppliedOverride) GetBlockedAfter() bool {
+	if x != nil {
+		return x.BlockedAfter
+	}
+	return false
+}
+
+func (x *AppliedOverride) GetChangedOutcome() bool {
+	if x != nil {
+		return x.ChangedOutcome
+	}
+	return false
+}
+
+type ListEntry struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	List      ListKind               `protobuf:"varint,1,opt,name=list,proto3,enum=riskengine.ListKind" json:"list,omitempty"`
+	Entity    EntityType             `protobuf:"varint,2,opt,name=entity,proto3,enum=riskengine.EntityType" json:"entity,omitempty"`
+	Value     string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Reason    string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Author    string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Limits the entry to one tenant's transactions; empty applies it to
+	// every tenant.
+	TenantId      string `protobuf:"bytes,8,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntry) Reset() {
+	*x = ListEntry{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntry) ProtoMessage() {}
+
+func (x *ListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntry.ProtoReflect.Descriptor instead.
+func (*ListEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListEntry) GetList() ListKind {
+	if x != nil {
+		return x.List
+	}
+	return ListKind_LIST_KIND_UNSPECIFIED
+}
+
+func (x *ListEntry) GetEntity() EntityType {
+	if x != nil {
+		return x.Entity
+	}
+	return EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+func (x *ListEntry) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ListEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ListEntry) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ListEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ListEntry) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ListEntry) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type AddListEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *ListEntry             `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddListEntryRequest) Reset() {
+	*x = AddListEntryRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddListEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddListEntryRequest) ProtoMessage() {}
+
+func (x *AddListEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddListEntryRequest.ProtoReflect.Descriptor instead.
+func (*AddListEntryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AddListEntryRequest) GetEntry() *ListEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type RemoveListEntryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	List   ListKind               `protobuf:"varint,1,opt,name=list,proto3,enum=riskengine.ListKind" json:"list,omitempty"`
+	Entity EntityType             `protobuf:"varint,2,opt,name=entity,proto3,enum=riskengine.EntityType" json:"entity,omitempty"`
+	Value  string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// The tenant of a tenant-scoped entry; empty removes the entry that
+	// applies to every tenant.
+	TenantId      string `protobuf:"bytes,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveListEntryRequest) Reset() {
+	*x = RemoveListEntryRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveListEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveListEntryRequest) ProtoMessage() {}
+
+func (x *RemoveListEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveListEntryRequest.ProtoReflect.Descriptor instead.
+func (*RemoveListEntryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RemoveListEntryRequest) GetList() ListKind {
+	if x != nil {
+		return x.List
+	}
+	return ListKind_LIST_KIND_UNSPECIFIED
+}
+
+func (x *RemoveListEntryRequest) GetEntity() EntityType {
+	if x != nil {
+		return x.Entity
+	}
+	return EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+func (x *RemoveListEntryRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *RemoveListEntryRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type RemoveListEntryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveListEntryResponse) Reset() {
+	*x = RemoveListEntryResponse{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveListEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveListEntryResponse) ProtoMessage() {}
+
+func (x *RemoveListEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveListEntryResponse.ProtoReflect.Descriptor instead.
+func (*RemoveListEntryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{9}
+}
+
+type ListListEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	List          ListKind               `protobuf:"varint,1,opt,name=list,proto3,enum=riskengine.ListKind" json:"list,omitempty"`
+	Entity        EntityType             `protobuf:"varint,2,opt,name=entity,proto3,enum=riskengine.EntityType" json:"entity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListListEntriesRequest) Reset() {
+	*x = ListListEntriesRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListListEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListListEntriesRequest) ProtoMessage() {}
+
+func (x *ListListEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListListEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListListEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListListEntriesRequest) GetList() ListKind {
+	if x != nil {
+		return x.List
+	}
+	return ListKind_LIST_KIND_UNSPECIFIED
+}
+
+func (x *ListListEntriesRequest) GetEntity() EntityType {
+	if x != nil {
+		return x.Entity
+	}
+	return EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+type ListListEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*ListEntry           `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListListEntriesResponse) Reset() {
+	*x = ListListEntriesResponse{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListListEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListListEntriesResponse) ProtoMessage() {}
+
+func (x *ListListEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListListEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListListEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListListEntriesResponse) GetEntries() []*ListEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// Merchant is a catalog entry. Descriptors are matched against name and
+// aliases, ignoring case, punctuation, store numbers and small misspellings.
+type Merchant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Derived from name when empty.
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// ISO 18245 merchant category code.
+	Mcc string `protobuf:"bytes,3,opt,name=mcc,proto3" json:"mcc,omitempty"`
+	// e.g. crypto_exchange, p2p_transfer, gambling, grocery.
+	Category      string           `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	RiskTier      MerchantRiskTier `protobuf:"varint,5,opt,name=risk_tier,json=riskTier,proto3,enum=riskengine.MerchantRiskTier" json:"risk_tier,omitempty"`
+	Aliases       []string         `protobuf:"bytes,6,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Merchant) Reset() {
+	*x = Merchant{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Merchant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Merchant) ProtoMessage() {}
+
+func (x *Merchant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Merchant.ProtoReflect.Descriptor instead.
+func (*Merchant) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Merchant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Merchant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Merchant) GetMcc() string {
+	if x != nil {
+		return x.Mcc
+	}
+	return ""
+}
+
+func (x *Merchant) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Merchant) GetRiskTier() MerchantRiskTier {
+	if x != nil {
+		return x.RiskTier
+	}
+	return MerchantRiskTier_MERCHANT_RISK_TIER_UNSPECIFIED
+}
+
+func (x *Merchant) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+type UpsertMerchantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchant      *Merchant              `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertMerchantRequest) Reset() {
+	*x = UpsertMerchantRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertMerchantRequest) ProtoMessage() {}
+
+func (x *UpsertMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertMerchantRequest.ProtoReflect.Descriptor instead.
+func (*UpsertMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpsertMerchantRequest) GetMerchant() *Merchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+type RemoveMerchantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMerchantRequest) Reset() {
+	*x = RemoveMerchantRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMerchantRequest) ProtoMessage() {}
+
+func (x *RemoveMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMerchantRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RemoveMerchantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RemoveMerchantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMerchantResponse) Reset() {
+	*x = RemoveMerchantResponse{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMerchantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMerchantResponse) ProtoMessage() {}
+
+func (x *RemoveMerchantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMerchantResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMerchantResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{15}
+}
+
+type ListMerchantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMerchantsRequest) Reset() {
+	*x = ListMerchantsRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMerchantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMerchantsRequest) ProtoMessage() {}
+
+func (x *ListMerchantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMerchantsRequest.ProtoReflect.Descriptor instead.
+func (*ListMerchantsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListMerchantsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type ListMerchantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchants     []*Merchant            `protobuf:"bytes,1,rep,name=merchants,proto3" json:"merchants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMerchantsResponse) Reset() {
+	*x = ListMerchantsResponse{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMerchantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMerchantsResponse) ProtoMessage() {}
+
+func (x *ListMerchantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMerchantsResponse.ProtoReflect.Descriptor instead.
+func (*ListMerchantsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListMerchantsResponse) GetMerchants() []*Merchant {
+	if x != nil {
+		return x.Merchants
+	}
+	return nil
+}
+
+// ReviewCase is a decision waiting for, or resolved by, an analyst. It keeps
+// the transaction as analyzed, in the base currency, and the verdict that
+// sent it to review.
+type ReviewCase struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId    string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Transaction *AnalyzeRequest        `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Reason      string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Explanation *Explanation           `protobuf:"bytes,5,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	Status      ReviewStatus           `protobuf:"varint,6,opt,name=status,proto3,enum=riskengine.ReviewStatus" json:"status,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// The case is escalated once when it passes due_at and again every SLA
+	// period after that.
+	DueAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Escalations   int32                  `protobuf:"varint,9,opt,name=escalations,proto3" json:"escalations,omitempty"`
+	Assignee      string                 `protobuf:"bytes,10,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	ClaimedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=claimed_at,json=claimedAt,proto3" json:"claimed_at,omitempty"`
+	Outcome       ReviewOutcome          `protobuf:"varint,12,opt,name=outcome,proto3,enum=riskengine.ReviewOutcome" json:"outcome,omitempty"`
+	Notes         string                 `protobuf:"bytes,13,opt,name=notes,proto3" json:"notes,omitempty"`
+	ResolvedAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewCase) Reset() {
+	*x = ReviewCase{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewCase) ProtoMessage() {}
+
+func (x *ReviewCase) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewCase.ProtoReflect.Descriptor instead.
+func (*ReviewCase) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReviewCase) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReviewCase) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ReviewCase) GetTransaction() *AnalyzeRequest {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *ReviewCase) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReviewCase) GetExplanation() *Explanation {
+	if x != nil {
+		return x.Explanation
+	}
+	return nil
+}
+
+func (x *ReviewCase) GetStatus() ReviewStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReviewStatus_REVIEW_STATUS_UNSPECIFIED
+}
+
+func (x *ReviewCase) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ReviewCase) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *ReviewCase) GetEscalations() int32 {
+	if x != nil {
+		return x.Escalations
+	}
+	return 0
+}
+
+func (x *ReviewCase) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *ReviewCase) GetClaimedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClaimedAt
+	}
+	return nil
+}
+
+func (x *ReviewCase) GetOutcome() ReviewOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return ReviewOutcome_REVIEW_OUTCOME_UNSPECIFIED
+}
+
+func (x *ReviewCase) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *ReviewCase) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+type ListReviewCasesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty lists every tenant the caller may see.
+	TenantId string `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Unspecified lists open (pending and claimed) cases.
+	Status      ReviewStatus `protobuf:"varint,2,opt,name=status,proto3,enum=riskengine.ReviewStatus" json:"status,omitempty"`
+	OverdueOnly bool         `protobuf:"varint,3,opt,name=overdue_only,json=overdueOnly,proto3" json:"overdue_only,omitempty"`
+	// Defaults to 100, at most 1000.
+	PageSize      int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewCasesRequest) Reset() {
+	*x = ListReviewCasesRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewCasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewCasesRequest) ProtoMessage() {}
+
+func (x *ListReviewCasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewCasesRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewCasesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListReviewCasesRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListReviewCasesRequest) GetStatus() ReviewStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReviewStatus_REVIEW_STATUS_UNSPECIFIED
+}
+
+func (x *ListReviewCasesRequest) GetOverdueOnly() bool {
+	if x != nil {
+		return x.OverdueOnly
+	}
+	return false
+}
+
+func (x *ListReviewCasesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// Cases are ordered by escalations, most first, then by due time.
+type ListReviewCasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cases         []*ReviewCase          `protobuf:"bytes,1,rep,name=cases,proto3" json:"cases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewCasesResponse) Reset() {
+	*x = ListReviewCasesResponse{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewCasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewCasesResponse) ProtoMessage() {}
+
+func (x *ListReviewCasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewCasesResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewCasesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListReviewCasesResponse) GetCases() []*ReviewCase {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+type GetReviewCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewCaseRequest) Reset() {
+	*x = GetReviewCaseRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewCaseRequest) ProtoMessage() {}
+
+func (x *GetReviewCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewCaseRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewCaseRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetReviewCaseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetReviewCaseRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ClaimReviewCaseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty claims the open case with the highest priority.
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId string `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Who claims the case when authentication is off; otherwise the
+	// authenticated caller.
+	Reviewer      string `protobuf:"bytes,3,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimReviewCaseRequest) Reset() {
+	*x = ClaimReviewCaseRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimReviewCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimReviewCaseRequest) ProtoMessage() {}
+
+func (x *ClaimReviewCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimReviewCaseRequest.ProtoReflect.Descriptor instead.
+func (*ClaimReviewCaseRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ClaimReviewCaseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ClaimReviewCaseRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ClaimReviewCaseRequest) GetReviewer() string {
+	if x != nil {
+		return x.Reviewer
+	}
+	return ""
+}
+
+type SubmitReviewOutcomeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Outcome  ReviewOutcome          `protobuf:"varint,3,opt,name=outcome,proto3,enum=riskengine.ReviewOutcome" json:"outcome,omitempty"`
+	Notes    string                 `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`
+	// Must match the case assignee; see ClaimReviewCaseRequest.reviewer.
+	Reviewer      string `protobuf:"bytes,5,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitReviewOutcomeRequest) Reset() {
+	*x = SubmitReviewOutcomeRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitReviewOutcomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitReviewOutcomeRequest) ProtoMessage() {}
+
+func (x *SubmitReviewOutcomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitReviewOutcomeRequest.ProtoReflect.Descriptor instead.
+func (*SubmitReviewOutcomeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SubmitReviewOutcomeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubmitReviewOutcomeRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SubmitReviewOutcomeRequest) GetOutcome() ReviewOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return ReviewOutcome_REVIEW_OUTCOME_UNSPECIFIED
+}
+
+func (x *SubmitReviewOutcomeRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *SubmitReviewOutcomeRequest) GetReviewer() string {
+	if x != nil {
+		return x.Reviewer
+	}
+	return ""
+}
+
+type ReportOutcomeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Label         OutcomeLabel           `protobuf:"varint,2,opt,name=label,proto3,enum=riskengine.OutcomeLabel" json:"label,omitempty"`
+	Source        OutcomeSource          `protobuf:"varint,3,opt,name=source,proto3,enum=riskengine.OutcomeSource" json:"source,omitempty"`
+	// When the outcome happened, e.g. the chargeback date. Defaults to now.
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TenantId      string                 `protobuf:"bytes,5,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportOutcomeRequest) Reset() {
+	*x = ReportOutcomeRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportOutcomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportOutcomeRequest) ProtoMessage() {}
+
+func (x *ReportOutcomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportOutcomeRequest.ProtoReflect.Descriptor instead.
+func (*ReportOutcomeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReportOutcomeRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ReportOutcomeRequest) GetLabel() OutcomeLabel {
+	if x != nil {
+		return x.Label
+	}
+	return OutcomeLabel_OUTCOME_LABEL_UNSPECIFIED
+}
+
+func (x *ReportOutcomeRequest) GetSource() OutcomeSource {
+	if x != nil {
+		return x.Source
+	}
+	return OutcomeSource_OUTCOME_SOURCE_UNSPECIFIED
+}
+
+func (x *ReportOutcomeRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ReportOutcomeRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ReportOutcomeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the label was joined to the engine's decision on the
+	// transaction. Only recent decisions can be joined.
+	MatchedDecision bool `protobuf:"varint,1,opt,name=matched_decision,json=matchedDecision,proto3" json:"matched_decision,omitempty"`
+	// The engine's decision, if matched: allow, block or review.
+	Decision string `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	// The label repeats the transaction's current one and changed nothing.
+	Duplicate bool `protobuf:"varint,3,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	// Deny list entries added because the transaction was confirmed as fraud.
+	Denylisted    []*ListEntry `protobuf:"bytes,4,rep,name=denylisted,proto3" json:"denylisted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportOutcomeResponse) Reset() {
+	*x = ReportOutcomeResponse{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportOutcomeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportOutcomeResponse) ProtoMessage() {}
+
+func (x *ReportOutcomeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportOutcomeResponse.ProtoReflect.Descriptor instead.
+func (*ReportOutcomeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReportOutcomeResponse) GetMatchedDecision() bool {
+	if x != nil {
+		return x.MatchedDecision
+	}
+	return false
+}
+
+func (x *ReportOutcomeResponse) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *ReportOutcomeResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *ReportOutcomeResponse) GetDenylisted() []*ListEntry {
+	if x != nil {
+		return x.Denylisted
+	}
+	return nil
+}
+
+// ImportOutcomesRequest reports a file of outcomes, all for one tenant.
+// Labels and sources are the lowercase names: fraud, legitimate; chargeback,
+// dispute, review, other.
+type ImportOutcomesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Format        OutcomeFileFormat      `protobuf:"varint,2,opt,name=format,proto3,enum=riskengine.OutcomeFileFormat" json:"format,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOutcomesRequest) Reset() {
+	*x = ImportOutcomesRequest{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOutcomesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOutcomesRequest) ProtoMessage() {}
+
+func (x *ImportOutcomesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOutcomesRequest.ProtoReflect.Descriptor instead.
+func (*ImportOutcomesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ImportOutcomesRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ImportOutcomesRequest) GetFormat() OutcomeFileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return OutcomeFileFormat_OUTCOME_FILE_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportOutcomesRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportOutcomesResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Imported   int32                  `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Duplicates int32                  `protobuf:"varint,2,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	// Imported labels joined to the engine's decision.
+	Matched int32 `protobuf:"varint,3,opt,name=matched,proto3" json:"matched,omitempty"`
+	// Rows that were not imported; the others are.
+	Errors        []*ImportError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOutcomesResponse) Reset() {
+	*x = ImportOutcomesResponse{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOutcomesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOutcomesResponse) ProtoMessage() {}
+
+func (x *ImportOutcomesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOutcomesResponse.ProtoReflect.Descriptor instead.
+func (*ImportOutcomesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ImportOutcomesResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportOutcomesResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *ImportOutcomesResponse) GetMatched() int32 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *ImportOutcomesResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ImportError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1-based line in the file.
+	Line          int32  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_api_proto_risk_engine_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_risk_engine_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_api_proto_risk_engine_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ImportError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_api_proto_risk_engine_proto protoreflect.FileDescriptor
 
 const file_api_proto_risk_engine_proto_rawDesc = "" +
 	"\n" +
 	"\x1bapi/proto/risk_engine.proto\x12\n" +
-	"riskengine\"\xd2\x01\n" +
+	"riskengine\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb6\x03\n" +
 	"\x0eAnalyzeRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\x06amount\x18\x03 \x01(\x01B\x02\x18\x01R\x06amount\x12\x1a\n" +
 	"\bmerchant\x18\x04 \x01(\tR\bmerchant\x12\x1a\n" +
 	"\blocation\x18\x05 \x01(\tR\blocation\x120\n" +
-	"\x14user_profile_context\x18\x06 \x01(\tR\x12userProfileContext\"h\n" +
+	"\x14user_profile_context\x18\x06 \x01(\tR\x12userProfileContext\x12\x1b\n" +
+	"\tdevice_id\x18\a \x01(\tR\bdeviceId\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\b \x01(\tR\tipAddress\x12\x19\n" +
+	"\bcard_bin\x18\t \x01(\tR\acardBin\x12!\n" +
+	"\fcountry_code\x18\n" +
+	" \x01(\tR\vcountryCode\x12\x1e\n" +
+	"\bcurrency\x18\v \x01(\tB\x02\x18\x01R\bcurrency\x12'\n" +
+	"\x05money\x18\f \x01(\v2\x11.riskengine.MoneyR\x05money\x12\x1b\n" +
+	"\ttenant_id\x18\r \x01(\tR\btenantId\"D\n" +
+	"\x05Money\x12\x1f\n" +
+	"\vminor_units\x18\x01 \x01(\x03R\n" +
+	"minorUnits\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\x8b\x02\n" +
 	"\x0fAnalyzeResponse\x12\x1d\n" +
 	"\n" +
 	"is_blocked\x18\x01 \x01(\bR\tisBlocked\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1e\n" +
-	"\vai_push_msg\x18\x03 \x01(\tR\taiPushMsg2b\n" +
+	"\vai_push_msg\x18\x03 \x01(\tR\taiPushMsg\x129\n" +
+	"\vexplanation\x18\x04 \x01(\v2\x17.riskengine.ExplanationR\vexplanation\x12$\n" +
+	"\x0ereview_case_id\x18\x05 \x01(\tR\freviewCaseId\x12$\n" +
+	"\x0eai_push_locale\x18\x06 \x01(\tR\faiPushLocale\x12\x1a\n" +
+	"\bdegraded\x18\a \x01(\bR\bdegraded\"\x9f\x02\n" +
+	"\vExplanation\x12\x1a\n" +
+	"\bdecision\x18\x01 \x01(\tR\bdecision\x12)\n" +
+	"\x10confidence_score\x18\x02 \x01(\x05R\x0fconfidenceScore\x12#\n" +
+	"\rllm_rationale\x18\x03 \x01(\tR\fllmRationale\x120\n" +
+	"\afactors\x18\x04 \x03(\v2\x16.riskengine.RiskFactorR\afactors\x129\n" +
+	"\toverrides\x18\x05 \x03(\v2\x1b.riskengine.AppliedOverrideR\toverrides\x12\x14\n" +
+	"\x05route\x18\x06 \x01(\tR\x05route\x12!\n" +
+	"\froute_reason\x18\a \x01(\tR\vrouteReason\"\x8b\x01\n" +
+	"\n" +
+	"RiskFactor\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x129\n" +
+	"\tdirection\x18\x02 \x01(\x0e2\x1b.riskengine.FactorDirectionR\tdirection\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x01R\x06weight\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\"\xb0\x01\n" +
+	"\x0fAppliedOverride\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x12\n" +
+	"\x04rule\x18\x02 \x01(\tR\x04rule\x12%\n" +
+	"\x0eblocked_before\x18\x03 \x01(\bR\rblockedBefore\x12#\n" +
+	"\rblocked_after\x18\x04 \x01(\bR\fblockedAfter\x12'\n" +
+	"\x0fchanged_outcome\x18\x05 \x01(\bR\x0echangedOutcome\"\xbe\x02\n" +
+	"\tListEntry\x12(\n" +
+	"\x04list\x18\x01 \x01(\x0e2\x14.riskengine.ListKindR\x04list\x12.\n" +
+	"\x06entity\x18\x02 \x01(\x0e2\x16.riskengine.EntityTypeR\x06entity\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\ttenant_id\x18\b \x01(\tR\btenantId\"B\n" +
+	"\x13AddListEntryRequest\x12+\n" +
+	"\x05entry\x18\x01 \x01(\v2\x15.riskengine.ListEntryR\x05entry\"\xa5\x01\n" +
+	"\x16RemoveListEntryRequest\x12(\n" +
+	"\x04list\x18\x01 \x01(\x0e2\x14.riskengine.ListKindR\x04list\x12.\n" +
+	"\x06entity\x18\x02 \x01(\x0e2\x16.riskengine.EntityTypeR\x06entity\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x1b\n" +
+	"\ttenant_id\x18\x04 \x01(\tR\btenantId\"\x19\n" +
+	"\x17RemoveListEntryResponse\"r\n" +
+	"\x16ListListEntriesRequest\x12(\n" +
+	"\x04list\x18\x01 \x01(\x0e2\x14.riskengine.ListKindR\x04list\x12.\n" +
+	"\x06entity\x18\x02 \x01(\x0e2\x16.riskengine.EntityTypeR\x06entity\"J\n" +
+	"\x17ListListEntriesResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.riskengine.ListEntryR\aentries\"\xb1\x01\n" +
+	"\bMerchant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03mcc\x18\x03 \x01(\tR\x03mcc\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x129\n" +
+	"\trisk_tier\x18\x05 \x01(\x0e2\x1c.riskengine.MerchantRiskTierR\briskTier\x12\x18\n" +
+	"\aaliases\x18\x06 \x03(\tR\aaliases\"I\n" +
+	"\x15UpsertMerchantRequest\x120\n" +
+	"\bmerchant\x18\x01 \x01(\v2\x14.riskengine.MerchantR\bmerchant\"'\n" +
+	"\x15RemoveMerchantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16RemoveMerchantResponse\"2\n" +
+	"\x14ListMerchantsRequest\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\"K\n" +
+	"\x15ListMerchantsResponse\x122\n" +
+	"\tmerchants\x18\x01 \x03(\v2\x14.riskengine.MerchantR\tmerchants\"\xeb\x04\n" +
+	"\n" +
+	"ReviewCase\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12<\n" +
+	"\vtransaction\x18\x03 \x01(\v2\x1a.riskengine.AnalyzeRequestR\vtransaction\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x129\n" +
+	"\vexplanation\x18\x05 \x01(\v2\x17.riskengine.ExplanationR\vexplanation\x120\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x18.riskengine.ReviewStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x121\n" +
+	"\x06due_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12 \n" +
+	"\vescalations\x18\t \x01(\x05R\vescalations\x12\x1a\n" +
+	"\bassignee\x18\n" +
+	" \x01(\tR\bassignee\x129\n" +
+	"\n" +
+	"claimed_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tclaimedAt\x123\n" +
+	"\aoutcome\x18\f \x01(\x0e2\x19.riskengine.ReviewOutcomeR\aoutcome\x12\x14\n" +
+	"\x05notes\x18\r \x01(\tR\x05notes\x12;\n" +
+	"\vresolved_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\"\xa7\x01\n" +
+	"\x16ListReviewCasesRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x120\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.riskengine.ReviewStatusR\x06status\x12!\n" +
+	"\foverdue_only\x18\x03 \x01(\bR\voverdueOnly\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"G\n" +
+	"\x17ListReviewCasesResponse\x12,\n" +
+	"\x05cases\x18\x01 \x03(\v2\x16.riskengine.ReviewCaseR\x05cases\"C\n" +
+	"\x14GetReviewCaseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\"a\n" +
+	"\x16ClaimReviewCaseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x1a\n" +
+	"\breviewer\x18\x03 \x01(\tR\breviewer\"\xb0\x01\n" +
+	"\x1aSubmitReviewOutcomeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x123\n" +
+	"\aoutcome\x18\x03 \x01(\x0e2\x19.riskengine.ReviewOutcomeR\aoutcome\x12\x14\n" +
+	"\x05notes\x18\x04 \x01(\tR\x05notes\x12\x1a\n" +
+	"\breviewer\x18\x05 \x01(\tR\breviewer\"\xf7\x01\n" +
+	"\x14ReportOutcomeRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12.\n" +
+	"\x05label\x18\x02 \x01(\x0e2\x18.riskengine.OutcomeLabelR\x05label\x121\n" +
+	"\x06source\x18\x03 \x01(\x0e2\x19.riskengine.OutcomeSourceR\x06source\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1b\n" +
+	"\ttenant_id\x18\x05 \x01(\tR\btenantId\"\xb3\x01\n" +
+	"\x15ReportOutcomeResponse\x12)\n" +
+	"\x10matched_decision\x18\x01 \x01(\bR\x0fmatchedDecision\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x1c\n" +
+	"\tduplicate\x18\x03 \x01(\bR\tduplicate\x125\n" +
+	"\n" +
+	"denylisted\x18\x04 \x03(\v2\x15.riskengine.ListEntryR\n" +
+	"denylisted\"\x7f\n" +
+	"\x15ImportOutcomesRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x125\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1d.riskengine.OutcomeFileFormatR\x06format\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\x9f\x01\n" +
+	"\x16ImportOutcomesResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x05R\bimported\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x02 \x01(\x05R\n" +
+	"duplicates\x12\x18\n" +
+	"\amatched\x18\x03 \x01(\x05R\amatched\x12/\n" +
+	"\x06errors\x18\x04 \x03(\v2\x17.riskengine.ImportErrorR\x06errors\";\n" +
+	"\vImportError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage*\x9b\x01\n" +
+	"\x0fFactorDirection\x12 \n" +
+	"\x1cFACTOR_DIRECTION_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fFACTOR_DIRECTION_INCREASES_RISK\x10\x01\x12#\n" +
+	"\x1fFACTOR_DIRECTION_DECREASES_RISK\x10\x02\x12\x1c\n" +
+	"\x18FACTOR_DIRECTION_NEUTRAL\x10\x03*N\n" +
+	"\bListKind\x12\x19\n" +
+	"\x15LIST_KIND_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fLIST_KIND_ALLOW\x10\x01\x12\x12\n" +
+	"\x0eLIST_KIND_DENY\x10\x02*\xb3\x01\n" +
+	"\n" +
+	"EntityType\x12\x1b\n" +
+	"\x17ENTITY_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ENTITY_TYPE_MERCHANT\x10\x01\x12\x14\n" +
+	"\x10ENTITY_TYPE_USER\x10\x02\x12\x16\n" +
+	"\x12ENTITY_TYPE_DEVICE\x10\x03\x12\x12\n" +
+	"\x0eENTITY_TYPE_IP\x10\x04\x12\x13\n" +
+	"\x0fENTITY_TYPE_BIN\x10\x05\x12\x17\n" +
+	"\x13ENTITY_TYPE_COUNTRY\x10\x06*\x8e\x01\n" +
+	"\x10MerchantRiskTier\x12\"\n" +
+	"\x1eMERCHANT_RISK_TIER_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16MERCHANT_RISK_TIER_LOW\x10\x01\x12\x1d\n" +
+	"\x19MERCHANT_RISK_TIER_MEDIUM\x10\x02\x12\x1b\n" +
+	"\x17MERCHANT_RISK_TIER_HIGH\x10\x03*\x7f\n" +
+	"\fReviewStatus\x12\x1d\n" +
+	"\x19REVIEW_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15REVIEW_STATUS_PENDING\x10\x01\x12\x19\n" +
+	"\x15REVIEW_STATUS_CLAIMED\x10\x02\x12\x1a\n" +
+	"\x16REVIEW_STATUS_RESOLVED\x10\x03*f\n" +
+	"\rReviewOutcome\x12\x1e\n" +
+	"\x1aREVIEW_OUTCOME_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17REVIEW_OUTCOME_APPROVED\x10\x01\x12\x18\n" +
+	"\x14REVIEW_OUTCOME_FRAUD\x10\x02*d\n" +
+	"\fOutcomeLabel\x12\x1d\n" +
+	"\x19OUTCOME_LABEL_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13OUTCOME_LABEL_FRAUD\x10\x01\x12\x1c\n" +
+	"\x18OUTCOME_LABEL_LEGITIMATE\x10\x02*\x9f\x01\n" +
+	"\rOutcomeSource\x12\x1e\n" +
+	"\x1aOUTCOME_SOURCE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19OUTCOME_SOURCE_CHARGEBACK\x10\x01\x12\x1a\n" +
+	"\x16OUTCOME_SOURCE_DISPUTE\x10\x02\x12\x19\n" +
+	"\x15OUTCOME_SOURCE_REVIEW\x10\x03\x12\x18\n" +
+	"\x14OUTCOME_SOURCE_OTHER\x10\x04*t\n" +
+	"\x11OutcomeFileFormat\x12#\n" +
+	"\x1fOUTCOME_FILE_FORMAT_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17OUTCOME_FILE_FORMAT_CSV\x10\x01\x12\x1d\n" +
+	"\x19OUTCOME_FILE_FORMAT_JSONL\x10\x022b\n" +
 	"\x11RiskEngineService\x12M\n" +
-	"\x12AnalyzeTransaction\x12\x1a.riskengine.AnalyzeRequest\x1a\x1b.riskengine.AnalyzeResponseB-Z+github.com/tokyosplif/ai-risk-engine/pkg/pbb\x06proto3"
+	"\x12AnalyzeTransaction\x12\x1a.riskengine.AnalyzeRequest\x1a\x1b.riskengine.AnalyzeResponse2\x8c\x04\n" +
+	"\x10RiskAdminService\x12F\n" +
+	"\fAddListEntry\x12\x1f.riskengine.AddListEntryRequest\x1a\x15.riskengine.ListEntry\x12Z\n" +
+	"\x0fRemoveListEntry\x12\".riskengine.RemoveListEntryRequest\x1a#.riskengine.RemoveListEntryResponse\x12Z\n" +
+	"\x0fListListEntries\x12\".riskengine.ListListEntriesRequest\x1a#.riskengine.ListListEntriesResponse\x12I\n" +
+	"\x0eUpsertMerchant\x12!.riskengine.UpsertMerchantRequest\x1a\x14.riskengine.Merchant\x12W\n" +
+	"\x0eRemoveMerchant\x12!.riskengine.RemoveMerchantRequest\x1a\".riskengine.RemoveMerchantResponse\x12T\n" +
+	"\rListMerchants\x12 .riskengine.ListMerchantsRequest\x1a!.riskengine.ListMerchantsResponse2\xe0\x02\n" +
+	"\x11RiskReviewService\x12Z\n" +
+	"\x0fListReviewCases\x12\".riskengine.ListReviewCasesRequest\x1a#.riskengine.ListReviewCasesResponse\x12I\n" +
+	"\rGetReviewCase\x12 .riskengine.GetReviewCaseRequest\x1a\x16.riskengine.ReviewCase\x12M\n" +
+	"\x0fClaimReviewCase\x12\".riskengine.ClaimReviewCaseRequest\x1a\x16.riskengine.ReviewCase\x12U\n" +
+	"\x13SubmitReviewOutcome\x12&.riskengine.SubmitReviewOutcomeRequest\x1a\x16.riskengine.ReviewCase2\xc4\x01\n" +
+	"\x13RiskFeedbackService\x12T\n" +
+	"\rReportOutcome\x12 .riskengine.ReportOutcomeRequest\x1a!.riskengine.ReportOutcomeResponse\x12W\n" +
+	"\x0eImportOutcomes\x12!.riskengine.ImportOutcomesRequest\x1a\".riskengine.ImportOutcomesResponseB-Z+github.com/tokyosplif/ai-risk-engine/pkg/pbb\x06proto3"
 
 var (
 	file_api_proto_risk_engine_proto_rawDescOnce sync.Once
@@ -198,19 +2722,116 @@ func file_api_proto_risk_engine_proto_rawDescGZIP() []byte {
 	return file_api_proto_risk_engine_proto_rawDescData
 }
 
-var file_api_proto_risk_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_proto_risk_engine_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_api_proto_risk_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_api_proto_risk_engine_proto_goTypes = []any{
-	(*AnalyzeRequest)(nil),  // 0: riskengine.AnalyzeRequest
-	(*AnalyzeResponse)(nil), // 1: riskengine.AnalyzeResponse
+	(FactorDirection)(0),               // 0: riskengine.FactorDirection
+	(ListKind)(0),                      // 1: riskengine.ListKind
+	(EntityType)(0),                    // 2: riskengine.EntityType
+	(MerchantRiskTier)(0),              // 3: riskengine.MerchantRiskTier
+	(ReviewStatus)(0),                  // 4: riskengine.ReviewStatus
+	(ReviewOutcome)(0),                 // 5: riskengine.ReviewOutcome
+	(OutcomeLabel)(0),                  // 6: riskengine.OutcomeLabel
+	(OutcomeSource)(0),                 // 7: riskengine.OutcomeSource
+	(OutcomeFileFormat)(0),             // 8: riskengine.OutcomeFileFormat
+	(*AnalyzeRequest)(nil),             // 9: riskengine.AnalyzeRequest
+	(*Money)(nil),                      // 10: riskengine.Money
+	(*AnalyzeResponse)(nil),            // 11: riskengine.AnalyzeResponse
+	(*Explanation)(nil),                // 12: riskengine.Explanation
+	(*RiskFactor)(nil),                 // 13: riskengine.RiskFactor
+	(*AppliedOverride)(nil),            // 14: riskengine.AppliedOverride
+	(*ListEntry)(nil),                  // 15: riskengine.ListEntry
+	(*AddListEntryRequest)(nil),        // 16: riskengine.AddListEntryRequest
+	(*RemoveListEntryRequest)(nil),     // 17: riskengine.RemoveListEntryRequest
+	(*RemoveListEntryResponse)(nil),    // 18: riskengine.RemoveListEntryResponse
+	(*ListListEntriesRequest)(nil),     // 19: riskengine.ListListEntriesRequest
+	(*ListListEntriesResponse)(nil),    // 20: riskengine.ListListEntriesResponse
+	(*Merchant)(nil),                   // 21: riskengine.Merchant
+	(*UpsertMerchantRequest)(nil),      // 22: riskengine.UpsertMerchantRequest
+	(*RemoveMerchantRequest)(nil),      // 23: riskengine.RemoveMerchantRequest
+	(*RemoveMerchantResponse)(nil),     // 24: riskengine.RemoveMerchantResponse
+	(*ListMerchantsRequest)(nil),       // 25: riskengine.ListMerchantsRequest
+	(*ListMerchantsResponse)(nil),      // 26: riskengine.ListMerchantsResponse
+	(*ReviewCase)(nil),                 // 27: riskengine.ReviewCase
+	(*ListReviewCasesRequest)(nil),     // 28: riskengine.ListReviewCasesRequest
+	(*ListReviewCasesResponse)(nil),    // 29: riskengine.ListReviewCasesResponse
+	(*GetReviewCaseRequest)(nil),       // 30: riskengine.GetReviewCaseRequest
+	(*ClaimReviewCaseRequest)(nil),     // 31: riskengine.ClaimReviewCaseRequest
+	(*SubmitReviewOutcomeRequest)(nil), // 32: riskengine.SubmitReviewOutcomeRequest
+	(*ReportOutcomeRequest)(nil),       // 33: riskengine.ReportOutcomeRequest
+	(*ReportOutcomeResponse)(nil),      // 34: riskengine.ReportOutcomeResponse
+	(*ImportOutcomesRequest)(nil),      // 35: riskengine.ImportOutcomesRequest
+	(*ImportOutcomesResponse)(nil),     // 36: riskengine.ImportOutcomesResponse
+	(*ImportError)(nil),                // 37: riskengine.ImportError
+	(*timestamppb.Timestamp)(nil),      // 38: google.protobuf.Timestamp
 }
 var file_api_proto_risk_engine_proto_depIdxs = []int32{
-	0, // 0: riskengine.RiskEngineService.AnalyzeTransaction:input_type -> riskengine.AnalyzeRequest
-	1, // 1: riskengine.RiskEngineService.AnalyzeTransaction:output_type -> riskengine.AnalyzeResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	10, // 0: riskengine.AnalyzeRequest.money:type_name -> riskengine.Money
+	12, // 1: riskengine.AnalyzeResponse.explanation:type_name -> riskengine.Explanation
+	13, // 2: riskengine.Explanation.factors:type_name -> riskengine.RiskFactor
+	14, // 3: riskengine.Explanation.overrides:type_name -> riskengine.AppliedOverride
+	0,  // 4: riskengine.RiskFactor.direction:type_name -> riskengine.FactorDirection
+	1,  // 5: riskengine.ListEntry.list:type_name -> riskengine.ListKind
+	2,  // 6: riskengine.ListEntry.entity:type_name -> riskengine.EntityType
+	38, // 7: riskengine.ListEntry.created_at:type_name -> google.protobuf.Timestamp
+	38, // 8: riskengine.ListEntry.expires_at:type_name -> google.protobuf.Timestamp
+	15, // 9: riskengine.AddListEntryRequest.entry:type_name -> riskengine.ListEntry
+	1,  // 10: riskengine.RemoveListEntryRequest.list:type_name -> riskengine.ListKind
+	2,  // 11: riskengine.RemoveListEntryRequest.entity:type_name -> riskengine.EntityType
+	1,  // 12: riskengine.ListListEntriesRequest.list:type_name -> riskengine.ListKind
+	2,  // 13: riskengine.ListListEntriesRequest.entity:type_name -> riskengine.EntityType
+	15, // 14: riskengine.ListListEntriesResponse.entries:type_name -> riskengine.ListEntry
+	3,  // 15: riskengine.Merchant.risk_tier:type_name -> riskengine.MerchantRiskTier
+	21, // 16: riskengine.UpsertMerchantRequest.merchant:type_name -> riskengine.Merchant
+	21, // 17: riskengine.ListMerchantsResponse.merchants:type_name -> riskengine.Merchant
+	9,  // 18: riskengine.ReviewCase.transaction:type_name -> riskengine.AnalyzeRequest
+	12, // 19: riskengine.ReviewCase.explanation:type_name -> riskengine.Explanation
+	4,  // 20: riskengine.ReviewCase.status:type_name -> riskengine.ReviewStatus
+	38, // 21: riskengine.ReviewCase.created_at:type_name -> google.protobuf.Timestamp
+	38, // 22: riskengine.ReviewCase.due_at:type_name -> google.protobuf.Timestamp
+	38, // 23: riskengine.ReviewCase.claimed_at:type_name -> google.protobuf.Timestamp
+	5,  // 24: riskengine.ReviewCase.outcome:type_name -> riskengine.ReviewOutcome
+	38, // 25: riskengine.ReviewCase.resolved_at:type_name -> google.protobuf.Timestamp
+	4,  // 26: riskengine.ListReviewCasesRequest.status:type_name -> riskengine.ReviewStatus
+	27, // 27: riskengine.ListReviewCasesResponse.cases:type_name -> riskengine.ReviewCase
+	5,  // 28: riskengine.SubmitReviewOutcomeRequest.outcome:type_name -> riskengine.ReviewOutcome
+	6,  // 29: riskengine.ReportOutcomeRequest.label:type_name -> riskengine.OutcomeLabel
+	7,  // 30: riskengine.ReportOutcomeRequest.source:type_name -> riskengine.OutcomeSource
+	38, // 31: riskengine.ReportOutcomeRequest.timestamp:type_name -> google.protobuf.Timestamp
+	15, // 32: riskengine.ReportOutcomeResponse.denylisted:type_name -> riskengine.ListEntry
+	8,  // 33: riskengine.ImportOutcomesRequest.format:type_name -> riskengine.OutcomeFileFormat
+	37, // 34: riskengine.ImportOutcomesResponse.errors:type_name -> riskengine.ImportError
+	9,  // 35: riskengine.RiskEngineService.AnalyzeTransaction:input_type -> riskengine.AnalyzeRequest
+	16, // 36: riskengine.RiskAdminService.AddListEntry:input_type -> riskengine.AddListEntryRequest
+	17, // 37: riskengine.RiskAdminService.RemoveListEntry:input_type -> riskengine.RemoveListEntryRequest
+	19, // 38: riskengine.RiskAdminService.ListListEntries:input_type -> riskengine.ListListEntriesRequest
+	22, // 39: riskengine.RiskAdminService.UpsertMerchant:input_type -> riskengine.UpsertMerchantRequest
+	23, // 40: riskengine.RiskAdminService.RemoveMerchant:input_type -> riskengine.RemoveMerchantRequest
+	25, // 41: riskengine.RiskAdminService.ListMerchants:input_type -> riskengine.ListMerchantsRequest
+	28, // 42: riskengine.RiskReviewService.ListReviewCases:input_type -> riskengine.ListReviewCasesRequest
+	30, // 43: riskengine.RiskReviewService.GetReviewCase:input_type -> riskengine.GetReviewCaseRequest
+	31, // 44: riskengine.RiskReviewService.ClaimReviewCase:input_type -> riskengine.ClaimReviewCaseRequest
+	32, // 45: riskengine.RiskReviewService.SubmitReviewOutcome:input_type -> riskengine.SubmitReviewOutcomeRequest
+	33, // 46: riskengine.RiskFeedbackService.ReportOutcome:input_type -> riskengine.ReportOutcomeRequest
+	35, // 47: riskengine.RiskFeedbackService.ImportOutcomes:input_type -> riskengine.ImportOutcomesRequest
+	11, // 48: riskengine.RiskEngineService.AnalyzeTransaction:output_type -> riskengine.AnalyzeResponse
+	15, // 49: riskengine.RiskAdminService.AddListEntry:output_type -> riskengine.ListEntry
+	18, // 50: riskengine.RiskAdminService.RemoveListEntry:output_type -> riskengine.RemoveListEntryResponse
+	20, // 51: riskengine.RiskAdminService.ListListEntries:output_type -> riskengine.ListListEntriesResponse
+	21, // 52: riskengine.RiskAdminService.UpsertMerchant:output_type -> riskengine.Merchant
+	24, // 53: riskengine.RiskAdminService.RemoveMerchant:output_type -> riskengine.RemoveMerchantResponse
+	26, // 54: riskengine.RiskAdminService.ListMerchants:output_type -> riskengine.ListMerchantsResponse
+	29, // 55: riskengine.RiskReviewService.ListReviewCases:output_type -> riskengine.ListReviewCasesResponse
+	27, // 56: riskengine.RiskReviewService.GetReviewCase:output_type -> riskengine.ReviewCase
+	27, // 57: riskengine.RiskReviewService.ClaimReviewCase:output_type -> riskengine.ReviewCase
+	27, // 58: riskengine.RiskReviewService.SubmitReviewOutcome:output_type -> riskengine.ReviewCase
+	34, // 59: riskengine.RiskFeedbackService.ReportOutcome:output_type -> riskengine.ReportOutcomeResponse
+	36, // 60: riskengine.RiskFeedbackService.ImportOutcomes:output_type -> riskengine.ImportOutcomesResponse
+	48, // [48:61] is the sub-list for method output_type
+	35, // [35:48] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_risk_engine_proto_init() }
@@ -223,13 +2844,14 @@ func file_api_proto_risk_engine_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_risk_engine_proto_rawDesc), len(file_api_proto_risk_engine_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      9,
+			NumMessages:   29,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   4,
 		},
 		GoTypes:           file_api_proto_risk_engine_proto_goTypes,
 		DependencyIndexes: file_api_proto_risk_engine_proto_depIdxs,
+		EnumInfos:         file_api_proto_risk_engine_proto_enumTypes,
 		MessageInfos:      file_api_proto_risk_engine_proto_msgTypes,
 	}.Build()
 	File_api_proto_risk_engine_proto = out.File
@@ -90,3 +90,588 @@ var RiskEngineService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/risk_engine.proto",
 }
+
+const (
+	RiskAdminService_AddListEntry_FullMethodName    = "/riskengine.RiskAdminService/AddListEntry"
+	RiskAdminService_RemoveListEntry_FullMethodName = "/riskengine.RiskAdminService/RemoveListEntry"
+	RiskAdminService_ListListEntries_FullMethodName = "/riskengine.RiskAdminService/ListListEntries"
+	RiskAdminService_UpsertMerchant_FullMethodName  = "/riskengine.RiskAdminService/UpsertMerchant"
+	RiskAdminService_RemoveMerchant_FullMethodName  = "/riskengine.RiskAdminService/RemoveMerchant"
+	RiskAdminService_ListMerchants_FullMethodName   = "/riskengine.RiskAdminService/ListMerchants"
+)
+
+type RiskAdminServiceClient interface {
+	AddListEntry(ctx context.Context, in *AddListEntryRequest, opts ...grpc.CallOption) (*ListEntry, error)
+	RemoveListEntry(ctx context.Context, in *RemoveListEntryRequest, opts ...grpc.CallOption) (*RemoveListEntryResponse, error)
+	ListListEntries(ctx context.Context, in *ListListEntriesRequest, opts ...grpc.CallOption) (*ListListEntriesResponse, error)
+	UpsertMerchant(ctx context.Context, in *UpsertMerchantRequest, opts ...grpc.CallOption) (*Merchant, error)
+	RemoveMerchant(ctx context.Context, in *RemoveMerchantRequest, opts ...grpc.CallOption) (*RemoveMerchantResponse, error)
+	ListMerchants(ctx context.Context, in *ListMerchantsRequest, opts ...grpc.CallOption) (*ListMerchantsResponse, error)
+}
+
+type riskAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRiskAdminServiceClient(cc grpc.ClientConnInterface) RiskAdminServiceClient {
+	return &riskAdminServiceClient{cc}
+}
+
+func (c *riskAdminServiceClient) AddListEntry(ctx context.Context, in *AddListEntryRequest, opts ...grpc.CallOption) (*ListEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEntry)
+	err := c.cc.Invoke(ctx, RiskAdminService_AddListEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *riskAdminServiceClient) RemoveListEntry(ctx context.Context, in *RemoveListEntryRequest, opts ...grpc.CallOption) (*RemoveListEntryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveListEntryResponse)
+	err := c.cc.Invoke(ctx, RiskAdminService_RemoveListEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *riskAdminServiceClient) ListListEntries(ctx context.Context, in *ListListEntriesRequest, opts ...grpc.CallOption) (*ListListEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListListEntriesResponse)
+	err := c.cc.Invoke(ctx, RiskAdminService_ListListEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *riskAdminServiceClient) UpsertMerchant(ctx context.Context, in *UpsertMerchantRequest, opts ...grpc.CallOption) (*Merchant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Merchant)
+	err := c.cc.Invoke(ctx, RiskAdminService_UpsertMerchant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *riskAdminServiceClient) RemoveMerchant(ctx context.Context, in *RemoveMerchantRequest, opts ...grpc.CallOption) (*RemoveMerchantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveMerchantResponse)
+	err := c.cc.Invoke(ctx, RiskAdminService_RemoveMerchant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *riskAdminServiceClient) ListMerchants(ctx context.Context, in *ListMerchantsRequest, opts ...grpc.CallOption) (*ListMerchantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMerchantsResponse)
+	err := c.cc.Invoke(ctx, RiskAdminService_ListMerchants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type RiskAdminServiceServer interface {
+	AddListEntry(context.Context, *AddListEntryRequest) (*ListEntry, error)
+	RemoveListEntry(context.Context, *RemoveListEntryRequest) (*RemoveListEntryResponse, error)
+	ListListEntries(context.Context, *ListListEntriesRequest) (*ListListEntriesResponse, error)
+	UpsertMerchant(context.Context, *UpsertMerchantRequest) (*Merchant, error)
+	RemoveMerchant(context.Context, *RemoveMerchantRequest) (*RemoveMerchantResponse, error)
+	ListMerchants(context.Context, *ListMerchantsRequest) (*ListMerchantsResponse, error)
+	mustEmbedUnimplementedRiskAdminServiceServer()
+}
+
+type UnimplementedRiskAdminServiceServer struct{}
+
+func (UnimplementedRiskAdminServiceServer) AddListEntry(context.Context, *AddListEntryRequest) (*ListEntry, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddListEntry not implemented")
+}
+func (UnimplementedRiskAdminServiceServer) RemoveListEntry(context.Context, *RemoveListEntryRequest) (*RemoveListEntryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveListEntry not implemented")
+}
+func (UnimplementedRiskAdminServiceServer) ListListEntries(context.Context, *ListListEntriesRequest) (*ListListEntriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListListEntries not implemented")
+}
+func (UnimplementedRiskAdminServiceServer) UpsertMerchant(context.Context, *UpsertMerchantRequest) (*Merchant, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertMerchant not implemented")
+}
+func (UnimplementedRiskAdminServiceServer) RemoveMerchant(context.Context, *RemoveMerchantRequest) (*RemoveMerchantResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveMerchant not implemented")
+}
+func (UnimplementedRiskAdminServiceServer) ListMerchants(context.Context, *ListMerchantsRequest) (*ListMerchantsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMerchants not implemented")
+}
+func (UnimplementedRiskAdminServiceServer) mustEmbedUnimplementedRiskAdminServiceServer() {}
+func (UnimplementedRiskAdminServiceServer) testEmbeddedByValue()                          {}
+
+type UnsafeRiskAdminServiceServer interface {
+	mustEmbedUnimplementedRiskAdminServiceServer()
+}
+
+func RegisterRiskAdminServiceServer(s grpc.ServiceRegistrar, srv RiskAdminServiceServer) {
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RiskAdminService_ServiceDesc, srv)
+}
+
+func _RiskAdminService_AddListEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddListEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskAdminServiceServer).AddListEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskAdminService_AddListEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskAdminServiceServer).AddListEntry(ctx, req.(*AddListEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RiskAdminService_RemoveListEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveListEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskAdminServiceServer).RemoveListEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskAdminService_RemoveListEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskAdminServiceServer).RemoveListEntry(ctx, req.(*RemoveListEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RiskAdminService_ListListEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListListEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskAdminServiceServer).ListListEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskAdminService_ListListEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskAdminServiceServer).ListListEntries(ctx, req.(*ListListEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RiskAdminService_UpsertMerchant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertMerchantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskAdminServiceServer).UpsertMerchant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskAdminService_UpsertMerchant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskAdminServiceServer).UpsertMerchant(ctx, req.(*UpsertMerchantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RiskAdminService_RemoveMerchant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMerchantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskAdminServiceServer).RemoveMerchant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskAdminService_RemoveMerchant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskAdminServiceServer).RemoveMerchant(ctx, req.(*RemoveMerchantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RiskAdminService_ListMerchants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMerchantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskAdminServiceServer).ListMerchants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskAdminService_ListMerchants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskAdminServiceServer).ListMerchants(ctx, req.(*ListMerchantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var RiskAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "riskengine.RiskAdminService",
+	HandlerType: (*RiskAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddListEntry",
+			Handler:    _RiskAdminService_AddListEntry_Handler,
+		},
+		{
+			MethodName: "RemoveListEntry",
+			Handler:    _RiskAdminService_RemoveListEntry_Handler,
+		},
+		{
+			MethodName: "ListListEntries",
+			Handler:    _RiskAdminService_ListListEntries_Handler,
+		},
+		{
+			MethodName: "UpsertMerchant",
+			Handler:    _RiskAdminService_UpsertMerchant_Handler,
+		},
+		{
+			MethodName: "RemoveMerchant",
+			Handler:    _RiskAdminService_RemoveMerchant_Handler,
+		},
+		{
+			MethodName: "ListMerchants",
+			Handler:    _RiskAdminService_ListMerchants_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/risk_engine.proto",
+}
+
+const (
+	RiskReviewService_ListReviewCases_FullMethodName     = "/riskengine.RiskReviewService/ListReviewCases"
+	RiskReviewService_GetReviewCase_FullMethodName       = "/riskengine.RiskReviewService/GetReviewCase"
+	RiskReviewService_ClaimReviewCase_FullMethodName     = "/riskengine.RiskReviewService/ClaimReviewCase"
+	RiskReviewService_SubmitReviewOutcome_FullMethodName = "/riskengine.RiskReviewService/SubmitReviewOutcome"
+)
+
+type RiskReviewServiceClient interface {
+	ListReviewCases(ctx context.Context, in *ListReviewCasesRequest, opts ...grpc.CallOption) (*ListReviewCasesResponse, error)
+	GetReviewCase(ctx context.Context, in *GetReviewCaseRequest, opts ...grpc.CallOption) (*ReviewCase, error)
+	ClaimReviewCase(ctx context.Context, in *ClaimReviewCaseRequest, opts ...grpc.CallOption) (*ReviewCase, error)
+	SubmitReviewOutcome(ctx context.Context, in *SubmitReviewOutcomeRequest, opts ...grpc.CallOption) (*ReviewCase, error)
+}
+
+type riskReviewServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRiskReviewServiceClient(cc grpc.ClientConnInterface) RiskReviewServiceClient {
+	return &riskReviewServiceClient{cc}
+}
+
+func (c *riskReviewServiceClient) ListReviewCases(ctx context.Context, in *ListReviewCasesRequest, opts ...grpc.CallOption) (*ListReviewCasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReviewCasesResponse)
+	err := c.cc.Invoke(ctx, RiskReviewService_ListReviewCases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *riskReviewServiceClient) GetReviewCase(ctx context.Context, in *GetReviewCaseRequest, opts ...grpc.CallOption) (*ReviewCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewCase)
+	err := c.cc.Invoke(ctx, RiskReviewService_GetReviewCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *riskReviewServiceClient) ClaimReviewCase(ctx context.Context, in *ClaimReviewCaseRequest, opts ...grpc.CallOption) (*ReviewCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewCase)
+	err := c.cc.Invoke(ctx, RiskReviewService_ClaimReviewCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *riskReviewServiceClient) SubmitReviewOutcome(ctx context.Context, in *SubmitReviewOutcomeRequest, opts ...grpc.CallOption) (*ReviewCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewCase)
+	err := c.cc.Invoke(ctx, RiskReviewService_SubmitReviewOutcome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type RiskReviewServiceServer interface {
+	ListReviewCases(context.Context, *ListReviewCasesRequest) (*ListReviewCasesResponse, error)
+	GetReviewCase(context.Context, *GetReviewCaseRequest) (*ReviewCase, error)
+	ClaimReviewCase(context.Context, *ClaimReviewCaseRequest) (*ReviewCase, error)
+	SubmitReviewOutcome(context.Context, *SubmitReviewOutcomeRequest) (*ReviewCase, error)
+	mustEmbedUnimplementedRiskReviewServiceServer()
+}
+
+type UnimplementedRiskReviewServiceServer struct{}
+
+func (UnimplementedRiskReviewServiceServer) ListReviewCases(context.Context, *ListReviewCasesRequest) (*ListReviewCasesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReviewCases not implemented")
+}
+func (UnimplementedRiskReviewServiceServer) GetReviewCase(context.Context, *GetReviewCaseRequest) (*ReviewCase, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReviewCase not implemented")
+}
+func (UnimplementedRiskReviewServiceServer) ClaimReviewCase(context.Context, *ClaimReviewCaseRequest) (*ReviewCase, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClaimReviewCase not implemented")
+}
+func (UnimplementedRiskReviewServiceServer) SubmitReviewOutcome(context.Context, *SubmitReviewOutcomeRequest) (*ReviewCase, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitReviewOutcome not implemented")
+}
+func (UnimplementedRiskReviewServiceServer) mustEmbedUnimplementedRiskReviewServiceServer() {}
+func (UnimplementedRiskReviewServiceServer) testEmbeddedByValue()                           {}
+
+type UnsafeRiskReviewServiceServer interface {
+	mustEmbedUnimplementedRiskReviewServiceServer()
+}
+
+func RegisterRiskReviewServiceServer(s grpc.ServiceRegistrar, srv RiskReviewServiceServer) {
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RiskReviewService_ServiceDesc, srv)
+}
+
+func _RiskReviewService_ListReviewCases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewCasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskReviewServiceServer).ListReviewCases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskReviewService_ListReviewCases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskReviewServiceServer).ListReviewCases(ctx, req.(*ListReviewCasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RiskReviewService_GetReviewCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReviewCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskReviewServiceServer).GetReviewCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskReviewService_GetReviewCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskReviewServiceServer).GetReviewCase(ctx, req.(*GetReviewCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RiskReviewService_ClaimReviewCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimReviewCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskReviewServiceServer).ClaimReviewCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskReviewService_ClaimReviewCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskReviewServiceServer).ClaimReviewCase(ctx, req.(*ClaimReviewCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RiskReviewService_SubmitReviewOutcome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitReviewOutcomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskReviewServiceServer).SubmitReviewOutcome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskReviewService_SubmitReviewOutcome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskReviewServiceServer).SubmitReviewOutcome(ctx, req.(*SubmitReviewOutcomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var RiskReviewService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "riskengine.RiskReviewService",
+	HandlerType: (*RiskReviewServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListReviewCases",
+			Handler:    _RiskReviewService_ListReviewCases_Handler,
+		},
+		{
+			MethodName: "GetReviewCase",
+			Handler:    _RiskReviewService_GetReviewCase_Handler,
+		},
+		{
+			MethodName: "ClaimReviewCase",
+			Handler:    _RiskReviewService_ClaimReviewCase_Handler,
+		},
+		{
+			MethodName: "SubmitReviewOutcome",
+			Handler:    _RiskReviewService_SubmitReviewOutcome_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/risk_engine.proto",
+}
+
+const (
+	RiskFeedbackService_ReportOutcome_FullMethodName  = "/riskengine.RiskFeedbackService/ReportOutcome"
+	RiskFeedbackService_ImportOutcomes_FullMethodName = "/riskengine.RiskFeedbackService/ImportOutcomes"
+)
+
+type RiskFeedbackServiceClient interface {
+	ReportOutcome(ctx context.Context, in *ReportOutcomeRequest, opts ...grpc.CallOption) (*ReportOutcomeResponse, error)
+	ImportOutcomes(ctx context.Context, in *ImportOutcomesRequest, opts ...grpc.CallOption) (*ImportOutcomesResponse, error)
+}
+
+type riskFeedbackServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRiskFeedbackServiceClient(cc grpc.ClientConnInterface) RiskFeedbackServiceClient {
+	return &riskFeedbackServiceClient{cc}
+}
+
+func (c *riskFeedbackServiceClient) ReportOutcome(ctx context.Context, in *ReportOutcomeRequest, opts ...grpc.CallOption) (*ReportOutcomeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportOutcomeResponse)
+	err := c.cc.Invoke(ctx, RiskFeedbackService_ReportOutcome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *riskFeedbackServiceClient) ImportOutcomes(ctx context.Context, in *ImportOutcomesRequest, opts ...grpc.CallOption) (*ImportOutcomesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportOutcomesResponse)
+	err := c.cc.Invoke(ctx, RiskFeedbackService_ImportOutcomes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type RiskFeedbackServiceServer interface {
+	ReportOutcome(context.Context, *ReportOutcomeRequest) (*ReportOutcomeResponse, error)
+	ImportOutcomes(context.Context, *ImportOutcomesRequest) (*ImportOutcomesResponse, error)
+	mustEmbedUnimplementedRiskFeedbackServiceServer()
+}
+
+type UnimplementedRiskFeedbackServiceServer struct{}
+
+func (UnimplementedRiskFeedbackServiceServer) ReportOutcome(context.Context, *ReportOutcomeRequest) (*ReportOutcomeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportOutcome not implemented")
+}
+func (UnimplementedRiskFeedbackServiceServer) ImportOutcomes(context.Context, *ImportOutcomesRequest) (*ImportOutcomesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportOutcomes not implemented")
+}
+func (UnimplementedRiskFeedbackServiceServer) mustEmbedUnimplementedRiskFeedbackServiceServer() {}
+func (UnimplementedRiskFeedbackServiceServer) testEmbeddedByValue()                             {}
+
+type UnsafeRiskFeedbackServiceServer interface {
+	mustEmbedUnimplementedRiskFeedbackServiceServer()
+}
+
+func RegisterRiskFeedbackServiceServer(s grpc.ServiceRegistrar, srv RiskFeedbackServiceServer) {
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RiskFeedbackService_ServiceDesc, srv)
+}
+
+func _RiskFeedbackService_ReportOutcome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportOutcomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskFeedbackServiceServer).ReportOutcome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskFeedbackService_ReportOutcome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskFeedbackServiceServer).ReportOutcome(ctx, req.(*ReportOutcomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RiskFeedbackService_ImportOutcomes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportOutcomesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskFeedbackServiceServer).ImportOutcomes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RiskFeedbackService_ImportOutcomes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskFeedbackServiceServer).ImportOutcomes(ctx, req.(*ImportOutcomesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var RiskFeedbackService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "riskengine.RiskFeedbackService",
+	HandlerType: (*RiskFeedbackServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportOutcome",
+			Handler:    _RiskFeedbackService_ReportOutcome_Handler,
+		},
+		{
+			MethodName: "ImportOutcomes",
+			Handler:    _RiskFeedbackService_ImportOutcomes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/risk_engine.proto",
+}
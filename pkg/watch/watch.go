@@ -0,0 +1,91 @@
+// Package watch calls back when files change on disk.
+package watch
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/tokyosplif/ai-risk-engine/pkg/closer"
+)
+
+// Files calls reload with the path of a file in paths each time it is
+// written, created or replaced, until ctx is done. It watches the files'
+// directories rather than the files, so it keeps working when an editor saves
+// by renaming a temporary file, when a Kubernetes volume swaps its ..data
+// symlink and when a file that was missing at startup is created. name is used
+// in logs.
+func Files(ctx context.Context, name string, reload func(path string), paths ...string) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		slog.Error("failed to create watcher", "watcher", name, "err", err)
+		return
+	}
+
+	defer closer.Close(watcher, "fsnotify watcher")
+
+	dirs := make(map[string][]string)
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		dir := filepath.Dir(path)
+		if _, ok := dirs[dir]; !ok {
+			if err := watcher.Add(dir); err != nil {
+				slog.Warn("file is not watched, changes need a restart", "watcher", name, "path", path, "err", err)
+				continue
+			}
+		}
+		dirs[dir] = append(dirs[dir], path)
+	}
+	if len(dirs) == 0 {
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			for _, path := range affected(dirs, event.Name) {
+				// A rename away or a half-done symlink swap leaves nothing to
+				// load yet; the Create that follows reloads.
+				if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				slog.Info("Detected file change, reloading...", "watcher", name, "path", path)
+				reload(path)
+			}
+		case e, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Error("watcher error", "watcher", name, "err", e)
+		case <-ctx.Done():
+			slog.Debug("stopping watcher", "watcher", name)
+			return
+		}
+	}
+}
+
+// affected returns the watched paths a change to name may have changed: the
+// file itself, or every file in the directory when name is the ..data
+// symlink of a Kubernetes volume.
+func affected(dirs map[string][]string, name string) []string {
+	name = filepath.Clean(name)
+	paths := dirs[filepath.Dir(name)]
+	if filepath.Base(name) == "..data" {
+		return paths
+	}
+	for _, path := range paths {
+		if path == name {
+			return []string{path}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func startFiles(t *testing.T, paths ...string) <-chan string {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	reloads := make(chan string, 16)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Files(ctx, "test", func(path string) { reloads <- path }, paths...)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	// Give the watcher time to register its directories.
+	time.Sleep(50 * time.Millisecond)
+	return reloads
+}
+
+func expectReload(t *testing.T, reloads <-chan string, want string) {
+	t.Helper()
+	select {
+	case got := <-reloads:
+		if got != want {
+			t.Errorf("Expected a reload of %s, got %s", want, got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Expected a reload of %s", want)
+	}
+	// Drain the other events of the same change.
+	for {
+		select {
+		case <-reloads:
+		case <-time.After(50 * time.Millisecond):
+			return
+		}
+	}
+}
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	other := filepath.Join(dir, "other.json")
+	reloads := startFiles(t, path)
+
+	// Created after the watch started.
+	if err := os.WriteFile(path, []byte("1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	expectReload(t, reloads, path)
+
+	// Written in place.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("2")
+	f.Close()
+	expectReload(t, reloads, path)
+
+	// Replaced by renaming a temporary file over it, as editors save.
+	tmp := filepath.Join(dir, "config.json.tmp")
+	if err := os.WriteFile(tmp, []byte("3"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	expectReload(t, reloads, path)
+
+	// Other files in the directory are ignored.
+	if err := os.WriteFile(other, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-reloads:
+		t.Errorf("Expected no reload for an unwatched file, got %s", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestFiles_KubernetesVolumeSwap(t *testing.T) {
+	dir := t.TempDir()
+	for _, v := range []string{"v1", "v2"} {
+		if err := os.Mkdir(filepath.Join(dir, v), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, v, "tenants.json"), []byte(v), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink("v1", filepath.Join(dir, "..data")); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "tenants.json")
+	if err := os.Symlink(filepath.Join("..data", "tenants.json"), path); err != nil {
+		t.Fatal(err)
+	}
+	reloads := startFiles(t, path)
+
+	// The kubelet points a new symlink at the new version and renames it
+	// over ..data.
+	if err := os.Symlink("v2", filepath.Join(dir, "..data_tmp")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")); err != nil {
+		t.Fatal(err)
+	}
+	expectReload(t, reloads, path)
+
+	if data, _ := os.ReadFile(path); string(data) != "v2" {
+		t.Errorf("Expected the new version behind the symlink, got %q", data)
+	}
+}